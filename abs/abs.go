@@ -0,0 +1,124 @@
+package abs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+)
+
+// Client is a client of the audiobookshelf api.
+// https://api.audiobookshelf.org
+type Client struct {
+	client *http.Client
+	absUrl *url.URL
+	token  string
+}
+
+// URL returns a clone of the audiobookshelf url used by the client
+func (c *Client) URL() *url.URL { return utils.CloneURL(c.absUrl) }
+
+// do makes a request to the audiobookshelf api, encoding body (if not nil) as json
+// and decoding the response into target (if not nil).
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	path string,
+	parameters url.Values,
+	body any,
+	target any,
+) error {
+	requestUrl := c.URL().JoinPath(path)
+	requestUrl.RawQuery = parameters.Encode()
+
+	var requestBody io.Reader = http.NoBody
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, requestUrl.String(), requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer response.Body.Close()
+
+	httpError := utils.HTTPResponseError(response)
+	if httpError != nil {
+		return httpError
+	}
+
+	if target == nil {
+		return nil
+	}
+
+	err = json.NewDecoder(response.Body).Decode(target)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return nil
+}
+
+// Libraries gets the libraries of the server.
+// https://api.audiobookshelf.org/#get-all-libraries
+func (c *Client) Libraries(ctx context.Context) ([]Library, error) {
+	var result struct {
+		Libraries []Library `json:"libraries"`
+	}
+	err := c.do(ctx, http.MethodGet, "api/libraries", nil, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Libraries, nil
+}
+
+// LibraryItems gets a page of the items of a library. Pages start at 0.
+// https://api.audiobookshelf.org/#get-a-library-39-s-items
+func (c *Client) LibraryItems(ctx context.Context, libraryId string, page, limit int) (LibraryItemsPage, error) {
+	parameters := url.Values{}
+	parameters.Set("page", strconv.Itoa(page))
+	parameters.Set("limit", strconv.Itoa(limit))
+	parameters.Set("minified", "0")
+
+	var result LibraryItemsPage
+	err := c.do(ctx, http.MethodGet, "api/libraries/"+url.PathEscape(libraryId)+"/items", parameters, nil, &result)
+	if err != nil {
+		return LibraryItemsPage{}, err
+	}
+
+	return result, nil
+}
+
+// UpdateMedia updates the metadata and tags of the media of a library item.
+// Only the fields set in the update are changed.
+// https://api.audiobookshelf.org/#update-a-library-item-39-s-media
+func (c *Client) UpdateMedia(ctx context.Context, itemId string, update MediaUpdate) error {
+	return c.do(ctx, http.MethodPatch, "api/items/"+url.PathEscape(itemId)+"/media", nil, update, nil)
+}
+
+// UpdateCover sets the cover of a library item to the image at a url, which the server downloads.
+// https://api.audiobookshelf.org/#upload-a-library-item-cover
+func (c *Client) UpdateCover(ctx context.Context, itemId, coverUrl string) error {
+	body := map[string]string{"url": coverUrl}
+	return c.do(ctx, http.MethodPost, "api/items/"+url.PathEscape(itemId)+"/cover", nil, body, nil)
+}
@@ -0,0 +1,151 @@
+package abs_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/abs"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "test-token"
+
+// stubServer is a stub audiobookshelf server with a single library, recording updates of its items.
+type stubServer struct {
+	*httptest.Server
+
+	items []abs.LibraryItem
+
+	mutex   sync.Mutex
+	updates map[string]abs.MediaUpdate
+	covers  map[string]string
+}
+
+func newStubServer(t *testing.T, items []abs.LibraryItem) *stubServer {
+	stub := &stubServer{
+		items:   items,
+		updates: make(map[string]abs.MediaUpdate),
+		covers:  make(map[string]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/libraries", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"libraries": []abs.Library{{Id: "library", Name: "Audiobooks", MediaType: "book"}},
+		})
+	})
+	mux.HandleFunc("GET /api/libraries/{id}/items", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "library" {
+			http.NotFound(w, r)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start := min(page*limit, len(stub.items))
+		end := min(start+limit, len(stub.items))
+		writeJSON(w, abs.LibraryItemsPage{Results: stub.items[start:end], Total: len(stub.items), Limit: limit, Page: page})
+	})
+	mux.HandleFunc("PATCH /api/items/{id}/media", func(w http.ResponseWriter, r *http.Request) {
+		var update abs.MediaUpdate
+		err := json.NewDecoder(r.Body).Decode(&update)
+		require.NoError(t, err)
+
+		stub.mutex.Lock()
+		stub.updates[r.PathValue("id")] = update
+		stub.mutex.Unlock()
+		writeJSON(w, map[string]any{"updated": true})
+	})
+	mux.HandleFunc("POST /api/items/{id}/cover", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			URL string `json:"url"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		require.NoError(t, err)
+
+		stub.mutex.Lock()
+		stub.covers[r.PathValue("id")] = body.URL
+		stub.mutex.Unlock()
+		writeJSON(w, map[string]any{"success": true})
+	})
+
+	stub.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(stub.Close)
+
+	return stub
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func newTestClient(t *testing.T, stub *stubServer) *abs.Client {
+	client, err := abs.NewClient(stub.URL, testToken)
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClientInvalid(t *testing.T) {
+	_, err := abs.NewClient("", testToken)
+	require.Error(t, err)
+
+	_, err = abs.NewClient("http://localhost:13378", " ")
+	require.Error(t, err)
+}
+
+func TestLibraries(t *testing.T) {
+	stub := newStubServer(t, nil)
+	client := newTestClient(t, stub)
+
+	libraries, err := client.Libraries(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []abs.Library{{Id: "library", Name: "Audiobooks", MediaType: "book"}}, libraries)
+
+	// Requests with an invalid token are unauthorized
+	client, err = abs.NewClient(stub.URL, "invalid")
+	require.NoError(t, err)
+	_, err = client.Libraries(context.Background())
+	require.ErrorContains(t, err, "401")
+}
+
+func TestLibraryItems(t *testing.T) {
+	stub := newStubServer(t, testItems)
+	client := newTestClient(t, stub)
+
+	itemsPage, err := client.LibraryItems(context.Background(), "library", 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, len(testItems), itemsPage.Total)
+	require.Len(t, itemsPage.Results, 1)
+	require.Equal(t, testItems[1], itemsPage.Results[0])
+
+	_, err = client.LibraryItems(context.Background(), "unknown", 0, 1)
+	require.Error(t, err)
+}
+
+func TestUpdateMedia(t *testing.T) {
+	stub := newStubServer(t, testItems)
+	client := newTestClient(t, stub)
+
+	update := abs.MediaUpdate{
+		Metadata: &abs.MetadataUpdate{Series: &[]abs.Series{{Name: "Middle Earth", Sequence: "0"}}},
+		Tags:     &[]string{"Fantasy"},
+	}
+	err := client.UpdateMedia(context.Background(), "hobbit", update)
+	require.NoError(t, err)
+	require.Equal(t, update, stub.updates["hobbit"])
+
+	err = client.UpdateCover(context.Background(), "hobbit", "https://example.com/cover.jpg")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/cover.jpg", stub.covers["hobbit"])
+}
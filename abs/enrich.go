@@ -0,0 +1,295 @@
+package abs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/orsinium-labs/enum"
+	"github.com/samber/lo"
+)
+
+// Number of library items got per request when walking a library
+const libraryItemsPageSize = 100
+
+// Field is a field of the metadata of a library item that can be enriched.
+type Field enum.Member[string]
+
+var (
+	fieldEnum = enum.NewBuilder[string, Field]()
+
+	FieldTitle         = fieldEnum.Add(Field{"title"})
+	FieldSubtitle      = fieldEnum.Add(Field{"subtitle"})
+	FieldAuthors       = fieldEnum.Add(Field{"authors"})
+	FieldSeries        = fieldEnum.Add(Field{"series"})
+	FieldGenres        = fieldEnum.Add(Field{"genres"})
+	FieldTags          = fieldEnum.Add(Field{"tags"})
+	FieldPublishedYear = fieldEnum.Add(Field{"published-year"})
+	FieldPublisher     = fieldEnum.Add(Field{"publisher"})
+	FieldDescription   = fieldEnum.Add(Field{"description"})
+	FieldISBN          = fieldEnum.Add(Field{"isbn"})
+	FieldASIN          = fieldEnum.Add(Field{"asin"})
+	FieldLanguage      = fieldEnum.Add(Field{"language"})
+	FieldCover         = fieldEnum.Add(Field{"cover"})
+
+	Fields = fieldEnum.Enum()
+
+	// DefaultFields are the fields enriched if none are set. Items are matched
+	// using their title and authors, so these are not changed by default.
+	DefaultFields = []Field{
+		FieldSubtitle,
+		FieldSeries,
+		FieldGenres,
+		FieldTags,
+		FieldPublishedYear,
+		FieldPublisher,
+		FieldDescription,
+		FieldISBN,
+		FieldASIN,
+		FieldLanguage,
+		FieldCover,
+	}
+)
+
+// Book is the metadata of a book matched to a library item, used to enrich it.
+// Unset fields are empty, and are never written to items.
+type Book struct {
+	Title         string
+	Subtitle      string
+	Authors       []string
+	Series        []Series
+	Genres        []string
+	Tags          []string
+	PublishedYear string
+	Publisher     string
+	Description   string
+	ISBN          string
+	ASIN          string
+	Language      string
+	// Cover is the url of the cover of the book
+	Cover string
+}
+
+// MatchFunc matches a library item to a book. If the item has no match, nil is returned.
+type MatchFunc func(ctx context.Context, item LibraryItem) (*Book, error)
+
+// EnrichOptions are the options of enriching a library.
+type EnrichOptions struct {
+	// Fields are the fields of items that are enriched. If empty, DefaultFields are enriched
+	Fields []Field
+	// Overwrite is whether fields items already have are changed. If false, only empty fields are set
+	Overwrite bool
+	// DryRun is whether changes are only reported, without updating items
+	DryRun bool
+}
+
+// Report is a report of the changes made enriching a library.
+type Report struct {
+	LibraryId string
+	DryRun    bool
+	Items     []ItemReport
+}
+
+// ItemReport is a report of the changes made enriching a library item.
+type ItemReport struct {
+	ItemId string
+	Title  string
+	// Matched is whether a book was matched to the item
+	Matched bool
+	Changes []Change
+	// Updated is whether the changes were written to the item. Always false for dry runs
+	Updated bool
+	// Error is the error matching or updating the item. Empty if there was no error
+	Error string
+}
+
+// Change is a change of a field of a library item. Lists are given as comma separated text.
+type Change struct {
+	Field Field
+	Old   string
+	New   string
+}
+
+// EnrichLibrary walks the books of a library, matching each to a book and writing the fields of the book
+// to it. Items are matched one at a time, so providers are not sent a burst of requests. Items that fail
+// to be matched or updated are reported, without stopping the walk.
+// Will return an error if the items of the library can not be got.
+func (c *Client) EnrichLibrary(
+	ctx context.Context,
+	libraryId string,
+	match MatchFunc,
+	options EnrichOptions,
+) (Report, error) {
+	fields := options.Fields
+	if len(fields) == 0 {
+		fields = DefaultFields
+	}
+
+	report := Report{LibraryId: libraryId, DryRun: options.DryRun}
+	for page := 0; ; page++ {
+		itemsPage, err := c.LibraryItems(ctx, libraryId, page, libraryItemsPageSize)
+		if err != nil {
+			return Report{}, fmt.Errorf("failed to get library items: %w", err)
+		}
+
+		for _, item := range itemsPage.Results {
+			if item.MediaType != "book" {
+				continue
+			}
+			if ctx.Err() != nil {
+				return Report{}, ctx.Err()
+			}
+			report.Items = append(report.Items, c.enrichItem(ctx, item, match, fields, options))
+		}
+
+		if len(itemsPage.Results) == 0 || (page+1)*libraryItemsPageSize >= itemsPage.Total {
+			break
+		}
+	}
+
+	return report, nil
+}
+
+func (c *Client) enrichItem(
+	ctx context.Context,
+	item LibraryItem,
+	match MatchFunc,
+	fields []Field,
+	options EnrichOptions,
+) ItemReport {
+	itemReport := ItemReport{ItemId: item.Id, Title: item.Media.Metadata.Title}
+
+	book, err := match(ctx, item)
+	if err != nil {
+		itemReport.Error = err.Error()
+		return itemReport
+	}
+	if book == nil {
+		return itemReport
+	}
+	itemReport.Matched = true
+
+	update, coverUrl, changes := itemChanges(item, *book, fields, options.Overwrite)
+	itemReport.Changes = changes
+	if options.DryRun || len(changes) == 0 {
+		return itemReport
+	}
+
+	if update.Metadata != nil || update.Tags != nil {
+		err := c.UpdateMedia(ctx, item.Id, update)
+		if err != nil {
+			itemReport.Error = fmt.Sprintf("failed to update item: %s", err)
+			return itemReport
+		}
+	}
+
+	if coverUrl != "" {
+		err := c.UpdateCover(ctx, item.Id, coverUrl)
+		if err != nil {
+			itemReport.Error = fmt.Sprintf("failed to update cover: %s", err)
+			return itemReport
+		}
+	}
+	itemReport.Updated = true
+
+	return itemReport
+}
+
+// itemChanges gets the changes of the fields of an item to the fields of a book, and the update and
+// cover url that make them. Fields the book does not have are not changed, and fields the item already
+// has are only changed if overwriting.
+func itemChanges(item LibraryItem, book Book, fields []Field, overwrite bool) (MediaUpdate, string, []Change) {
+	metadata := item.Media.Metadata
+
+	var changes []Change
+	changed := func(field Field, current, new string) bool {
+		if new == "" || new == current || (current != "" && !overwrite) {
+			return false
+		}
+		changes = append(changes, Change{Field: field, Old: current, New: new})
+		return true
+	}
+
+	var metadataUpdate MetadataUpdate
+	var update MediaUpdate
+	var coverUrl string
+	for _, field := range lo.Uniq(fields) {
+		switch field {
+		case FieldTitle:
+			if changed(field, metadata.Title, book.Title) {
+				metadataUpdate.Title = &book.Title
+			}
+		case FieldSubtitle:
+			if changed(field, metadata.Subtitle, book.Subtitle) {
+				metadataUpdate.Subtitle = &book.Subtitle
+			}
+		case FieldAuthors:
+			authorNames := lo.Map(metadata.Authors, func(author Author, _ int) string { return author.Name })
+			if changed(field, strings.Join(authorNames, ", "), strings.Join(book.Authors, ", ")) {
+				metadataUpdate.Authors = lo.ToPtr(lo.Map(book.Authors, func(name string, _ int) Author {
+					return Author{Name: name}
+				}))
+			}
+		case FieldSeries:
+			if changed(field, seriesText(metadata.Series), seriesText(book.Series)) {
+				metadataUpdate.Series = &book.Series
+			}
+		case FieldGenres:
+			if changed(field, strings.Join(metadata.Genres, ", "), strings.Join(book.Genres, ", ")) {
+				metadataUpdate.Genres = &book.Genres
+			}
+		case FieldTags:
+			if changed(field, strings.Join(item.Media.Tags, ", "), strings.Join(book.Tags, ", ")) {
+				update.Tags = &book.Tags
+			}
+		case FieldPublishedYear:
+			if changed(field, metadata.PublishedYear, book.PublishedYear) {
+				metadataUpdate.PublishedYear = &book.PublishedYear
+			}
+		case FieldPublisher:
+			if changed(field, metadata.Publisher, book.Publisher) {
+				metadataUpdate.Publisher = &book.Publisher
+			}
+		case FieldDescription:
+			if changed(field, metadata.Description, book.Description) {
+				metadataUpdate.Description = &book.Description
+			}
+		case FieldISBN:
+			if changed(field, metadata.ISBN, book.ISBN) {
+				metadataUpdate.ISBN = &book.ISBN
+			}
+		case FieldASIN:
+			if changed(field, metadata.ASIN, book.ASIN) {
+				metadataUpdate.ASIN = &book.ASIN
+			}
+		case FieldLanguage:
+			if changed(field, metadata.Language, book.Language) {
+				metadataUpdate.Language = &book.Language
+			}
+		case FieldCover:
+			// The cover of an item is a path on the server, so it is never the same as the cover url of a book
+			if changed(field, item.Media.CoverPath, book.Cover) {
+				coverUrl = book.Cover
+			}
+		}
+	}
+
+	if metadataUpdate != (MetadataUpdate{}) {
+		update.Metadata = &metadataUpdate
+	}
+
+	return update, coverUrl, changes
+}
+
+// seriesText gets series as comma separated text e.g. "Discworld #1, Rincewind #1".
+func seriesText(series []Series) string {
+	seriesNames := make([]string, 0, len(series))
+	for _, seriesSingle := range series {
+		seriesName := seriesSingle.Name
+		if seriesSingle.Sequence != "" {
+			seriesName += " #" + seriesSingle.Sequence
+		}
+		seriesNames = append(seriesNames, seriesName)
+	}
+	return strings.Join(seriesNames, ", ")
+}
@@ -0,0 +1,140 @@
+package abs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/abs"
+	"github.com/stretchr/testify/require"
+)
+
+var testItems = []abs.LibraryItem{
+	{
+		Id:        "hobbit",
+		MediaType: "book",
+		Media: abs.Media{
+			Metadata: abs.Metadata{
+				Title:       "The Hobbit",
+				Authors:     []abs.Author{{Id: "tolkien", Name: "J.R.R. Tolkien"}},
+				Description: "A hobbit goes on an adventure",
+			},
+		},
+	},
+	{
+		Id:        "unknown",
+		MediaType: "book",
+		Media:     abs.Media{Metadata: abs.Metadata{Title: "Unknown"}},
+	},
+	{
+		Id:        "error",
+		MediaType: "book",
+		Media:     abs.Media{Metadata: abs.Metadata{Title: "Error"}},
+	},
+	{
+		Id:        "podcast",
+		MediaType: "podcast",
+		Media:     abs.Media{Metadata: abs.Metadata{Title: "The Hobbit Podcast"}},
+	},
+}
+
+var testBook = abs.Book{
+	Title:         "The Hobbit, or There and Back Again",
+	Authors:       []string{"J.R.R. Tolkien"},
+	Series:        []abs.Series{{Name: "Middle Earth", Sequence: "0"}},
+	Genres:        []string{"Fantasy", "Classic"},
+	PublishedYear: "1937",
+	Description:   "In a hole in the ground there lived a hobbit.",
+	ISBN:          "9780618260300",
+	Cover:         "https://example.com/hobbit.jpg",
+}
+
+// testMatch matches items titled "The Hobbit" to the test book. Items titled "Error" fail to match.
+func testMatch(_ context.Context, item abs.LibraryItem) (*abs.Book, error) {
+	switch item.Media.Metadata.Title {
+	case "The Hobbit":
+		return &testBook, nil
+	case "Error":
+		return nil, errors.New("failed to match")
+	default:
+		return nil, nil
+	}
+}
+
+func TestEnrichLibraryDryRun(t *testing.T) {
+	stub := newStubServer(t, testItems)
+	client := newTestClient(t, stub)
+
+	report, err := client.EnrichLibrary(context.Background(), "library", testMatch, abs.EnrichOptions{DryRun: true})
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+
+	// Podcasts are not enriched
+	require.Len(t, report.Items, 3)
+
+	hobbitReport := report.Items[0]
+	require.Equal(t, "hobbit", hobbitReport.ItemId)
+	require.True(t, hobbitReport.Matched)
+	require.False(t, hobbitReport.Updated)
+	require.Equal(t, []abs.Change{
+		{Field: abs.FieldSeries, New: "Middle Earth #0"},
+		{Field: abs.FieldGenres, New: "Fantasy, Classic"},
+		{Field: abs.FieldPublishedYear, New: "1937"},
+		{Field: abs.FieldISBN, New: "9780618260300"},
+		{Field: abs.FieldCover, New: "https://example.com/hobbit.jpg"},
+	}, hobbitReport.Changes)
+
+	require.False(t, report.Items[1].Matched)
+	require.Empty(t, report.Items[1].Changes)
+	require.Equal(t, "failed to match", report.Items[2].Error)
+
+	// Nothing is written
+	require.Empty(t, stub.updates)
+	require.Empty(t, stub.covers)
+}
+
+func TestEnrichLibrary(t *testing.T) {
+	stub := newStubServer(t, testItems)
+	client := newTestClient(t, stub)
+
+	report, err := client.EnrichLibrary(context.Background(), "library", testMatch, abs.EnrichOptions{
+		Fields:    []abs.Field{abs.FieldTitle, abs.FieldAuthors, abs.FieldDescription, abs.FieldCover},
+		Overwrite: true,
+	})
+	require.NoError(t, err)
+	require.True(t, report.Items[0].Updated)
+
+	// Only the selected fields that differ are changed
+	require.Equal(t, []abs.Change{
+		{Field: abs.FieldTitle, Old: "The Hobbit", New: testBook.Title},
+		{Field: abs.FieldDescription, Old: "A hobbit goes on an adventure", New: testBook.Description},
+		{Field: abs.FieldCover, New: testBook.Cover},
+	}, report.Items[0].Changes)
+
+	require.Equal(t, map[string]abs.MediaUpdate{
+		"hobbit": {
+			Metadata: &abs.MetadataUpdate{
+				Title:       &testBook.Title,
+				Description: &testBook.Description,
+			},
+		},
+	}, stub.updates)
+	require.Equal(t, map[string]string{"hobbit": testBook.Cover}, stub.covers)
+}
+
+func TestEnrichLibraryPages(t *testing.T) {
+	// Enough items to need several pages
+	items := make([]abs.LibraryItem, 250)
+	for idx := range items {
+		items[idx] = testItems[1]
+	}
+	stub := newStubServer(t, items)
+	client := newTestClient(t, stub)
+
+	report, err := client.EnrichLibrary(context.Background(), "library", testMatch, abs.EnrichOptions{})
+	require.NoError(t, err)
+	require.Len(t, report.Items, 250)
+
+	_, err = client.EnrichLibrary(context.Background(), "unknown", testMatch, abs.EnrichOptions{})
+	require.Error(t, err)
+}
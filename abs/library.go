@@ -0,0 +1,85 @@
+package abs
+
+// Library is a library of an audiobookshelf server.
+type Library struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	// MediaType is the type of media of the library. Either "book" or "podcast"
+	MediaType string `json:"mediaType"`
+}
+
+// LibraryItemsPage is a page of the items of a library.
+type LibraryItemsPage struct {
+	Results []LibraryItem `json:"results"`
+	Total   int           `json:"total"`
+	Limit   int           `json:"limit"`
+	Page    int           `json:"page"`
+}
+
+// LibraryItem is an item of a library e.g. a book.
+type LibraryItem struct {
+	Id        string `json:"id"`
+	LibraryId string `json:"libraryId"`
+	// MediaType is the type of media of the item. Either "book" or "podcast"
+	MediaType string `json:"mediaType"`
+	Media     Media  `json:"media"`
+}
+
+// Media is the media of a library item.
+type Media struct {
+	Metadata  Metadata `json:"metadata"`
+	Tags      []string `json:"tags"`
+	CoverPath string   `json:"coverPath"`
+}
+
+// Metadata is the metadata of the media of a library item.
+// Unset fields are empty.
+type Metadata struct {
+	Title         string   `json:"title"`
+	Subtitle      string   `json:"subtitle"`
+	Authors       []Author `json:"authors"`
+	Narrators     []string `json:"narrators"`
+	Series        []Series `json:"series"`
+	Genres        []string `json:"genres"`
+	PublishedYear string   `json:"publishedYear"`
+	Publisher     string   `json:"publisher"`
+	Description   string   `json:"description"`
+	ISBN          string   `json:"isbn"`
+	ASIN          string   `json:"asin"`
+	Language      string   `json:"language"`
+}
+
+// Author is an author of a book. The id is empty for authors that do not exist yet.
+type Author struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// Series is a series of a book. The id is empty for series that do not exist yet.
+type Series struct {
+	Id       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Sequence string `json:"sequence,omitempty"`
+}
+
+// MediaUpdate is an update of the media of a library item. Fields that are nil are not changed.
+type MediaUpdate struct {
+	Metadata *MetadataUpdate `json:"metadata,omitempty"`
+	Tags     *[]string       `json:"tags,omitempty"`
+}
+
+// MetadataUpdate is an update of the metadata of the media of a library item. Fields that are nil are not changed.
+// Authors and series that do not exist are created.
+type MetadataUpdate struct {
+	Title         *string   `json:"title,omitempty"`
+	Subtitle      *string   `json:"subtitle,omitempty"`
+	Authors       *[]Author `json:"authors,omitempty"`
+	Series        *[]Series `json:"series,omitempty"`
+	Genres        *[]string `json:"genres,omitempty"`
+	PublishedYear *string   `json:"publishedYear,omitempty"`
+	Publisher     *string   `json:"publisher,omitempty"`
+	Description   *string   `json:"description,omitempty"`
+	ISBN          *string   `json:"isbn,omitempty"`
+	ASIN          *string   `json:"asin,omitempty"`
+	Language      *string   `json:"language,omitempty"`
+}
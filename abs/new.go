@@ -0,0 +1,77 @@
+package abs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+)
+
+// ClientOption configures a client created using NewClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	httpClient  *http.Client
+	timeout     time.Duration
+	retryPolicy utils.RetryPolicy
+}
+
+// WithHTTPClient sets the http client used to make requests.
+// The http client is copied, so it is not modified by other options.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(o *clientOptions) { o.httpClient = client }
+}
+
+// WithTimeout sets the time limit of requests. 0 for no time limit.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) { o.timeout = timeout }
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests.
+func WithRetryPolicy(policy utils.RetryPolicy) ClientOption {
+	return func(o *clientOptions) { o.retryPolicy = policy }
+}
+
+// NewClient creates a new client of the audiobookshelf server at a url,
+// authenticating using an api token of an audiobookshelf user.
+// Will return an error if the url, token or an option is invalid.
+func NewClient(absUrl, token string, options ...ClientOption) (*Client, error) {
+	var opts clientOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	if absUrl == "" {
+		return nil, errors.New("audiobookshelf url must be set")
+	}
+	parsedAbsUrl, err := url.Parse(strings.Trim(absUrl, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid audiobookshelf url: %w", err)
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, errors.New("audiobookshelf token must be set")
+	}
+
+	httpClient := &http.Client{}
+	if opts.httpClient != nil {
+		*httpClient = *opts.httpClient
+	}
+	if opts.timeout > 0 {
+		httpClient.Timeout = opts.timeout
+	}
+	if opts.retryPolicy.MaxRetries > 0 {
+		httpClient.Transport = utils.NewRetryTransport(httpClient.Transport, opts.retryPolicy)
+	}
+
+	return &Client{
+		client: httpClient,
+		absUrl: parsedAbsUrl,
+		token:  token,
+	}, nil
+}
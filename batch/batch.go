@@ -0,0 +1,74 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/orsinium-labs/enum"
+)
+
+var ErrJobNotFound = errors.New("batch job not found")
+
+// Item is an item of a library to match. Any of its fields may be empty.
+type Item struct {
+	Title  string `json:"title,omitempty"`
+	Author string `json:"author,omitempty"`
+	ISBN   string `json:"isbn,omitempty"`
+	ASIN   string `json:"asin,omitempty"`
+}
+
+// Job is a batch of items to match using a provider.
+type Job struct {
+	Id       string `json:"id"`
+	Provider string `json:"provider"`
+	// Region is the region of the provider, if it has regions
+	Region string `json:"region,omitempty"`
+	// MinConfidence is the confidence a book must have of being an item for it to match
+	MinConfidence float64   `json:"minConfidence,omitempty"`
+	Items         []Item    `json:"items"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// Result is the result of matching an item of a job.
+type Result struct {
+	// Index is the index of the item in the items of the job
+	Index int  `json:"index"`
+	Item  Item `json:"item"`
+	// Match is the book matched to the item. nil if the item has no match
+	Match json.RawMessage `json:"match,omitempty"`
+	// Error is the error matching the item. Empty if the item was matched
+	Error string `json:"error,omitempty"`
+}
+
+// Status is the status of a job.
+type Status enum.Member[string]
+
+var (
+	statusEnum = enum.NewBuilder[string, Status]()
+
+	StatusQueued    = statusEnum.Add(Status{"queued"})
+	StatusRunning   = statusEnum.Add(Status{"running"})
+	StatusCompleted = statusEnum.Add(Status{"completed"})
+
+	Statuses = statusEnum.Enum()
+)
+
+// Progress is the progress of a job.
+type Progress struct {
+	Id        string
+	Provider  string
+	Status    Status
+	Total     int
+	Completed int
+	Matched   int
+	Failed    int
+	CreatedAt time.Time
+	// CompletedAt is when every item of the job was matched. nil if the job is not completed
+	CompletedAt *time.Time
+}
+
+// MatchFunc matches an item of a job, returning the matched book.
+// If the item has no match, nil is returned.
+type MatchFunc func(ctx context.Context, job Job, item Item) (json.RawMessage, error)
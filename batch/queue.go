@@ -0,0 +1,350 @@
+package batch
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// maxExpiryInterval is the maximum interval completed jobs are checked for expiry at
+const maxExpiryInterval = time.Minute
+
+// Queue matches the items of jobs using a fixed number of workers, so matching a whole library
+// does not burst requests to providers. Items are matched in the order their jobs were submitted.
+type Queue struct {
+	store     *store
+	match     MatchFunc
+	retention time.Duration
+
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	jobs    map[string]*jobState
+	pending []task
+	closed  bool
+
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+}
+
+type jobState struct {
+	job         Job
+	results     []Result
+	started     bool
+	completedAt *time.Time
+	// updated is closed (and replaced) when a result is added, waking anything waiting for results
+	updated chan struct{}
+}
+
+// task is an item of a job to match.
+type task struct {
+	job   *jobState
+	index int
+}
+
+// NewQueue creates a queue that matches the items of jobs using a number of workers (at least 1).
+// If dir is set, jobs and their results are saved in it, and the unfinished jobs of a previous queue
+// saved in it are resumed. Will return an error if the saved jobs can not be loaded.
+// Completed jobs and their results are removed once retention has passed since they were completed.
+// If retention <= 0, completed jobs are never removed.
+func NewQueue(dir string, workers int, retention time.Duration, match MatchFunc) (*Queue, error) {
+	queue := &Queue{
+		match:     match,
+		retention: retention,
+		jobs:      make(map[string]*jobState),
+	}
+	queue.cond = sync.NewCond(&queue.mutex)
+
+	if dir != "" {
+		jobStore, err := newStore(dir)
+		if err != nil {
+			return nil, err
+		}
+		queue.store = jobStore
+
+		savedJobs, err := jobStore.load()
+		if err != nil {
+			return nil, err
+		}
+		for _, savedJob := range savedJobs {
+			queue.add(savedJob.job, savedJob.results, savedJob.completedAt)
+		}
+		queue.removeExpired()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queue.cancel = cancel
+	for range max(workers, 1) {
+		queue.workers.Add(1)
+		go queue.work(ctx)
+	}
+	if retention > 0 {
+		queue.workers.Add(1)
+		go queue.expire(ctx)
+	}
+
+	return queue, nil
+}
+
+// Close stops matching items, waiting for the items being matched to be cancelled.
+// Unfinished jobs are resumed by the next queue using the same directory.
+func (q *Queue) Close() {
+	q.mutex.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.mutex.Unlock()
+
+	q.cancel()
+	q.workers.Wait()
+}
+
+// Submit adds a job to the queue, returning its progress. The id and creation time of the job are set.
+func (q *Queue) Submit(job Job) (Progress, error) {
+	id, err := newJobId()
+	if err != nil {
+		return Progress{}, err
+	}
+	job.Id = id
+	job.CreatedAt = time.Now().UTC()
+
+	if q.store != nil {
+		err := q.store.saveJob(job, nil)
+		if err != nil {
+			return Progress{}, err
+		}
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	state := q.add(job, nil, nil)
+	return state.progress(), nil
+}
+
+// Progress gets the progress of a job.
+// Will return ErrJobNotFound if the job does not exist.
+func (q *Queue) Progress(id string) (Progress, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	state, ok := q.jobs[id]
+	if !ok {
+		return Progress{}, ErrJobNotFound
+	}
+
+	return state.progress(), nil
+}
+
+// Results calls fn with each result of a job, in the order items were matched. If follow is set, results
+// are waited for until the job is completed or the context is done. Returns the first error returned by fn.
+// Will return ErrJobNotFound if the job does not exist.
+func (q *Queue) Results(ctx context.Context, id string, follow bool, fn func(Result) error) error {
+	q.mutex.Lock()
+	state, ok := q.jobs[id]
+	q.mutex.Unlock()
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	sent := 0
+	for {
+		// Results are only appended, so those already added can be read without holding the lock
+		q.mutex.Lock()
+		results := state.results[sent:]
+		completed := state.completedAt != nil
+		updated := state.updated
+		q.mutex.Unlock()
+
+		for _, result := range results {
+			err := fn(result)
+			if err != nil {
+				return err
+			}
+		}
+		sent += len(results)
+
+		if completed || !follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
+// add adds a job and its existing results to the queue, queueing its unmatched items.
+// The queue must be locked, or not yet in use.
+func (q *Queue) add(job Job, results []Result, completedAt *time.Time) *jobState {
+	state := &jobState{
+		job:         job,
+		results:     results,
+		started:     len(results) != 0,
+		completedAt: completedAt,
+		updated:     make(chan struct{}),
+	}
+	q.jobs[job.Id] = state
+
+	matchedIndexes := lo.SliceToMap(results, func(result Result) (int, bool) { return result.Index, true })
+	for index := range job.Items {
+		if !matchedIndexes[index] {
+			q.pending = append(q.pending, task{job: state, index: index})
+		}
+	}
+
+	if len(results) >= len(job.Items) && completedAt == nil {
+		state.completedAt = lo.ToPtr(time.Now().UTC())
+	}
+	q.cond.Broadcast()
+
+	return state
+}
+
+// work matches queued items until the queue is closed.
+func (q *Queue) work(ctx context.Context) {
+	defer q.workers.Done()
+
+	for {
+		q.mutex.Lock()
+		for len(q.pending) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if q.closed {
+			q.mutex.Unlock()
+			return
+		}
+
+		nextTask := q.pending[0]
+		q.pending = q.pending[1:]
+		nextTask.job.started = true
+		q.mutex.Unlock()
+
+		item := nextTask.job.job.Items[nextTask.index]
+		result := Result{Index: nextTask.index, Item: item}
+
+		match, err := q.match(ctx, nextTask.job.job, item)
+		if err != nil {
+			if ctx.Err() != nil {
+				// The queue is closing. The item will be matched when the job is resumed
+				return
+			}
+			result.Error = err.Error()
+		} else {
+			result.Match = match
+		}
+
+		q.addResult(nextTask.job, result)
+	}
+}
+
+// expire removes expired jobs until the queue is closed.
+func (q *Queue) expire(ctx context.Context) {
+	defer q.workers.Done()
+
+	ticker := time.NewTicker(min(q.retention, maxExpiryInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.removeExpired()
+		}
+	}
+}
+
+// removeExpired removes the jobs completed longer than the retention of the queue ago,
+// removing them from the store if the queue has one.
+func (q *Queue) removeExpired() {
+	if q.retention <= 0 {
+		return
+	}
+
+	q.mutex.Lock()
+	var expiredIds []string
+	for id, state := range q.jobs {
+		if state.completedAt != nil && time.Since(*state.completedAt) >= q.retention {
+			expiredIds = append(expiredIds, id)
+			delete(q.jobs, id)
+		}
+	}
+	q.mutex.Unlock()
+
+	if q.store == nil {
+		return
+	}
+	for _, id := range expiredIds {
+		err := q.store.remove(id)
+		if err != nil {
+			log.Printf("Failed to remove batch job %s: %s", id, err)
+		}
+	}
+}
+
+// addResult adds the result of an item to its job, saving it if the queue has a store.
+func (q *Queue) addResult(state *jobState, result Result) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	state.results = append(state.results, result)
+	if len(state.results) == len(state.job.Items) {
+		state.completedAt = lo.ToPtr(time.Now().UTC())
+	}
+
+	close(state.updated)
+	state.updated = make(chan struct{})
+
+	if q.store != nil {
+		err := q.store.saveResult(state.job.Id, result)
+		if err != nil {
+			log.Printf("Failed to save result of batch job %s: %s", state.job.Id, err)
+		}
+
+		if state.completedAt != nil {
+			err = q.store.saveJob(state.job, state.completedAt)
+			if err != nil {
+				log.Printf("Failed to save batch job %s: %s", state.job.Id, err)
+			}
+		}
+	}
+}
+
+func (s *jobState) progress() Progress {
+	status := StatusQueued
+	switch {
+	case s.completedAt != nil:
+		status = StatusCompleted
+	case s.started:
+		status = StatusRunning
+	}
+
+	return Progress{
+		Id:          s.job.Id,
+		Provider:    s.job.Provider,
+		Status:      status,
+		Total:       len(s.job.Items),
+		Completed:   len(s.results),
+		Matched:     lo.CountBy(s.results, func(result Result) bool { return result.Match != nil }),
+		Failed:      lo.CountBy(s.results, func(result Result) bool { return result.Error != "" }),
+		CreatedAt:   s.job.CreatedAt,
+		CompletedAt: s.completedAt,
+	}
+}
+
+// newJobId creates a random id for a job.
+func newJobId() (string, error) {
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", fmt.Errorf("failed to create job id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
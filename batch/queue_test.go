@@ -0,0 +1,166 @@
+package batch_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/batch"
+	"github.com/stretchr/testify/require"
+)
+
+var testItems = []batch.Item{
+	{Title: "The Hobbit", Author: "J.R.R. Tolkien"},
+	{Title: "Unknown"},
+	{Title: "Error"},
+}
+
+// testMatch matches items by their title. Items titled "Unknown" have no match and "Error" fail to match.
+func testMatch(_ context.Context, _ batch.Job, item batch.Item) (json.RawMessage, error) {
+	switch item.Title {
+	case "Unknown":
+		return nil, nil
+	case "Error":
+		return nil, errors.New("failed to match")
+	default:
+		return json.Marshal(map[string]string{"title": item.Title})
+	}
+}
+
+func TestQueue(t *testing.T) {
+	queue, err := batch.NewQueue("", 2, 0, testMatch)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	progress, err := queue.Submit(batch.Job{Provider: "goodreads", Items: testItems})
+	require.NoError(t, err)
+	require.NotEmpty(t, progress.Id)
+	require.Equal(t, 3, progress.Total)
+
+	results := followResults(t, queue, progress.Id)
+	require.Len(t, results, 3)
+	require.JSONEq(t, `{"title":"The Hobbit"}`, string(results[0].Match))
+	require.Nil(t, results[1].Match)
+	require.Empty(t, results[1].Error)
+	require.Equal(t, "failed to match", results[2].Error)
+
+	progress, err = queue.Progress(progress.Id)
+	require.NoError(t, err)
+	require.Equal(t, batch.StatusCompleted, progress.Status)
+	require.Equal(t, 3, progress.Completed)
+	require.Equal(t, 1, progress.Matched)
+	require.Equal(t, 1, progress.Failed)
+	require.NotNil(t, progress.CompletedAt)
+
+	_, err = queue.Progress("unknown")
+	require.ErrorIs(t, err, batch.ErrJobNotFound)
+}
+
+func TestQueueResume(t *testing.T) {
+	dir := t.TempDir()
+
+	// Block matching of the second item, so the job is unfinished when the queue is closed
+	var matched sync.WaitGroup
+	matched.Add(1)
+	blockingMatch := func(ctx context.Context, job batch.Job, item batch.Item) (json.RawMessage, error) {
+		if item.Title != testItems[0].Title {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		defer matched.Done()
+		return testMatch(ctx, job, item)
+	}
+
+	queue, err := batch.NewQueue(dir, 1, 0, blockingMatch)
+	require.NoError(t, err)
+	progress, err := queue.Submit(batch.Job{Provider: "goodreads", Items: testItems})
+	require.NoError(t, err)
+	matched.Wait()
+	queue.Close()
+
+	// Simulate a result that was partially written when stopped
+	resultsFile, err := os.OpenFile(filepath.Join(dir, progress.Id+".ndjson"), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = resultsFile.WriteString(`{"index":1,"it`)
+	require.NoError(t, err)
+	require.NoError(t, resultsFile.Close())
+
+	// Only the unmatched items are matched when resumed
+	var resumedItems []string
+	var resumedItemsMutex sync.Mutex
+	recordingMatch := func(ctx context.Context, job batch.Job, item batch.Item) (json.RawMessage, error) {
+		resumedItemsMutex.Lock()
+		resumedItems = append(resumedItems, item.Title)
+		resumedItemsMutex.Unlock()
+		return testMatch(ctx, job, item)
+	}
+
+	queue, err = batch.NewQueue(dir, 1, 0, recordingMatch)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	results := followResults(t, queue, progress.Id)
+	require.Len(t, results, 3)
+	require.Equal(t, []string{"Unknown", "Error"}, resumedItems)
+
+	// Completed jobs are not resumed
+	queue.Close()
+	queue, err = batch.NewQueue(dir, 1, 0, recordingMatch)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	progress, err = queue.Progress(progress.Id)
+	require.NoError(t, err)
+	require.Equal(t, batch.StatusCompleted, progress.Status)
+	require.Equal(t, 3, progress.Completed)
+	require.Len(t, resumedItems, 2)
+}
+
+func TestQueueExpiry(t *testing.T) {
+	dir := t.TempDir()
+
+	queue, err := batch.NewQueue(dir, 1, 50*time.Millisecond, testMatch)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	progress, err := queue.Submit(batch.Job{Provider: "goodreads", Items: testItems})
+	require.NoError(t, err)
+	followResults(t, queue, progress.Id)
+
+	// Completed jobs and their results are removed once expired
+	require.Eventually(t, func() bool {
+		_, err := queue.Progress(progress.Id)
+		return errors.Is(err, batch.ErrJobNotFound)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		files, err := os.ReadDir(dir)
+		return err == nil && len(files) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// followResults gets all the results of a job, waiting for the job to complete
+func followResults(t *testing.T, queue *batch.Queue, id string) []batch.Result {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var results []batch.Result
+	err := queue.Results(ctx, id, true, func(result batch.Result) error {
+		results = append(results, result)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Order results by item, as items are matched concurrently
+	sortedResults := make([]batch.Result, len(results))
+	for _, result := range results {
+		sortedResults[result.Index] = result
+	}
+
+	return sortedResults
+}
@@ -0,0 +1,191 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// store saves jobs and their results in a directory, so they can be resumed.
+// Each job is saved in "<id>.json", and its results are appended to "<id>.ndjson".
+type store struct {
+	dir string
+}
+
+// savedJob is a job loaded from a store, with its results.
+type savedJob struct {
+	job         Job
+	results     []Result
+	completedAt *time.Time
+}
+
+// jobFile is the content of the file a job is saved in.
+type jobFile struct {
+	Job
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+func newStore(dir string) (*store, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch directory: %w", err)
+	}
+	return &store{dir: dir}, nil
+}
+
+func (s *store) jobPath(id string) string     { return filepath.Join(s.dir, id+".json") }
+func (s *store) resultsPath(id string) string { return filepath.Join(s.dir, id+".ndjson") }
+
+func (s *store) saveJob(job Job, completedAt *time.Time) error {
+	data, err := json.Marshal(jobFile{Job: job, CompletedAt: completedAt})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	// Write to a temporary file first, so a job is never partially written
+	tempPath := s.jobPath(job.Id) + ".tmp"
+	err = os.WriteFile(tempPath, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write job: %w", err)
+	}
+
+	err = os.Rename(tempPath, s.jobPath(job.Id))
+	if err != nil {
+		return fmt.Errorf("failed to write job: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) saveResult(id string, result Result) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	file, err := os.OpenFile(s.resultsPath(id), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open results: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+
+	return nil
+}
+
+// remove removes a job and its results.
+func (s *store) remove(id string) error {
+	err := os.Remove(s.resultsPath(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove results: %w", err)
+	}
+
+	err = os.Remove(s.jobPath(id))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove job: %w", err)
+	}
+
+	return nil
+}
+
+// load loads the saved jobs and their results, oldest first. Jobs that can not be read are skipped.
+func (s *store) load() ([]savedJob, error) {
+	jobPaths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch jobs: %w", err)
+	}
+
+	jobs := make([]savedJob, 0, len(jobPaths))
+	for _, jobPath := range jobPaths {
+		job, err := s.loadJob(strings.TrimSuffix(filepath.Base(jobPath), ".json"))
+		if err != nil {
+			log.Printf("Failed to load batch job %s: %s", jobPath, err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	slices.SortFunc(jobs, func(a, b savedJob) int { return a.job.CreatedAt.Compare(b.job.CreatedAt) })
+
+	return jobs, nil
+}
+
+func (s *store) loadJob(id string) (savedJob, error) {
+	data, err := os.ReadFile(s.jobPath(id))
+	if err != nil {
+		return savedJob{}, fmt.Errorf("failed to read job: %w", err)
+	}
+
+	var file jobFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return savedJob{}, fmt.Errorf("failed to unmarshal job: %w", err)
+	}
+
+	results, err := s.loadResults(id, len(file.Items))
+	if err != nil {
+		return savedJob{}, err
+	}
+
+	return savedJob{
+		job:         file.Job,
+		results:     results,
+		completedAt: file.CompletedAt,
+	}, nil
+}
+
+// loadResults loads the results of a job. Results that can not be read (e.g. that were partially
+// written when stopped) are dropped, rewriting the results so new results are not appended to them.
+func (s *store) loadResults(id string, numItems int) ([]Result, error) {
+	data, err := os.ReadFile(s.resultsPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+
+	var results []Result
+	matchedIndexes := make(map[int]bool)
+	invalid := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		var result Result
+		err := json.Unmarshal(scanner.Bytes(), &result)
+		if err != nil || result.Index < 0 || result.Index >= numItems || matchedIndexes[result.Index] {
+			invalid = true
+			continue
+		}
+		matchedIndexes[result.Index] = true
+		results = append(results, result)
+	}
+
+	if invalid || (len(data) != 0 && data[len(data)-1] != '\n') {
+		err := os.Remove(s.resultsPath(id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewrite results: %w", err)
+		}
+		for _, result := range results {
+			err := s.saveResult(id, result)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return results, nil
+}
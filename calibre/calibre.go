@@ -0,0 +1,205 @@
+package calibre
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/samber/lo"
+)
+
+// Confidence books must match a search with to be found
+const minSearchConfidence = 0.6
+
+// Name of the database of a calibre library, in the root directory of the library
+const databaseName = "metadata.db"
+
+var ErrBookNotFound = errors.New("book not found")
+
+// Book is a book in a calibre library.
+type Book struct {
+	Id        int
+	Title     string
+	Authors   []string
+	Series    *Series
+	Tags      []string
+	Publisher string
+	// PublishedDate is the date the book was published (e.g. "2006-01-02"). Empty if unknown
+	PublishedDate string
+	// Languages are the ISO 639 codes of the languages of the book (e.g. "eng"), most significant first
+	Languages []string
+	// Identifiers are the identifiers of the book by type, e.g. "isbn", "amazon" or "goodreads"
+	Identifiers map[string]string
+	// Description is the html description (comments) of the book
+	Description string
+	// Dir is the directory of the files of the book, relative to the library
+	Dir      string
+	HasCover bool
+}
+
+type Series struct {
+	Name  string
+	Index float64
+}
+
+// ISBN gets the isbn of the book. Empty if unknown.
+func (b Book) ISBN() string {
+	return b.Identifiers["isbn"]
+}
+
+// ASIN gets the asin of the book. Empty if unknown.
+func (b Book) ASIN() string {
+	return lo.CoalesceOrEmpty(b.Identifiers["amazon"], b.Identifiers["asin"], b.Identifiers["mobi-asin"])
+}
+
+// Match is a book found by searching a library.
+type Match struct {
+	Book Book
+	// Confidence is how confident it is that the book is the book searched for, between 0 and 1
+	Confidence float64
+}
+
+// Library is a calibre library, read from its database. The library is only read, never written to.
+type Library struct {
+	dir string
+
+	mutex sync.Mutex
+	books []Book
+	// modTime and size are of the database when it was last read, to know when it has changed
+	modTime time.Time
+	size    int64
+}
+
+// NewLibrary creates a library from the root directory of a calibre library, containing its metadata.db.
+// The database is not read until the library is searched. Will return an error if the database does not exist.
+func NewLibrary(dir string) (*Library, error) {
+	info, err := os.Stat(filepath.Join(dir, databaseName))
+	if err != nil {
+		return nil, fmt.Errorf("invalid calibre library: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("invalid calibre library: %s is a directory", databaseName)
+	}
+
+	return &Library{dir: dir}, nil
+}
+
+// Books gets the books in the library, reading the database if it has changed since it was last read.
+func (l *Library) Books(ctx context.Context) ([]Book, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	databasePath := filepath.Join(l.dir, databaseName)
+	info, err := os.Stat(databasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calibre library: %w", err)
+	}
+	if l.books != nil && info.ModTime().Equal(l.modTime) && info.Size() == l.size {
+		return l.books, nil
+	}
+
+	books, err := readBooks(ctx, databasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calibre library: %w", err)
+	}
+	l.books = books
+	l.modTime = info.ModTime()
+	l.size = info.Size()
+
+	return l.books, nil
+}
+
+// Book gets a book in the library by its id.
+// Will return ErrBookNotFound if the library does not have the book.
+func (l *Library) Book(ctx context.Context, id int) (Book, error) {
+	books, err := l.Books(ctx)
+	if err != nil {
+		return Book{}, err
+	}
+
+	book, ok := lo.Find(books, func(book Book) bool { return book.Id == id })
+	if !ok {
+		return Book{}, ErrBookNotFound
+	}
+
+	return book, nil
+}
+
+// CoverPath gets the path of the cover (cover.jpg) of a book in the library.
+// Will return ErrBookNotFound if the library does not have the book, or the book does not have a cover.
+func (l *Library) CoverPath(ctx context.Context, id int) (string, error) {
+	book, err := l.Book(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if !book.HasCover {
+		return "", ErrBookNotFound
+	}
+
+	// Directories of books are always inside the library
+	coverPath := filepath.Join(l.dir, filepath.FromSlash(book.Dir), "cover.jpg")
+	relativePath, err := filepath.Rel(l.dir, coverPath)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return "", fmt.Errorf("invalid directory of book %d: %s", id, book.Dir)
+	}
+
+	return coverPath, nil
+}
+
+// Search searches for books in the library by a title and optionally an author, using fuzzy matching.
+// Folder style queries (e.g. "Author - Series 01 - Title") are parsed, and an isbn or asin matches a book exactly.
+// Books are returned from the most to the least similar.
+func (l *Library) Search(ctx context.Context, title string, author *string) ([]Match, error) {
+	books, err := l.Books(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	searchQuery := query.Parse(title, lo.FromPtr(author))
+	identifier := normaliseIdentifier(title)
+
+	var matches []Match
+	for _, book := range books {
+		confidence := searchQuery.Confidence(bookMatch(book))
+		if identifier != "" &&
+			(identifier == normaliseIdentifier(book.ISBN()) || identifier == normaliseIdentifier(book.ASIN())) {
+			confidence = 1
+		}
+		if confidence >= minSearchConfidence {
+			matches = append(matches, Match{Book: book, Confidence: confidence})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b Match) int { return cmp.Compare(b.Confidence, a.Confidence) })
+
+	return matches, nil
+}
+
+// bookMatch gets a book as a match, to compute the confidence it is the book searched for by a query.
+func bookMatch(book Book) query.Match {
+	match := query.Match{
+		Title:  book.Title,
+		Author: strings.Join(book.Authors, ", "),
+	}
+	if book.Series != nil {
+		match.Series = []string{book.Series.Name}
+	}
+	if len(book.PublishedDate) >= 4 {
+		match.Year = book.PublishedDate[:4]
+	}
+	return match
+}
+
+// normaliseIdentifier normalises an isbn or asin for comparison, removing hyphens and spaces.
+func normaliseIdentifier(identifier string) string {
+	identifier = strings.NewReplacer("-", "", " ", "").Replace(identifier)
+	return strings.ToUpper(identifier)
+}
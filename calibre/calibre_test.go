@@ -0,0 +1,135 @@
+package calibre_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/calibre"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+// Library generated from testdata/library/metadata.sql
+const testLibraryDir = "testdata/library"
+
+func TestLibraryBooks(t *testing.T) {
+	library, err := calibre.NewLibrary(testLibraryDir)
+	require.NoError(t, err)
+
+	books, err := library.Books(context.Background())
+	require.NoError(t, err)
+	require.Len(t, books, 200)
+
+	hobbit := books[0]
+	require.Equal(t, 1, hobbit.Id)
+	require.Equal(t, "The Hobbit", hobbit.Title)
+	require.Equal(t, []string{"J.R.R. Tolkien"}, hobbit.Authors)
+	require.Equal(t, &calibre.Series{Name: "Middle Earth", Index: 0}, hobbit.Series)
+	require.Equal(t, []string{"Fantasy", "Classics"}, hobbit.Tags)
+	require.Equal(t, "Houghton Mifflin", hobbit.Publisher)
+	require.Equal(t, "1937-09-21", hobbit.PublishedDate)
+	require.Equal(t, []string{"eng"}, hobbit.Languages)
+	require.Equal(t, "9780618260300", hobbit.ISBN())
+	require.Equal(t, "B007978NPG", hobbit.ASIN())
+	require.Equal(t, "5907", hobbit.Identifiers["goodreads"])
+	require.Equal(t, "J.R.R. Tolkien/The Hobbit (1)", hobbit.Dir)
+	require.True(t, hobbit.HasCover)
+
+	// Descriptions overflowing their page are read
+	require.Len(t, hobbit.Description, 21652)
+	require.Contains(t, hobbit.Description, "<p>In a hole in the ground there lived a hobbit.</p>")
+
+	// Authors and languages are in the order they were added
+	goodOmens := books[2]
+	require.Equal(t, []string{"Terry Pratchett", "Neil Gaiman"}, goodOmens.Authors)
+	require.Equal(t, []string{"eng", "deu"}, goodOmens.Languages)
+	require.Nil(t, goodOmens.Series)
+	require.False(t, goodOmens.HasCover)
+
+	// Undefined dates are unknown
+	unknown := books[3]
+	require.Empty(t, unknown.PublishedDate)
+	require.Empty(t, unknown.Authors)
+	require.Empty(t, unknown.Description)
+
+	// Rows of tables spanning multiple pages are all read in order
+	require.Equal(t, "Filler 200", books[199].Title)
+}
+
+func TestLibrarySearch(t *testing.T) {
+	library, err := calibre.NewLibrary(testLibraryDir)
+	require.NoError(t, err)
+
+	matches, err := library.Search(context.Background(), "the hobbit", lo.ToPtr("tolkien"))
+	require.NoError(t, err)
+	require.NotEmpty(t, matches)
+	require.Equal(t, "The Hobbit", matches[0].Book.Title)
+
+	matches, err = library.Search(context.Background(), "Pratchett - Good Omens", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, matches)
+	require.Equal(t, "Good Omens", matches[0].Book.Title)
+
+	// Isbns match exactly, ignoring hyphens
+	matches, err = library.Search(context.Background(), "9780060853983", nil)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, "Good Omens", matches[0].Book.Title)
+	require.InDelta(t, 1, matches[0].Confidence, 0)
+
+	matches, err = library.Search(context.Background(), "B007978NPG", nil)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, "The Hobbit", matches[0].Book.Title)
+
+	matches, err = library.Search(context.Background(), "Dune", lo.ToPtr("Frank Herbert"))
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
+
+func TestLibraryCoverPath(t *testing.T) {
+	library, err := calibre.NewLibrary(testLibraryDir)
+	require.NoError(t, err)
+
+	coverPath, err := library.CoverPath(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(testLibraryDir, "J.R.R. Tolkien", "The Hobbit (1)", "cover.jpg"), coverPath)
+	require.FileExists(t, coverPath)
+
+	// Book without a cover
+	_, err = library.CoverPath(context.Background(), 3)
+	require.ErrorIs(t, err, calibre.ErrBookNotFound)
+
+	_, err = library.CoverPath(context.Background(), 1000)
+	require.ErrorIs(t, err, calibre.ErrBookNotFound)
+}
+
+func TestLibraryReload(t *testing.T) {
+	libraryDir := t.TempDir()
+	databasePath := filepath.Join(libraryDir, "metadata.db")
+
+	database, err := os.ReadFile(filepath.Join(testLibraryDir, "metadata.db"))
+	require.NoError(t, err)
+	err = os.WriteFile(databasePath, database, 0o600)
+	require.NoError(t, err)
+
+	library, err := calibre.NewLibrary(libraryDir)
+	require.NoError(t, err)
+
+	books, err := library.Books(context.Background())
+	require.NoError(t, err)
+	require.Len(t, books, 200)
+
+	// Changed databases are read again
+	err = os.WriteFile(databasePath, []byte("not a database"), 0o600)
+	require.NoError(t, err)
+	_, err = library.Books(context.Background())
+	require.Error(t, err)
+}
+
+func TestNewLibraryInvalid(t *testing.T) {
+	_, err := calibre.NewLibrary(t.TempDir())
+	require.Error(t, err)
+}
@@ -0,0 +1,222 @@
+package calibre
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	_ "modernc.org/sqlite" // Registers the sqlite database driver
+)
+
+// Year of the date calibre uses for books with an unknown published date (0101-01-01)
+const undefinedYear = "0101"
+
+// Queries of the names of items linked to books, selecting the id of the book and the name of the item.
+// Only languages are ordered by calibre, other items are linked in the order they were added to a book.
+const (
+	authorsQuery = `
+		SELECT link.book, authors.name FROM books_authors_link AS link
+		JOIN authors ON authors.id = link.author
+		ORDER BY link.id`
+	tagsQuery = `
+		SELECT link.book, tags.name FROM books_tags_link AS link
+		JOIN tags ON tags.id = link.tag
+		ORDER BY link.id`
+	publishersQuery = `
+		SELECT link.book, publishers.name FROM books_publishers_link AS link
+		JOIN publishers ON publishers.id = link.publisher
+		ORDER BY link.id`
+	languagesQuery = `
+		SELECT link.book, languages.lang_code FROM books_languages_link AS link
+		JOIN languages ON languages.id = link.lang_code
+		ORDER BY link.item_order, link.id`
+	seriesQuery = `
+		SELECT link.book, series.name FROM books_series_link AS link
+		JOIN series ON series.id = link.series
+		ORDER BY link.id`
+)
+
+// readBooks reads the books of a calibre library from its database, which is opened read-only.
+func readBooks(ctx context.Context, databasePath string) ([]Book, error) {
+	absolutePath, err := filepath.Abs(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	databaseUri := url.URL{Scheme: "file", Path: filepath.ToSlash(absolutePath), RawQuery: "mode=ro"}
+
+	db, err := sql.Open("sqlite", databaseUri.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, title, COALESCE(substr(pubdate, 1, 10), ''), path, has_cover, COALESCE(isbn, ''), series_index
+		FROM books
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read books: %w", err)
+	}
+	defer rows.Close()
+
+	var books []Book
+	seriesIndexes := make(map[int]float64)
+	for rows.Next() {
+		book := Book{Identifiers: make(map[string]string)}
+		var pubdate, isbn string
+		var seriesIndex float64
+		err = rows.Scan(&book.Id, &book.Title, &pubdate, &book.Dir, &book.HasCover, &isbn, &seriesIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read book: %w", err)
+		}
+
+		book.PublishedDate = publishedDate(pubdate)
+		// Older libraries store the isbn of books in the books table
+		if isbn != "" {
+			book.Identifiers["isbn"] = isbn
+		}
+		// The index of a book in its series is stored with the book
+		seriesIndexes[book.Id] = seriesIndex
+
+		books = append(books, book)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read books: %w", err)
+	}
+
+	booksById := make(map[int]*Book, len(books))
+	for idx := range books {
+		booksById[books[idx].Id] = &books[idx]
+	}
+
+	err = readLinkedNames(ctx, db, booksById, authorsQuery, func(book *Book, name string) {
+		book.Authors = append(book.Authors, name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read authors: %w", err)
+	}
+
+	err = readLinkedNames(ctx, db, booksById, tagsQuery, func(book *Book, name string) {
+		book.Tags = append(book.Tags, name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tags: %w", err)
+	}
+
+	err = readLinkedNames(ctx, db, booksById, publishersQuery, func(book *Book, name string) {
+		book.Publisher = name
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read publishers: %w", err)
+	}
+
+	err = readLinkedNames(ctx, db, booksById, languagesQuery, func(book *Book, code string) {
+		book.Languages = append(book.Languages, code)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read languages: %w", err)
+	}
+
+	err = readLinkedNames(ctx, db, booksById, seriesQuery, func(book *Book, name string) {
+		book.Series = &Series{Name: name, Index: seriesIndexes[book.Id]}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read series: %w", err)
+	}
+
+	err = readBookValues(ctx, db, booksById, "SELECT book, type, val FROM identifiers ORDER BY id",
+		func(book *Book, kind, value string) {
+			book.Identifiers[strings.ToLower(kind)] = value
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identifiers: %w", err)
+	}
+
+	err = readBookValues(ctx, db, booksById, "SELECT book, '', text FROM comments ORDER BY id",
+		func(book *Book, _, text string) {
+			book.Description = text
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comments: %w", err)
+	}
+
+	return books, nil
+}
+
+// readLinkedNames reads the names of items (e.g. authors) linked to books using a query selecting the id
+// of each book and the name of each item linked to it, calling a function with each book and name.
+func readLinkedNames(
+	ctx context.Context,
+	db *sql.DB,
+	booksById map[int]*Book,
+	query string,
+	fn func(book *Book, name string),
+) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookId int
+		var name sql.NullString
+		err = rows.Scan(&bookId, &name)
+		if err != nil {
+			return err
+		}
+
+		book, ok := booksById[bookId]
+		if ok && name.String != "" {
+			fn(book, name.String)
+		}
+	}
+
+	return rows.Err()
+}
+
+// readBookValues reads keyed values of books (e.g. identifiers) using a query selecting the id of each book,
+// a key and a value, calling a function with each book, key and value. Empty values are ignored.
+func readBookValues(
+	ctx context.Context,
+	db *sql.DB,
+	booksById map[int]*Book,
+	query string,
+	fn func(book *Book, key, value string),
+) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookId int
+		var key, value sql.NullString
+		err = rows.Scan(&bookId, &key, &value)
+		if err != nil {
+			return err
+		}
+
+		book, ok := booksById[bookId]
+		if ok && value.String != "" {
+			fn(book, key.String, value.String)
+		}
+	}
+
+	return rows.Err()
+}
+
+// publishedDate gets the date part of a published timestamp of calibre (e.g. "2006-01-02 00:00:00+00:00").
+// Empty if the date is unknown.
+func publishedDate(timestamp string) string {
+	if len(timestamp) < 10 || strings.HasPrefix(timestamp, undefinedYear) {
+		return ""
+	}
+	return timestamp[:10]
+}
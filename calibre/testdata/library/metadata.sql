@@ -0,0 +1,112 @@
+-- Calibre library used by tests, with the tables of the schema of calibre that are read.
+-- metadata.db is generated from this file using:
+--   rm -f metadata.db && sqlite3 metadata.db < metadata.sql
+-- Small pages are used so tables span multiple pages, and long values overflow their page.
+PRAGMA page_size = 1024;
+
+CREATE TABLE books ( id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL DEFAULT 'Unknown' COLLATE NOCASE,
+    sort TEXT COLLATE NOCASE,
+    timestamp TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    pubdate TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    series_index REAL NOT NULL DEFAULT 1.0,
+    author_sort TEXT COLLATE NOCASE,
+    isbn TEXT DEFAULT "" COLLATE NOCASE,
+    lccn TEXT DEFAULT "" COLLATE NOCASE,
+    path TEXT NOT NULL DEFAULT "",
+    flags INTEGER NOT NULL DEFAULT 1,
+    uuid TEXT,
+    has_cover BOOL DEFAULT 0,
+    last_modified TIMESTAMP NOT NULL DEFAULT "2000-01-01 00:00:00+00:00");
+CREATE TABLE authors ( id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL COLLATE NOCASE,
+    sort TEXT COLLATE NOCASE,
+    link TEXT NOT NULL DEFAULT "",
+    UNIQUE(name));
+CREATE TABLE books_authors_link ( id INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    author INTEGER NOT NULL,
+    UNIQUE(book, author));
+CREATE TABLE series ( id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL COLLATE NOCASE,
+    sort TEXT COLLATE NOCASE,
+    link TEXT NOT NULL DEFAULT "",
+    UNIQUE (name));
+CREATE TABLE books_series_link ( id INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    series INTEGER NOT NULL,
+    UNIQUE(book));
+CREATE TABLE tags ( id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL COLLATE NOCASE,
+    link TEXT NOT NULL DEFAULT "",
+    UNIQUE (name));
+CREATE TABLE books_tags_link ( id INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    tag INTEGER NOT NULL,
+    UNIQUE(book, tag));
+CREATE TABLE publishers ( id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL COLLATE NOCASE,
+    sort TEXT COLLATE NOCASE,
+    link TEXT NOT NULL DEFAULT "",
+    UNIQUE(name));
+CREATE TABLE books_publishers_link ( id INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    publisher INTEGER NOT NULL,
+    UNIQUE(book));
+CREATE TABLE languages ( id INTEGER PRIMARY KEY,
+    lang_code TEXT NOT NULL COLLATE NOCASE,
+    link TEXT NOT NULL DEFAULT "",
+    UNIQUE(lang_code));
+CREATE TABLE books_languages_link ( id INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    lang_code INTEGER NOT NULL,
+    item_order INTEGER NOT NULL DEFAULT 0,
+    UNIQUE(book, lang_code));
+CREATE TABLE identifiers ( id INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    type TEXT NOT NULL DEFAULT "isbn" COLLATE NOCASE,
+    val TEXT NOT NULL COLLATE NOCASE,
+    UNIQUE(book, type));
+CREATE TABLE comments ( id INTEGER PRIMARY KEY,
+    book INTEGER NOT NULL,
+    text TEXT NOT NULL COLLATE NOCASE,
+    UNIQUE(book));
+
+INSERT INTO books (id, title, pubdate, series_index, path, has_cover) VALUES
+    (1, 'The Hobbit', '1937-09-21 00:00:00+00:00', 0, 'J.R.R. Tolkien/The Hobbit (1)', 1),
+    (2, 'The Fellowship of the Ring', '1954-07-29 00:00:00+00:00', 1, 'J.R.R. Tolkien/The Fellowship of the Ring (2)', 0),
+    (3, 'Good Omens', '1990-05-01 00:00:00+00:00', 1, 'Terry Pratchett/Good Omens (3)', 0),
+    (4, 'Unknown Book', '0101-01-01 00:00:00+00:00', 1.5, 'Unknown/Unknown Book (4)', 0);
+
+-- Books so the books table spans multiple pages
+WITH RECURSIVE filler(id) AS (SELECT 5 UNION ALL SELECT id + 1 FROM filler WHERE id < 200)
+INSERT INTO books (id, title, pubdate, path) SELECT id, 'Filler ' || id, '0101-01-01 00:00:00+00:00', 'Filler/' || id FROM filler;
+
+INSERT INTO authors (id, name, sort) VALUES
+    (1, 'J.R.R. Tolkien', 'Tolkien, J.R.R.'),
+    (2, 'Neil Gaiman', 'Gaiman, Neil'),
+    (3, 'Terry Pratchett', 'Pratchett, Terry');
+INSERT INTO books_authors_link (id, book, author) VALUES (1, 1, 1), (2, 2, 1), (3, 3, 3), (4, 3, 2);
+
+INSERT INTO series (id, name, sort) VALUES (1, 'Middle Earth', 'Middle Earth'), (2, 'The Lord of the Rings', 'Lord of the Rings, The');
+INSERT INTO books_series_link (id, book, series) VALUES (1, 1, 1), (2, 2, 2);
+
+INSERT INTO tags (id, name) VALUES (1, 'Fantasy'), (2, 'Classics'), (3, 'Humor');
+INSERT INTO books_tags_link (id, book, tag) VALUES (1, 1, 1), (2, 1, 2), (3, 2, 1), (4, 3, 1), (5, 3, 3);
+
+INSERT INTO publishers (id, name, sort) VALUES (1, 'Houghton Mifflin', 'Houghton Mifflin'), (2, 'Gollancz', 'Gollancz');
+INSERT INTO books_publishers_link (id, book, publisher) VALUES (1, 1, 1), (2, 2, 1), (3, 3, 2);
+
+INSERT INTO languages (id, lang_code) VALUES (1, 'eng'), (2, 'deu');
+INSERT INTO books_languages_link (id, book, lang_code, item_order) VALUES (1, 1, 1, 0), (2, 3, 2, 1), (3, 3, 1, 0);
+
+INSERT INTO identifiers (id, book, type, val) VALUES
+    (1, 1, 'isbn', '9780618260300'),
+    (2, 1, 'goodreads', '5907'),
+    (3, 1, 'amazon', 'B007978NPG'),
+    (4, 3, 'isbn', '978-0-06-085398-3');
+
+-- Descriptions long enough to overflow their page
+INSERT INTO comments (id, book, text) VALUES
+    (1, 1, '<p>In a hole in the ground there lived a hobbit.</p>' || replace(hex(zeroblob(600)), '00', '<p>Not a nasty, dirty, wet hole.</p>')),
+    (2, 3, '<p>According to The Nice and Accurate Prophecies of Agnes Nutter, Witch, the world will end on a Saturday.</p>');
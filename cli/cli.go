@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ahobsonsayers/abs-tract/config"
+)
+
+const usage = `Usage: abs-tract [command] [arguments]
+
+Commands:
+  serve                        Start the server (default)
+  search goodreads <query>     Search for books using goodreads
+  search kindle <query>        Search for books using kindle
+  goodreads <query>            Shorthand for "search goodreads"
+  kindle <query>               Shorthand for "search kindle"
+  lookup --isbn <isbn>         Look up a book by its isbn
+  lookup --asin <asin>         Look up a book by its asin
+  help                         Show this help
+
+Run "abs-tract <command> --help" for the flags of a command.
+Configuration is loaded from environment variables, as when serving.
+`
+
+// ErrUsage is returned when a command is used incorrectly. The usage has already been written.
+var ErrUsage = errors.New("invalid usage")
+
+// Run runs the command given by the arguments (excluding the program name), writing its output to stdout
+// and its usage to stderr. If no command is given, the server is started.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	err := run(ctx, args, stdout, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return runServe(ctx, nil, stderr)
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "serve":
+		return runServe(ctx, args, stderr)
+	case "search":
+		if len(args) == 0 {
+			fmt.Fprint(stderr, usage)
+			return fmt.Errorf("%w: search requires a provider", ErrUsage)
+		}
+		return runSearch(ctx, args[0], args[1:], stdout, stderr)
+	case "goodreads", "kindle":
+		return runSearch(ctx, command, args, stdout, stderr)
+	case "lookup":
+		return runLookup(ctx, args, stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("%w: unknown command %q", ErrUsage, command)
+	}
+}
+
+// parseFlags parses the flags of a command, returning its positional arguments.
+// Unlike flag.FlagSet.Parse, flags can be given after positional arguments.
+func parseFlags(flagSet *flag.FlagSet, args []string) ([]string, error) {
+	var positionalArgs []string
+	for {
+		err := flagSet.Parse(args)
+		if err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %w", ErrUsage, err)
+		}
+
+		args = flagSet.Args()
+		if len(args) == 0 {
+			return positionalArgs, nil
+		}
+		positionalArgs = append(positionalArgs, args[0])
+		args = args[1:]
+	}
+}
+
+// newFlagSet creates the flag set of a command, writing its usage and errors to stderr.
+func newFlagSet(name, arguments string, stderr io.Writer) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: abs-tract %s %s\n\nFlags:\n", name, arguments)
+		flagSet.PrintDefaults()
+	}
+	return flagSet
+}
+
+// loadConfig loads the configuration from environment variables.
+func loadConfig() (config.Config, error) {
+	cfg, err := config.FromEnv()
+	if err != nil {
+		return config.Config{}, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg, nil
+}
+
+// joinArgs joins positional arguments into a single query, so queries do not need quoting.
+func joinArgs(args []string) string {
+	return strings.TrimSpace(strings.Join(args, " "))
+}
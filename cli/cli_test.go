@@ -0,0 +1,145 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/cli"
+	"github.com/ahobsonsayers/abs-tract/fixture"
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/ahobsonsayers/abs-tract/server"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchGoodreads(t *testing.T) {
+	setTestProviders(t)
+
+	stdout, _, err := run(t, "search", "goodreads", "The Hobbit", "--author", "J.R.R. Tolkien")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 3)
+	require.Regexp(t, `^TITLE\s+AUTHOR\s+YEAR\s+SERIES\s+ISBN\s+ASIN\s+CONFIDENCE$`, lines[0])
+	require.Contains(t, lines[1], "The Hobbit, or There and Back Again")
+	require.Contains(t, lines[1], "Middle Earth #0")
+	require.Contains(t, lines[1], "9780618260300")
+}
+
+func TestSearchGoodreadsJSON(t *testing.T) {
+	setTestProviders(t)
+
+	stdout, _, err := run(
+		t, "goodreads", "The", "Hobbit", "--author", "J.R.R. Tolkien", "--limit", "1", "--output", "json",
+	)
+	require.NoError(t, err)
+
+	var books []server.BookMetadata
+	err = json.Unmarshal([]byte(stdout), &books)
+	require.NoError(t, err)
+	require.Len(t, books, 1)
+	require.Equal(t, "The Hobbit, or There and Back Again", books[0].Title)
+	require.Equal(t, "J.R.R. Tolkien", lo.FromPtr(books[0].Author))
+}
+
+func TestSearchGoodreadsRaw(t *testing.T) {
+	setTestProviders(t)
+
+	stdout, _, err := run(t, "goodreads", "The Hobbit", "--author", "J.R.R. Tolkien", "--raw")
+	require.NoError(t, err)
+
+	var books []goodreads.Book
+	err = json.Unmarshal([]byte(stdout), &books)
+	require.NoError(t, err)
+	require.NotEmpty(t, books)
+	require.Equal(t, "The Hobbit, or There and Back Again", books[0].BestEdition.Title())
+}
+
+func TestSearchKindle(t *testing.T) {
+	setTestProviders(t)
+
+	stdout, _, err := run(t, "kindle", "--region", "uk", "The Hobbit", "--author", "J.R.R. Tolkien", "--output", "json")
+	require.NoError(t, err)
+
+	var books []server.BookMetadata
+	err = json.Unmarshal([]byte(stdout), &books)
+	require.NoError(t, err)
+	require.NotEmpty(t, books)
+	require.Contains(t, books[0].Title, "The Hobbit")
+	require.NotEmpty(t, lo.FromPtr(books[0].Asin))
+
+	stdout, _, err = run(t, "kindle", "--region", "uk", "The Hobbit", "--author", "J.R.R. Tolkien", "--raw")
+	require.NoError(t, err)
+
+	var rawBooks []kindle.Book
+	err = json.Unmarshal([]byte(stdout), &rawBooks)
+	require.NoError(t, err)
+	require.Equal(t, lo.FromPtr(books[0].Asin), rawBooks[0].ASIN)
+}
+
+func TestUsage(t *testing.T) {
+	tests := map[string][]string{
+		"unknown command":       {"find", "The Hobbit"},
+		"unknown provider":      {"search", "audible", "The Hobbit"},
+		"missing provider":      {"search"},
+		"missing query":         {"search", "goodreads", "--author", "J.R.R. Tolkien"},
+		"unknown flag":          {"goodreads", "The Hobbit", "--narrator", "Andy Serkis"},
+		"unknown output format": {"goodreads", "The Hobbit", "--output", "xml"},
+		"lookup no identifier":  {"lookup"},
+		"lookup two identifier": {"lookup", "--isbn", "9780618260300", "--asin", "B007978NPG"},
+		"serve arguments":       {"serve", "now"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, stderr, err := run(t, args...)
+			require.ErrorIs(t, err, cli.ErrUsage)
+			require.Contains(t, stderr, "Usage: abs-tract")
+		})
+	}
+}
+
+func TestHelp(t *testing.T) {
+	stdout, _, err := run(t, "help")
+	require.NoError(t, err)
+	require.Contains(t, stdout, "search goodreads <query>")
+
+	_, stderr, err := run(t, "lookup", "--help")
+	require.NoError(t, err)
+	require.Contains(t, stderr, "-isbn")
+}
+
+func run(t *testing.T, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+
+	var stdoutBuffer, stderrBuffer bytes.Buffer
+	err = cli.Run(context.Background(), args, &stdoutBuffer, &stderrBuffer)
+	return stdoutBuffer.String(), stderrBuffer.String(), err
+}
+
+// setTestProviders sets the providers used by commands to servers serving recorded fixtures
+func setTestProviders(t *testing.T) {
+	goodreadsFixtureServer := fixture.NewServer(t, "../testdata/goodreads", goodreads.DefaultGoodreadsUrl)
+	goodreadsFixtureProxy := httputil.NewSingleHostReverseProxy(lo.Must(url.Parse(goodreadsFixtureServer.URL)))
+	goodreadsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Editions of works are not recorded, so books are never localised to other editions
+		if strings.HasPrefix(r.URL.Path, "/work/editions/") {
+			http.NotFound(w, r)
+			return
+		}
+		goodreadsFixtureProxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(goodreadsServer.Close)
+	kindleServer := fixture.NewServer(t, "../testdata/kindle", "https://www.amazon.co.uk")
+
+	t.Setenv("GOODREADS_URL", goodreadsServer.URL)
+	t.Setenv("KINDLE_URL", kindleServer.URL)
+}
@@ -0,0 +1,322 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ahobsonsayers/abs-tract/config"
+	"github.com/ahobsonsayers/abs-tract/description"
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/ahobsonsayers/abs-tract/server"
+	"github.com/samber/lo"
+)
+
+const (
+	providerGoodreads = "goodreads"
+	providerKindle    = "kindle"
+)
+
+// outputOptions are the options of how books are written.
+type outputOptions struct {
+	// format is the format books are written in. Either "table" or "json"
+	format string
+	// raw is whether the books parsed from the provider are dumped, instead of the books returned by the server
+	raw bool
+}
+
+func addOutputFlags(flagSet *flag.FlagSet) *outputOptions {
+	var options outputOptions
+	flagSet.StringVar(&options.format, "output", "table", `Output format. Either "table" or "json"`)
+	flagSet.BoolVar(&options.raw, "raw", false, "Dump the books parsed from the provider as json, before conversion")
+	return &options
+}
+
+// searchOptions are the options of a search.
+type searchOptions struct {
+	provider string
+	query    string
+	author   *string
+	region   string
+	limit    int
+}
+
+// runSearch searches for books using a provider, writing the books found.
+func runSearch(ctx context.Context, provider string, args []string, stdout, stderr io.Writer) error {
+	flagSet := newFlagSet("search "+provider, "<query> [flags]", stderr)
+	author := flagSet.String("author", "", "Author of the book")
+	limit := flagSet.Int("limit", 10, "Maximum number of books")
+	region := flagSet.String("region", "us", `Kindle region e.g. "uk" or "us"`)
+	output := addOutputFlags(flagSet)
+
+	positionalArgs, err := parseFlags(flagSet, args)
+	if err != nil {
+		return err
+	}
+
+	searchQuery := joinArgs(positionalArgs)
+	if searchQuery == "" {
+		flagSet.Usage()
+		return fmt.Errorf("%w: a query must be given", ErrUsage)
+	}
+
+	err = validateSearch(flagSet, provider, *output)
+	if err != nil {
+		return err
+	}
+
+	return search(ctx, searchOptions{
+		provider: provider,
+		query:    searchQuery,
+		author:   lo.EmptyableToPtr(strings.TrimSpace(*author)),
+		region:   *region,
+		limit:    *limit,
+	}, *output, "", stdout, stderr)
+}
+
+// runLookup looks up a book by its isbn or asin, writing the books found.
+// Books with the isbn or asin are written first.
+func runLookup(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flagSet := newFlagSet("lookup", "--isbn <isbn> | --asin <asin> [flags]", stderr)
+	isbn := flagSet.String("isbn", "", "ISBN of the book")
+	asin := flagSet.String("asin", "", "ASIN of the book")
+	provider := flagSet.String(
+		"provider", "",
+		`Provider to look up the book using. Either "goodreads" or "kindle". `+
+			`Defaults to goodreads for isbns and kindle for asins`,
+	)
+	limit := flagSet.Int("limit", 5, "Maximum number of books")
+	region := flagSet.String("region", "us", `Kindle region e.g. "uk" or "us"`)
+	output := addOutputFlags(flagSet)
+
+	positionalArgs, err := parseFlags(flagSet, args)
+	if err != nil {
+		return err
+	}
+	if len(positionalArgs) != 0 || (*isbn == "") == (*asin == "") {
+		flagSet.Usage()
+		return fmt.Errorf("%w: exactly one of --isbn or --asin must be given", ErrUsage)
+	}
+
+	identifier := strings.TrimSpace(lo.CoalesceOrEmpty(*isbn, *asin))
+	if *provider == "" {
+		*provider = lo.Ternary(*isbn != "", providerGoodreads, providerKindle)
+	}
+
+	err = validateSearch(flagSet, *provider, *output)
+	if err != nil {
+		return err
+	}
+
+	return search(ctx, searchOptions{
+		provider: *provider,
+		query:    identifier,
+		region:   *region,
+		limit:    *limit,
+	}, *output, identifier, stdout, stderr)
+}
+
+// validateSearch validates the provider and output of a search, writing the usage of the command if invalid.
+func validateSearch(flagSet *flag.FlagSet, provider string, output outputOptions) error {
+	if provider != providerGoodreads && provider != providerKindle {
+		flagSet.Usage()
+		return fmt.Errorf("%w: unknown provider %q", ErrUsage, provider)
+	}
+	if output.format != "table" && output.format != "json" {
+		flagSet.Usage()
+		return fmt.Errorf("%w: unknown output format %q", ErrUsage, output.format)
+	}
+	return nil
+}
+
+// search searches for books, writing them to stdout. If identifier is set, books with
+// the isbn or asin are written first.
+func search(
+	ctx context.Context,
+	options searchOptions,
+	output outputOptions,
+	identifier string,
+	stdout io.Writer,
+	stderr io.Writer,
+) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if output.raw {
+		books, err := searchRawBooks(ctx, cfg, options)
+		if err != nil {
+			return err
+		}
+		return writeJSON(stdout, books)
+	}
+
+	books, err := searchBooks(ctx, cfg, options)
+	if err != nil {
+		return err
+	}
+
+	if identifier != "" {
+		slices.SortStableFunc(books, func(a, b server.BookMetadata) int {
+			return lo.Ternary(hasIdentifier(a, identifier), 0, 1) - lo.Ternary(hasIdentifier(b, identifier), 0, 1)
+		})
+	}
+
+	if output.format == "json" {
+		return writeJSON(stdout, books)
+	}
+	if len(books) == 0 {
+		fmt.Fprintln(stderr, "No books found")
+		return nil
+	}
+	return writeBooksTable(stdout, books)
+}
+
+// searchBooks searches for books using the client of a provider, converting them to the books returned
+// by the server. Unlike the server, goodreads books are not localised and covers are not analysed.
+func searchBooks(ctx context.Context, cfg config.Config, options searchOptions) ([]server.BookMetadata, error) {
+	searchQuery := query.Parse(options.query, lo.FromPtr(options.author))
+
+	var books []server.BookMetadata
+	switch options.provider {
+	case providerGoodreads:
+		descriptionFormat := description.DefaultFormat
+		if cfg.DescriptionFormat != "" {
+			parsedDescriptionFormat := description.Formats.Parse(cfg.DescriptionFormat)
+			if parsedDescriptionFormat == nil {
+				return nil, fmt.Errorf("invalid description format: %s", cfg.DescriptionFormat)
+			}
+			descriptionFormat = *parsedDescriptionFormat
+		}
+
+		goodreadsBooks, err := searchGoodreadsBooks(ctx, cfg, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, goodreadsBook := range goodreadsBooks {
+			books = append(books, server.GoodreadsBookToBookMetadata(goodreadsBook, descriptionFormat))
+		}
+
+	case providerKindle:
+		kindleBooks, err := searchKindleBooks(ctx, cfg, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, kindleBook := range kindleBooks {
+			books = append(books, server.KindleBookToBookMetadata(kindleBook))
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown provider %q", options.provider)
+	}
+
+	for idx := range books {
+		books[idx].Confidence = lo.ToPtr(server.BookConfidence(searchQuery, books[idx]))
+	}
+
+	return books, nil
+}
+
+// searchRawBooks searches for books using the client of a provider, returning
+// the books parsed from the provider before they are converted.
+func searchRawBooks(ctx context.Context, cfg config.Config, options searchOptions) (any, error) {
+	switch options.provider {
+	case providerGoodreads:
+		return searchGoodreadsBooks(ctx, cfg, options)
+	case providerKindle:
+		return searchKindleBooks(ctx, cfg, options)
+	}
+
+	return nil, fmt.Errorf("unknown provider %q", options.provider)
+}
+
+// searchGoodreadsBooks searches for books using a goodreads client, returning up to the limit of books.
+func searchGoodreadsBooks(ctx context.Context, cfg config.Config, options searchOptions) ([]goodreads.Book, error) {
+	client, err := server.NewGoodreadsClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := query.Search(
+		ctx, options.query, options.author,
+		func(ctx context.Context, title string, author *string) ([]goodreads.Book, error) {
+			return client.SearchBooks(ctx, title, author)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return books[:min(len(books), options.limit)], nil
+}
+
+// searchKindleBooks searches for books using a kindle client of the region, returning up to the limit of books.
+func searchKindleBooks(ctx context.Context, cfg config.Config, options searchOptions) ([]kindle.Book, error) {
+	client, err := server.NewKindleClient(cfg, options.region)
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := query.Search(
+		ctx, options.query, options.author,
+		func(ctx context.Context, title string, author *string) ([]kindle.Book, error) {
+			return client.Search(ctx, title, author)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return books[:min(len(books), options.limit)], nil
+}
+
+// hasIdentifier returns whether a book has an isbn or asin.
+func hasIdentifier(book server.BookMetadata, identifier string) bool {
+	return lo.FromPtr(book.Isbn) == identifier || lo.FromPtr(book.Asin) == identifier
+}
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func writeBooksTable(w io.Writer, books []server.BookMetadata) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "TITLE\tAUTHOR\tYEAR\tSERIES\tISBN\tASIN\tCONFIDENCE")
+
+	for _, book := range books {
+		series := lo.Map(lo.FromPtr(book.Series), func(series server.SeriesMetadata, _ int) string {
+			if series.Sequence == nil {
+				return series.Series
+			}
+			return fmt.Sprintf("%s #%s", series.Series, *series.Sequence)
+		})
+
+		var confidence string
+		if book.Confidence != nil {
+			confidence = fmt.Sprintf("%.2f", *book.Confidence)
+		}
+
+		fmt.Fprintf(
+			table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			book.Title,
+			lo.FromPtr(book.Author),
+			lo.FromPtr(book.PublishedYear),
+			strings.Join(series, ", "),
+			lo.FromPtr(book.Isbn),
+			lo.FromPtr(book.Asin),
+			confidence,
+		)
+	}
+
+	return table.Flush()
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/ahobsonsayers/abs-tract/server"
+)
+
+const defaultServerAddress = "0.0.0.0:5555"
+
+// runServe starts the server, serving until it fails.
+func runServe(_ context.Context, args []string, stderr io.Writer) error {
+	flagSet := newFlagSet("serve", "[flags]", stderr)
+	address := flagSet.String("address", defaultServerAddress, "Address to listen on")
+
+	positionalArgs, err := parseFlags(flagSet, args)
+	if err != nil {
+		return err
+	}
+	if len(positionalArgs) != 0 {
+		flagSet.Usage()
+		return fmt.Errorf("%w: serve takes no arguments", ErrUsage)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	router, err := server.NewRouter(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create router: %w", err)
+	}
+
+	// Start the Server
+	log.Printf("Server listening on %s\n", *address)
+	err = http.ListenAndServe(*address, router)
+	if err != nil {
+		return fmt.Errorf("server exited with error: %w", err)
+	}
+
+	return nil
+}
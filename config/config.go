@@ -0,0 +1,432 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is the configuration of abs-tract.
+type Config struct {
+	// GoodreadsURL is the url of goodreads. If unset, the default goodreads url is used.
+	// Env: GOODREADS_URL
+	GoodreadsURL string
+
+	// GoodreadsSearchStrategies are the strategies used to search goodreads for books, in the order they are tried.
+	// Each is one of "title", "title-without-subtitle", "title-and-author", "author" or "all".
+	// If unset, all strategies are tried in that order.
+	// Env: GOODREADS_SEARCH_STRATEGIES (comma separated)
+	GoodreadsSearchStrategies []string
+
+	// GoodreadsSearchConfidence is the confidence (between 0 and 1) a goodreads search strategy must find
+	// a book with for searching to stop, without trying the remaining strategies.
+	// Env: GOODREADS_SEARCH_CONFIDENCE
+	GoodreadsSearchConfidence float64
+
+	// Languages are the preferred languages of book metadata, most preferred first. Each is either a
+	// language code (e.g. "de" or "ger") or name (e.g. "German"). Goodreads books are returned in their edition
+	// in the most preferred language, and kindle searches without a region use the marketplace of the language.
+	// Env: LANGUAGES (comma separated)
+	Languages []string
+
+	// KindleURL is the url of amazon, used instead of the url of the kindle region.
+	// If unset, the url of the region is used. This is mostly useful for testing.
+	// Env: KINDLE_URL
+	KindleURL string
+
+	// KindleImpersonations are the browsers that requests to amazon are made to look like they are from,
+	// rotated when requests are blocked. Each is one of "chrome", "firefox", "safari" or "none".
+	// Env: KINDLE_IMPERSONATION (comma separated)
+	KindleImpersonations []string
+
+	// KindleProxies are the urls of http, https or socks5 proxies to rotate requests to amazon between.
+	// Env: KINDLE_PROXIES (comma separated)
+	KindleProxies []string
+
+	// KindleProxyRotation is when requests to amazon are rotated to the next proxy.
+	// Either "request" to rotate every request, or "block" to rotate when a proxy is blocked.
+	// Env: KINDLE_PROXY_ROTATION
+	KindleProxyRotation string
+
+	// KindleProxyUnhealthyTimeout is the time a blocked or failing proxy is removed from rotation for.
+	// Env: KINDLE_PROXY_UNHEALTHY_TIMEOUT
+	KindleProxyUnhealthyTimeout time.Duration
+
+	// ProxyURL is the url of a proxy to make requests to providers through.
+	// If unset, the proxy set by HTTP_PROXY and HTTPS_PROXY is used.
+	// Env: PROXY_URL
+	ProxyURL string
+
+	// RequestTimeout is the time limit of requests to providers. 0 for the provider default.
+	// Env: REQUEST_TIMEOUT
+	RequestTimeout time.Duration
+
+	// RequestRetries is the maximum number of times failed requests to providers are retried.
+	// Env: REQUEST_RETRIES
+	RequestRetries int
+
+	// CircuitBreakerThreshold is the number of consecutive failed requests to a provider after which
+	// requests to it are stopped, failing immediately instead. 0 disables stopping requests.
+	// Env: CIRCUIT_BREAKER_THRESHOLD
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerTimeout is the time requests to a failing provider are stopped for,
+	// before they are tried again.
+	// Env: CIRCUIT_BREAKER_TIMEOUT
+	CircuitBreakerTimeout time.Duration
+
+	// PublicURL is the url abs-tract is reachable at by clients. If set, cover urls
+	// in responses are proxied through abs-tract instead of pointing at the provider.
+	// Env: PUBLIC_URL
+	PublicURL string
+
+	// CoverCacheDir is the directory covers fetched from providers are cached in.
+	// Env: COVER_CACHE_DIR
+	CoverCacheDir string
+
+	// CoverCacheSize is the maximum total size in megabytes of cached covers, after which the
+	// least recently used covers are removed. 0 for no maximum.
+	// Env: COVER_CACHE_SIZE
+	CoverCacheSize int
+
+	// CoverMaxWidth is the maximum width of proxied covers. 0 for no maximum.
+	// Env: COVER_MAX_WIDTH
+	CoverMaxWidth int
+
+	// CoverMaxHeight is the maximum height of proxied covers. 0 for no maximum.
+	// Env: COVER_MAX_HEIGHT
+	CoverMaxHeight int
+
+	// CoverFormat is the format proxied covers are converted to. One of "jpeg", "png" or "webp".
+	// If empty, covers keep their format.
+	// Env: COVER_FORMAT
+	CoverFormat string
+
+	// CoverAnalysis fetches the candidate covers of books from all sources, rejecting placeholders and
+	// small covers, and returns the best cover with its size.
+	// Env: COVER_ANALYSIS
+	CoverAnalysis bool
+
+	// DescriptionFormat is the default format of book descriptions and author biographies.
+	// Either "plain", "markdown" or "html". Can be overridden per request.
+	// Env: DESCRIPTION_FORMAT
+	DescriptionFormat string
+
+	// RatingTags adds the average rating of a book to its tags, allowing filtering by rating.
+	// Env: RATING_TAGS
+	RatingTags bool
+
+	// GenreTaxonomyFile is the path to a yaml file mapping goodreads shelves to genres.
+	// If unset, the built-in taxonomy is used.
+	// Env: GENRE_TAXONOMY_FILE
+	GenreTaxonomyFile string
+
+	// MaxGenres is the maximum number of genres returned for a book. 0 for no maximum.
+	// Env: MAX_GENRES
+	MaxGenres int
+
+	// MinGenreShelfCount is the minimum number of users that must have added a book to
+	// a shelf for the shelf to be used as a genre.
+	// Env: MIN_GENRE_SHELF_COUNT
+	MinGenreShelfCount int
+
+	// MaxTags is the maximum number of tags returned for a book. 0 for no maximum.
+	// Env: MAX_TAGS
+	MaxTags int
+
+	// MinTagShelfWeight is the minimum popularity of a shelf for it to be used as a tag,
+	// relative to the most popular shelf of a book. Between 0 and 1.
+	// Env: MIN_TAG_SHELF_WEIGHT
+	MinTagShelfWeight float64
+
+	// TagBlocklist is a list of additional patterns (regular expressions) of shelves
+	// that should never be used as tags.
+	// Env: TAG_BLOCKLIST (comma separated)
+	TagBlocklist []string
+
+	// BatchDir is the directory batch match jobs and their results are saved in,
+	// so unfinished jobs are resumed when restarted.
+	// Env: BATCH_DIR
+	BatchDir string
+
+	// BatchWorkers is the number of items of batch match jobs matched at the same time.
+	// Env: BATCH_WORKERS
+	BatchWorkers int
+
+	// BatchRetention is how long completed batch match jobs and their results are kept for.
+	// If 0, completed jobs are kept forever.
+	// Env: BATCH_RETENTION
+	BatchRetention time.Duration
+
+	// AbsURL is the url of an audiobookshelf server whose libraries can be enriched.
+	// If unset, enriching libraries is disabled.
+	// Env: ABS_URL
+	AbsURL string
+
+	// AbsToken is the api token of the audiobookshelf user libraries are enriched as.
+	// Env: ABS_TOKEN
+	AbsToken string
+
+	// LocalLibraryDirs are the directories of local libraries of books, searched using their audio file tags
+	// and OPF files. If unset, searching local libraries is disabled.
+	// Env: LOCAL_LIBRARY_DIRS (comma separated)
+	LocalLibraryDirs []string
+
+	// LocalLibraryScanInterval is how often local libraries are scanned for changes when searched.
+	// 0 to only scan local libraries once.
+	// Env: LOCAL_LIBRARY_SCAN_INTERVAL
+	LocalLibraryScanInterval time.Duration
+
+	// CalibreLibraryDir is the directory of a calibre library (containing its metadata.db), which is only read.
+	// If unset, searching calibre is disabled.
+	// Env: CALIBRE_LIBRARY_DIR
+	CalibreLibraryDir string
+}
+
+// FromEnv loads the configuration from environment variables.
+// Unset environment variables will use the default configuration.
+// Will return an error if an environment variable is invalid.
+func FromEnv() (Config, error) {
+	var config Config
+	var err error
+
+	config.GoodreadsURL = envString("GOODREADS_URL", "")
+	config.GoodreadsSearchStrategies = envList("GOODREADS_SEARCH_STRATEGIES")
+
+	config.GoodreadsSearchConfidence, err = envFloat("GOODREADS_SEARCH_CONFIDENCE", 0.8)
+	if err != nil {
+		return Config{}, err
+	}
+	if config.GoodreadsSearchConfidence > 1 {
+		return Config{}, errors.New("invalid GOODREADS_SEARCH_CONFIDENCE: must be between 0 and 1")
+	}
+
+	config.Languages = envList("LANGUAGES")
+	config.KindleURL = envString("KINDLE_URL", "")
+	config.KindleImpersonations = envList("KINDLE_IMPERSONATION")
+	config.KindleProxies = envList("KINDLE_PROXIES")
+	config.KindleProxyRotation = envString("KINDLE_PROXY_ROTATION", "request")
+
+	config.KindleProxyUnhealthyTimeout, err = envDuration("KINDLE_PROXY_UNHEALTHY_TIMEOUT", 10*time.Minute)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.ProxyURL = envString("PROXY_URL", "")
+
+	config.RequestTimeout, err = envDuration("REQUEST_TIMEOUT", 0)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.RequestRetries, err = envInt("REQUEST_RETRIES", 2)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.CircuitBreakerThreshold, err = envInt("CIRCUIT_BREAKER_THRESHOLD", 5)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.CircuitBreakerTimeout, err = envDuration("CIRCUIT_BREAKER_TIMEOUT", time.Minute)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.PublicURL = strings.TrimRight(envString("PUBLIC_URL", ""), "/")
+	config.CoverCacheDir = envString("COVER_CACHE_DIR", filepath.Join(os.TempDir(), "abs-tract", "covers"))
+
+	config.CoverCacheSize, err = envInt("COVER_CACHE_SIZE", 500)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.CoverMaxWidth, err = envInt("COVER_MAX_WIDTH", 0)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.CoverMaxHeight, err = envInt("COVER_MAX_HEIGHT", 0)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.CoverFormat = envString("COVER_FORMAT", "")
+
+	config.CoverAnalysis, err = envBool("COVER_ANALYSIS", false)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.DescriptionFormat = envString("DESCRIPTION_FORMAT", "plain")
+
+	config.RatingTags, err = envBool("RATING_TAGS", false)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.GenreTaxonomyFile = envString("GENRE_TAXONOMY_FILE", "")
+
+	config.MaxGenres, err = envInt("MAX_GENRES", 3)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.MinGenreShelfCount, err = envInt("MIN_GENRE_SHELF_COUNT", 0)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.MaxTags, err = envInt("MAX_TAGS", 10)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.MinTagShelfWeight, err = envFloat("MIN_TAG_SHELF_WEIGHT", 0.03)
+	if err != nil {
+		return Config{}, err
+	}
+	if config.MinTagShelfWeight > 1 {
+		return Config{}, errors.New("invalid MIN_TAG_SHELF_WEIGHT: must be between 0 and 1")
+	}
+
+	config.TagBlocklist = envList("TAG_BLOCKLIST")
+	config.BatchDir = envString("BATCH_DIR", filepath.Join(os.TempDir(), "abs-tract", "batches"))
+
+	config.BatchWorkers, err = envInt("BATCH_WORKERS", 2)
+	if err != nil {
+		return Config{}, err
+	}
+	if config.BatchWorkers == 0 {
+		return Config{}, errors.New("invalid BATCH_WORKERS: must be at least 1")
+	}
+
+	config.BatchRetention, err = envDuration("BATCH_RETENTION", 24*time.Hour)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.AbsURL = envString("ABS_URL", "")
+	config.AbsToken = envString("ABS_TOKEN", "")
+	if config.AbsURL != "" && config.AbsToken == "" {
+		return Config{}, errors.New("invalid ABS_TOKEN: must be set if ABS_URL is set")
+	}
+
+	config.LocalLibraryDirs = envList("LOCAL_LIBRARY_DIRS")
+
+	config.LocalLibraryScanInterval, err = envDuration("LOCAL_LIBRARY_SCAN_INTERVAL", time.Hour)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config.CalibreLibraryDir = envString("CALIBRE_LIBRARY_DIR", "")
+
+	return config, nil
+}
+
+// envString gets the value of a string environment variable.
+// If the variable is unset or empty, the default value is returned.
+func envString(key, defaultValue string) string {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// envList gets the values of a comma separated list environment variable.
+// Empty values are removed. If the variable is unset or empty, nil is returned.
+func envList(key string) []string {
+	value := envString(key, "")
+	if value == "" {
+		return nil
+	}
+
+	var values []string
+	for _, listValue := range strings.Split(value, ",") {
+		listValue = strings.TrimSpace(listValue)
+		if listValue != "" {
+			values = append(values, listValue)
+		}
+	}
+
+	return values
+}
+
+// envBool gets the value of a boolean environment variable.
+// If the variable is unset or empty, the default value is returned.
+func envBool(key string, defaultValue bool) (bool, error) {
+	value := envString(key, "")
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsedValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return parsedValue, nil
+}
+
+// envInt gets the value of a non-negative integer environment variable.
+// If the variable is unset or empty, the default value is returned.
+func envInt(key string, defaultValue int) (int, error) {
+	value := envString(key, "")
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsedValue, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	if parsedValue < 0 {
+		return 0, fmt.Errorf("invalid %s: must not be negative", key)
+	}
+
+	return parsedValue, nil
+}
+
+// envFloat gets the value of a non-negative float environment variable.
+// If the variable is unset or empty, the default value is returned.
+func envFloat(key string, defaultValue float64) (float64, error) {
+	value := envString(key, "")
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsedValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	if parsedValue < 0 {
+		return 0, fmt.Errorf("invalid %s: must not be negative", key)
+	}
+
+	return parsedValue, nil
+}
+
+// envDuration gets the value of a non-negative duration environment variable (e.g. "30s").
+// If the variable is unset or empty, the default value is returned.
+func envDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	value := envString(key, "")
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsedValue, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	if parsedValue < 0 {
+		return 0, fmt.Errorf("invalid %s: must not be negative", key)
+	}
+
+	return parsedValue, nil
+}
@@ -0,0 +1,155 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromEnvDefaults(t *testing.T) {
+	cfg, err := config.FromEnv()
+	require.NoError(t, err)
+	require.Empty(t, cfg.GoodreadsURL)
+	require.Empty(t, cfg.GoodreadsSearchStrategies)
+	require.InDelta(t, 0.8, cfg.GoodreadsSearchConfidence, 0)
+	require.Empty(t, cfg.Languages)
+	require.Empty(t, cfg.KindleURL)
+	require.Empty(t, cfg.KindleImpersonations)
+	require.Empty(t, cfg.KindleProxies)
+	require.Equal(t, "request", cfg.KindleProxyRotation)
+	require.Equal(t, 10*time.Minute, cfg.KindleProxyUnhealthyTimeout)
+	require.Empty(t, cfg.ProxyURL)
+	require.Zero(t, cfg.RequestTimeout)
+	require.Equal(t, 2, cfg.RequestRetries)
+	require.Equal(t, 5, cfg.CircuitBreakerThreshold)
+	require.Equal(t, time.Minute, cfg.CircuitBreakerTimeout)
+	require.Empty(t, cfg.PublicURL)
+	require.Equal(t, filepath.Join(os.TempDir(), "abs-tract", "covers"), cfg.CoverCacheDir)
+	require.Equal(t, 500, cfg.CoverCacheSize)
+	require.Zero(t, cfg.CoverMaxWidth)
+	require.Zero(t, cfg.CoverMaxHeight)
+	require.Empty(t, cfg.CoverFormat)
+	require.False(t, cfg.CoverAnalysis)
+	require.Equal(t, "plain", cfg.DescriptionFormat)
+	require.False(t, cfg.RatingTags)
+	require.Empty(t, cfg.GenreTaxonomyFile)
+	require.Equal(t, 3, cfg.MaxGenres)
+	require.Equal(t, 0, cfg.MinGenreShelfCount)
+	require.Equal(t, 10, cfg.MaxTags)
+	require.InDelta(t, 0.03, cfg.MinTagShelfWeight, 0)
+	require.Empty(t, cfg.TagBlocklist)
+	require.Equal(t, filepath.Join(os.TempDir(), "abs-tract", "batches"), cfg.BatchDir)
+	require.Equal(t, 2, cfg.BatchWorkers)
+	require.Equal(t, 24*time.Hour, cfg.BatchRetention)
+	require.Empty(t, cfg.AbsURL)
+	require.Empty(t, cfg.AbsToken)
+	require.Empty(t, cfg.LocalLibraryDirs)
+	require.Equal(t, time.Hour, cfg.LocalLibraryScanInterval)
+	require.Empty(t, cfg.CalibreLibraryDir)
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("GOODREADS_URL", "http://localhost:8080")
+	t.Setenv("GOODREADS_SEARCH_STRATEGIES", "title,author")
+	t.Setenv("GOODREADS_SEARCH_CONFIDENCE", "0.5")
+	t.Setenv("LANGUAGES", "de, fr,English")
+	t.Setenv("KINDLE_URL", "http://localhost:8081")
+	t.Setenv("KINDLE_IMPERSONATION", "firefox,safari")
+	t.Setenv("KINDLE_PROXIES", "http://proxy1:3128,socks5://proxy2:1080")
+	t.Setenv("KINDLE_PROXY_ROTATION", "block")
+	t.Setenv("KINDLE_PROXY_UNHEALTHY_TIMEOUT", "1h")
+	t.Setenv("PROXY_URL", "http://proxy:3128")
+	t.Setenv("REQUEST_TIMEOUT", "30s")
+	t.Setenv("REQUEST_RETRIES", "0")
+	t.Setenv("CIRCUIT_BREAKER_THRESHOLD", "0")
+	t.Setenv("CIRCUIT_BREAKER_TIMEOUT", "5m")
+	t.Setenv("PUBLIC_URL", "http://abs-tract:5555/")
+	t.Setenv("COVER_CACHE_DIR", "/cache/covers")
+	t.Setenv("COVER_CACHE_SIZE", "100")
+	t.Setenv("COVER_MAX_WIDTH", "400")
+	t.Setenv("COVER_MAX_HEIGHT", "600")
+	t.Setenv("COVER_FORMAT", "jpeg")
+	t.Setenv("COVER_ANALYSIS", "true")
+	t.Setenv("DESCRIPTION_FORMAT", "markdown")
+	t.Setenv("RATING_TAGS", "true")
+	t.Setenv("GENRE_TAXONOMY_FILE", "/config/genres.yaml")
+	t.Setenv("MAX_GENRES", "5")
+	t.Setenv("MIN_GENRE_SHELF_COUNT", "100")
+	t.Setenv("MAX_TAGS", "0")
+	t.Setenv("MIN_TAG_SHELF_WEIGHT", "0.5")
+	t.Setenv("TAG_BLOCKLIST", "spoilers, ,^signed$")
+	t.Setenv("BATCH_DIR", "/data/batches")
+	t.Setenv("BATCH_WORKERS", "4")
+	t.Setenv("BATCH_RETENTION", "168h")
+	t.Setenv("ABS_URL", "http://audiobookshelf:13378")
+	t.Setenv("ABS_TOKEN", "token")
+	t.Setenv("LOCAL_LIBRARY_DIRS", "/audiobooks,/podcasts")
+	t.Setenv("LOCAL_LIBRARY_SCAN_INTERVAL", "0")
+	t.Setenv("CALIBRE_LIBRARY_DIR", "/books")
+
+	cfg, err := config.FromEnv()
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8080", cfg.GoodreadsURL)
+	require.Equal(t, []string{"title", "author"}, cfg.GoodreadsSearchStrategies)
+	require.InDelta(t, 0.5, cfg.GoodreadsSearchConfidence, 0)
+	require.Equal(t, []string{"de", "fr", "English"}, cfg.Languages)
+	require.Equal(t, "http://localhost:8081", cfg.KindleURL)
+	require.Equal(t, []string{"firefox", "safari"}, cfg.KindleImpersonations)
+	require.Equal(t, []string{"http://proxy1:3128", "socks5://proxy2:1080"}, cfg.KindleProxies)
+	require.Equal(t, "block", cfg.KindleProxyRotation)
+	require.Equal(t, time.Hour, cfg.KindleProxyUnhealthyTimeout)
+	require.Equal(t, "http://proxy:3128", cfg.ProxyURL)
+	require.Equal(t, 30*time.Second, cfg.RequestTimeout)
+	require.Zero(t, cfg.RequestRetries)
+	require.Zero(t, cfg.CircuitBreakerThreshold)
+	require.Equal(t, 5*time.Minute, cfg.CircuitBreakerTimeout)
+	require.Equal(t, "http://abs-tract:5555", cfg.PublicURL)
+	require.Equal(t, "/cache/covers", cfg.CoverCacheDir)
+	require.Equal(t, 100, cfg.CoverCacheSize)
+	require.Equal(t, 400, cfg.CoverMaxWidth)
+	require.Equal(t, 600, cfg.CoverMaxHeight)
+	require.Equal(t, "jpeg", cfg.CoverFormat)
+	require.True(t, cfg.CoverAnalysis)
+	require.Equal(t, "markdown", cfg.DescriptionFormat)
+	require.True(t, cfg.RatingTags)
+	require.Equal(t, "/config/genres.yaml", cfg.GenreTaxonomyFile)
+	require.Equal(t, 5, cfg.MaxGenres)
+	require.Equal(t, 100, cfg.MinGenreShelfCount)
+	require.Equal(t, 0, cfg.MaxTags)
+	require.InDelta(t, 0.5, cfg.MinTagShelfWeight, 0)
+	require.Equal(t, []string{"spoilers", "^signed$"}, cfg.TagBlocklist)
+	require.Equal(t, "/data/batches", cfg.BatchDir)
+	require.Equal(t, 4, cfg.BatchWorkers)
+	require.Equal(t, 168*time.Hour, cfg.BatchRetention)
+	require.Equal(t, "http://audiobookshelf:13378", cfg.AbsURL)
+	require.Equal(t, "token", cfg.AbsToken)
+	require.Equal(t, []string{"/audiobooks", "/podcasts"}, cfg.LocalLibraryDirs)
+	require.Zero(t, cfg.LocalLibraryScanInterval)
+	require.Equal(t, "/books", cfg.CalibreLibraryDir)
+}
+
+func TestFromEnvInvalid(t *testing.T) {
+	tests := map[string]string{
+		"RATING_TAGS":                 "not a bool",
+		"MAX_GENRES":                  "-1",
+		"COVER_CACHE_SIZE":            "1GB",
+		"REQUEST_TIMEOUT":             "30",
+		"MIN_TAG_SHELF_WEIGHT":        "1.5",
+		"GOODREADS_SEARCH_CONFIDENCE": "2",
+		"BATCH_WORKERS":               "0",
+		"BATCH_RETENTION":             "1",
+		"ABS_URL":                     "http://audiobookshelf:13378",
+		"LOCAL_LIBRARY_SCAN_INTERVAL": "1",
+	}
+	for key, value := range tests {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			_, err := config.FromEnv()
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package cover
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+
+	mapset "github.com/deckarep/golang-set/v2"
+)
+
+const (
+	// MinWidth is the minimum width of a cover. Smaller covers are rejected.
+	MinWidth = 100
+	// MinHeight is the minimum height of a cover. Smaller covers are rejected.
+	MinHeight = 100
+
+	// Minimum and maximum aspect ratio (width / height) of a cover. Covers outside this are rejected.
+	minAspectRatio = 0.4
+	maxAspectRatio = 1.25
+
+	// Maximum number of candidate covers fetched and analysed at a time
+	maxConcurrentAnalyses = 8
+)
+
+var (
+	// ErrRejected is the error covers are rejected with if they are a placeholder, too small or not cover shaped
+	ErrRejected    = errors.New("cover rejected")
+	ErrPlaceholder = fmt.Errorf("%w: placeholder", ErrRejected)
+	ErrTooSmall    = fmt.Errorf("%w: too small", ErrRejected)
+	ErrAspectRatio = fmt.Errorf("%w: invalid aspect ratio", ErrRejected)
+
+	// placeholderPatterns are patterns in the urls of placeholder covers, shown when a book has no cover
+	placeholderPatterns = []string{"nophoto", "no-img", "no_image", "noimage", "no-image"}
+)
+
+// Candidate is a candidate cover of a book.
+type Candidate struct {
+	Provider Provider
+	URL      string
+}
+
+// Analysis is the analysis of a candidate cover.
+type Analysis struct {
+	Candidate
+	Width  int
+	Height int
+	Hash   string // Hash of the cover image, which is the same for identical covers at different urls
+}
+
+// AspectRatio is the aspect ratio (width / height) of the cover.
+func (a Analysis) AspectRatio() float64 { return float64(a.Width) / float64(a.Height) }
+
+// Score is the quality score of the cover. Higher is better.
+// Covers are scored by their size, with covers that are wider than they are tall penalised.
+func (a Analysis) Score() float64 {
+	score := float64(a.Width * a.Height)
+	if a.AspectRatio() > 1.05 {
+		score /= 2
+	}
+	return score
+}
+
+// OpenLibraryURL gets the url of the cover of a book with an isbn from open library.
+// Open library responds with not found if it does not have a cover of the book.
+func OpenLibraryURL(isbn string) string {
+	return fmt.Sprintf("https://covers.openlibrary.org/b/isbn/%s-L.jpg?default=false", isbn)
+}
+
+// Analyse fetches and analyses a candidate cover.
+// Will return an error if the cover can not be fetched, or is a placeholder, too small or not cover shaped.
+func (c *Client) Analyse(ctx context.Context, candidate Candidate) (Analysis, error) {
+	for _, pattern := range placeholderPatterns {
+		if strings.Contains(strings.ToLower(candidate.URL), pattern) {
+			return Analysis{}, ErrPlaceholder
+		}
+	}
+
+	err := validateURL(candidate.Provider, candidate.URL)
+	if err != nil {
+		return Analysis{}, err
+	}
+
+	cover, err := c.original(ctx, candidate.URL)
+	if err != nil {
+		return Analysis{}, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(cover.Data))
+	if err != nil {
+		return Analysis{}, fmt.Errorf("failed to decode cover: %w", err)
+	}
+
+	hash := sha256.Sum256(cover.Data)
+	analysis := Analysis{
+		Candidate: candidate,
+		Width:     config.Width,
+		Height:    config.Height,
+		Hash:      hex.EncodeToString(hash[:]),
+	}
+
+	if analysis.Width < MinWidth || analysis.Height < MinHeight {
+		return Analysis{}, fmt.Errorf("%w: %dx%d", ErrTooSmall, analysis.Width, analysis.Height)
+	}
+	if analysis.AspectRatio() < minAspectRatio || analysis.AspectRatio() > maxAspectRatio {
+		return Analysis{}, fmt.Errorf("%w: %dx%d", ErrAspectRatio, analysis.Width, analysis.Height)
+	}
+
+	return analysis, nil
+}
+
+// Best analyses candidate covers of a book, returning the analysis of the best cover.
+// Candidates are analysed concurrently. Candidates that fail analysis are skipped.
+// If candidates have the same score, the earliest candidate is preferred.
+// If all candidates fail analysis, the errors of all candidates are returned.
+func (c *Client) Best(ctx context.Context, candidates ...Candidate) (Analysis, error) {
+	analyses, errs := c.analyseAll(ctx, candidates)
+
+	var best *Analysis
+	for _, analysis := range analyses {
+		if analysis != nil && (best == nil || analysis.Score() > best.Score()) {
+			best = analysis
+		}
+	}
+	if best == nil {
+		return Analysis{}, errors.Join(append([]error{errors.New("no valid covers")}, errs...)...)
+	}
+
+	return *best, nil
+}
+
+// Distinct analyses candidate covers, returning the analyses of all distinct covers in the order of the candidates.
+// Candidates are analysed concurrently. Candidates that fail analysis are skipped. Covers are distinct if their
+// images are different, so the same cover at different urls is only returned once.
+func (c *Client) Distinct(ctx context.Context, candidates ...Candidate) []Analysis {
+	analyses, _ := c.analyseAll(ctx, candidates)
+
+	distinct := make([]Analysis, 0, len(analyses))
+	seenHashes := mapset.NewThreadUnsafeSet[string]()
+	for _, analysis := range analyses {
+		if analysis != nil && !seenHashes.Contains(analysis.Hash) {
+			distinct = append(distinct, *analysis)
+			seenHashes.Add(analysis.Hash)
+		}
+	}
+
+	return distinct
+}
+
+// analyseAll analyses candidate covers concurrently, returning the analysis or error of each candidate.
+// At most maxConcurrentAnalyses candidates are analysed at a time, as books can have many candidates.
+func (c *Client) analyseAll(ctx context.Context, candidates []Candidate) ([]*Analysis, []error) {
+	analyses := make([]*Analysis, len(candidates))
+	errs := make([]error, len(candidates))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentAnalyses)
+	for idx, candidate := range candidates {
+		wg.Add(1)
+
+		go func(candidate Candidate, idx int) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			analysis, err := c.Analyse(ctx, candidate)
+			if err != nil {
+				errs[idx] = fmt.Errorf("%s cover %s: %w", candidate.Provider.Value, candidate.URL, err)
+				return
+			}
+			analyses[idx] = &analysis
+		}(candidate, idx)
+	}
+
+	wg.Wait()
+
+	return analyses, errs
+}
@@ -0,0 +1,100 @@
+package cover_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyse(t *testing.T) {
+	client := newAnalysisTestClient(t)
+
+	analysis, err := client.Analyse(context.Background(), kindleCandidate("large.png"))
+	require.NoError(t, err)
+	require.Equal(t, 400, analysis.Width)
+	require.Equal(t, 600, analysis.Height)
+
+	_, err = client.Analyse(context.Background(), kindleCandidate("small.png"))
+	require.ErrorIs(t, err, cover.ErrTooSmall)
+
+	_, err = client.Analyse(context.Background(), kindleCandidate("wide.png"))
+	require.ErrorIs(t, err, cover.ErrAspectRatio)
+
+	_, err = client.Analyse(context.Background(), kindleCandidate("no-img-sm.png"))
+	require.ErrorIs(t, err, cover.ErrPlaceholder)
+	require.ErrorIs(t, err, cover.ErrRejected)
+}
+
+func TestBest(t *testing.T) {
+	client := newAnalysisTestClient(t)
+
+	best, err := client.Best(
+		context.Background(),
+		kindleCandidate("small.png"),
+		kindleCandidate("medium.png"),
+		kindleCandidate("missing.png"),
+		kindleCandidate("large.png"),
+		kindleCandidate("wide.png"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, kindleCandidate("large.png"), best.Candidate)
+	require.Equal(t, 400, best.Width)
+	require.Equal(t, 600, best.Height)
+}
+
+func TestBestNoValidCovers(t *testing.T) {
+	client := newAnalysisTestClient(t)
+
+	_, err := client.Best(context.Background(), kindleCandidate("small.png"), kindleCandidate("missing.png"))
+	require.ErrorIs(t, err, cover.ErrTooSmall)
+	require.ErrorIs(t, err, cover.ErrNotFound)
+}
+
+func TestDistinct(t *testing.T) {
+	client := newAnalysisTestClient(t)
+
+	distinct := client.Distinct(
+		context.Background(),
+		kindleCandidate("medium.png"),
+		kindleCandidate("large.png"),
+		kindleCandidate("small.png"),
+		kindleCandidate("medium.png"),
+		kindleCandidate("large-copy.png"),
+		kindleCandidate("missing.png"),
+	)
+	require.Len(t, distinct, 2)
+	require.Equal(t, kindleCandidate("medium.png"), distinct[0].Candidate)
+	require.Equal(t, kindleCandidate("large.png"), distinct[1].Candidate)
+	require.NotEqual(t, distinct[0].Hash, distinct[1].Hash)
+}
+
+// newAnalysisTestClient creates a client whose requests are made to a server serving covers of different sizes
+func newAnalysisTestClient(t *testing.T) *cover.Client {
+	largeCover := testCover(t, 400, 600)
+	covers := map[string]cover.Image{
+		"/images/I/large.png":      largeCover,
+		"/images/I/large-copy.png": largeCover,
+		"/images/I/medium.png":     testCover(t, 200, 300),
+		"/images/I/small.png":      testCover(t, 1, 1),
+		"/images/I/wide.png":       testCover(t, 600, 200),
+	}
+
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		coverImage, ok := covers[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(coverImage.Data)
+	}))
+}
+
+func kindleCandidate(fileName string) cover.Candidate {
+	return cover.Candidate{
+		Provider: cover.ProviderKindle,
+		URL:      "https://m.media-amazon.com/images/I/" + fileName,
+	}
+}
@@ -0,0 +1,175 @@
+package cover
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxCacheSize is the default maximum total size of the covers in a cache
+const DefaultMaxCacheSize = 500 << 20 // 500MB
+
+// tempFileExtension is the extension of covers that are still being written to a cache
+const tempFileExtension = ".tmp"
+
+// Cache is a cache of covers stored on disk.
+// When the cache is full, the least recently used covers are removed.
+type Cache struct {
+	dir     string
+	maxSize int64
+
+	mutex sync.Mutex
+	size  int64
+}
+
+// NewCache creates a new cache of covers stored in a directory, creating the directory if it does not exist.
+// Covers are removed once their total size is larger than the maximum size. If <= 0, there is no maximum.
+func NewCache(dir string, maxSize int64) (*Cache, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cover cache directory: %w", err)
+	}
+
+	cache := &Cache{dir: dir, maxSize: maxSize}
+
+	// Covers may have been cached before, e.g. if the directory is kept between restarts
+	files, err := cache.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		cache.size += file.Size()
+	}
+
+	err = cache.evict()
+	if err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// Get gets a cached cover. If the cover is not cached, false is returned.
+func (c *Cache) Get(key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read cached cover: %w", err)
+	}
+
+	// The modification time of a cover is when it was last used, so used covers are removed last.
+	// Failing to update it only means the cover may be removed sooner.
+	now := time.Now()
+	_ = os.Chtimes(c.path(key), now, now)
+
+	return data, true, nil
+}
+
+// Put caches a cover, removing the least recently used covers if the cache is full.
+func (c *Cache) Put(key string, data []byte) error {
+	// Write to a temporary file first, so a partially written cover is never read
+	file, err := os.CreateTemp(c.dir, "*"+tempFileExtension)
+	if err != nil {
+		return fmt.Errorf("failed to create cached cover: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write cached cover: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write cached cover: %w", err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Covers can be replaced, so the size of the replaced cover must be removed
+	var replacedSize int64
+	info, err := os.Stat(c.path(key))
+	if err == nil {
+		replacedSize = info.Size()
+	}
+
+	err = os.Rename(file.Name(), c.path(key))
+	if err != nil {
+		return fmt.Errorf("failed to write cached cover: %w", err)
+	}
+	c.size += int64(len(data)) - replacedSize
+
+	return c.evict()
+}
+
+// evict removes the least recently used covers until the cache is no larger than the maximum size.
+// The cache must be locked, unless it is being created.
+func (c *Cache) evict() error {
+	if c.maxSize <= 0 || c.size <= c.maxSize {
+		return nil
+	}
+
+	files, err := c.files()
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(files, func(a, b fs.FileInfo) int { return a.ModTime().Compare(b.ModTime()) })
+
+	for _, file := range files {
+		if c.size <= c.maxSize {
+			break
+		}
+
+		err = os.Remove(c.path(file.Name()))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove cached cover: %w", err)
+		}
+		c.size -= file.Size()
+	}
+
+	return nil
+}
+
+// files gets the info of the cached covers, excluding covers still being written.
+func (c *Cache) files() ([]fs.FileInfo, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cover cache directory: %w", err)
+	}
+
+	files := make([]fs.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasSuffix(entry.Name(), tempFileExtension) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			// Covers can be removed while the directory is read
+			continue
+		}
+		files = append(files, info)
+	}
+
+	return files, nil
+}
+
+func (c *Cache) path(key string) string { return filepath.Join(c.dir, key) }
+
+// cacheKey creates a cache key from its parts.
+func cacheKey(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,76 @@
+package cover_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	cache, err := cover.NewCache(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	_, ok, err := cache.Get("cover")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	err = cache.Put("cover", []byte("cover"))
+	require.NoError(t, err)
+
+	data, ok, err := cache.Get("cover")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("cover"), data)
+}
+
+func TestCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+
+	// Covers cached before the cache is created should count towards its size
+	err := os.WriteFile(filepath.Join(dir, "old"), make([]byte, 10), 0o600)
+	require.NoError(t, err)
+	oldTime := time.Now().Add(-time.Hour)
+	err = os.Chtimes(filepath.Join(dir, "old"), oldTime, oldTime)
+	require.NoError(t, err)
+
+	cache, err := cover.NewCache(dir, 25)
+	require.NoError(t, err)
+
+	err = cache.Put("first", make([]byte, 10))
+	require.NoError(t, err)
+	setUsed(t, dir, "first", time.Now().Add(-time.Minute))
+
+	// Least recently used cover should be removed when the cache is full
+	err = cache.Put("second", make([]byte, 10))
+	require.NoError(t, err)
+	requireCached(t, cache, "old", false)
+	requireCached(t, cache, "first", true)
+	requireCached(t, cache, "second", true)
+
+	// Getting a cover marks it as used, so it is kept
+	setUsed(t, dir, "second", time.Now().Add(-time.Minute))
+	setUsed(t, dir, "first", time.Now().Add(-2*time.Minute))
+	requireCached(t, cache, "first", true)
+
+	err = cache.Put("third", make([]byte, 10))
+	require.NoError(t, err)
+	requireCached(t, cache, "second", false)
+	requireCached(t, cache, "first", true)
+	requireCached(t, cache, "third", true)
+}
+
+func setUsed(t *testing.T, dir, key string, used time.Time) {
+	err := os.Chtimes(filepath.Join(dir, key), used, used)
+	require.NoError(t, err)
+}
+
+func requireCached(t *testing.T, cache *cover.Cache, key string, cached bool) {
+	t.Helper()
+	_, ok, err := cache.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, cached, ok, key)
+}
@@ -0,0 +1,223 @@
+package cover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
+)
+
+const (
+	// maxCoverSize is the maximum size of a cover that will be fetched
+	maxCoverSize = 20 << 20 // 20MB
+
+	// maxRedirects is the maximum number of redirects followed when fetching a cover
+	maxRedirects = 10
+)
+
+var DefaultClient = &Client{client: &http.Client{CheckRedirect: checkRedirect}}
+
+type providerKey struct{}
+
+// Client gets covers from providers, optionally caching them on disk.
+type Client struct {
+	client *http.Client
+	cache  *Cache
+}
+
+// ClientOption configures a client created using NewClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	httpClient   *http.Client
+	cacheDir     string
+	maxCacheSize *int64
+}
+
+// WithHTTPClient sets the http client used to fetch covers.
+// The http client is copied, as only redirects to the hosts of providers are followed.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(o *clientOptions) { o.httpClient = client }
+}
+
+// WithCacheDir sets the directory covers are cached in.
+// If not set, covers are not cached.
+func WithCacheDir(dir string) ClientOption {
+	return func(o *clientOptions) { o.cacheDir = dir }
+}
+
+// WithMaxCacheSize sets the maximum total size in bytes of cached covers, after which
+// the least recently used covers are removed. If <= 0, there is no maximum.
+// If not set, DefaultMaxCacheSize is used.
+func WithMaxCacheSize(maxSize int64) ClientOption {
+	return func(o *clientOptions) { o.maxCacheSize = &maxSize }
+}
+
+// NewClient creates a new cover client.
+// If no options are given, the client will be the same as the default client.
+// Will return an error if the cache directory can not be created.
+func NewClient(options ...ClientOption) (*Client, error) {
+	var opts clientOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	httpClient := &http.Client{}
+	if opts.httpClient != nil {
+		httpClient = lo.ToPtr(*opts.httpClient)
+	}
+	httpClient.CheckRedirect = checkRedirect
+	client := &Client{client: httpClient}
+
+	if opts.cacheDir != "" {
+		maxCacheSize := int64(DefaultMaxCacheSize)
+		if opts.maxCacheSize != nil {
+			maxCacheSize = *opts.maxCacheSize
+		}
+
+		cache, err := NewCache(opts.cacheDir, maxCacheSize)
+		if err != nil {
+			return nil, err
+		}
+		client.cache = cache
+	}
+
+	return client, nil
+}
+
+// Get gets a cover of a provider by its id, transformed using the options.
+// Will return ErrInvalidID if the id is invalid, or ErrNotFound if the cover does not exist.
+func (c *Client) Get(ctx context.Context, provider Provider, id string, options Options) (Image, error) {
+	coverUrl, err := URL(provider, id)
+	if err != nil {
+		return Image{}, err
+	}
+
+	// Redirects are only followed to the hosts of the provider
+	ctx = context.WithValue(ctx, providerKey{}, provider)
+
+	if !options.transforms() {
+		return c.original(ctx, coverUrl)
+	}
+
+	key := cacheKey(coverUrl, options.key())
+	if cover, ok := c.cached(key); ok {
+		return cover, nil
+	}
+
+	cover, err := c.original(ctx, coverUrl)
+	if err != nil {
+		return Image{}, err
+	}
+
+	cover, err = Transform(cover, options)
+	if err != nil {
+		return Image{}, err
+	}
+	c.store(key, cover)
+
+	return cover, nil
+}
+
+// original gets the original cover, fetching it if it is not cached.
+func (c *Client) original(ctx context.Context, coverUrl string) (Image, error) {
+	key := cacheKey(coverUrl)
+	if cover, ok := c.cached(key); ok {
+		return cover, nil
+	}
+
+	cover, err := c.fetch(ctx, coverUrl)
+	if err != nil {
+		return Image{}, err
+	}
+	c.store(key, cover)
+
+	return cover, nil
+}
+
+func (c *Client) fetch(ctx context.Context, coverUrl string) (Image, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, coverUrl, http.NoBody)
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return Image{}, ErrNotFound
+	}
+	err = utils.HTTPResponseError(response)
+	if err != nil {
+		return Image{}, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxCoverSize+1))
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(data) > maxCoverSize {
+		return Image{}, fmt.Errorf("cover is larger than %d bytes", maxCoverSize)
+	}
+
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") {
+		return Image{}, fmt.Errorf("cover is not an image: got content type %s", contentType)
+	}
+
+	return Image{Data: data, ContentType: contentType}, nil
+}
+
+// checkRedirect only allows covers to be redirected to the hosts of their provider,
+// so covers can not be redirected to other urls.
+func checkRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	provider, ok := request.Context().Value(providerKey{}).(Provider)
+	if !ok {
+		return errors.New("redirect of cover of unknown provider")
+	}
+
+	return validateRedirect(provider, request.URL)
+}
+
+// cached gets a cached cover. Failing to read the cache is not an error, as the cover can be fetched instead.
+func (c *Client) cached(key string) (Image, bool) {
+	if c.cache == nil {
+		return Image{}, false
+	}
+
+	data, ok, err := c.cache.Get(key)
+	if err != nil {
+		log.Printf("Failed to get cover from cache: %s", err)
+		return Image{}, false
+	}
+	if !ok {
+		return Image{}, false
+	}
+
+	return Image{Data: data, ContentType: http.DetectContentType(data)}, true
+}
+
+// store caches a cover. Failing to cache a cover is not an error, as the cover can be fetched again.
+func (c *Client) store(key string, cover Image) {
+	if c.cache == nil {
+		return
+	}
+
+	err := c.cache.Put(key, cover.Data)
+	if err != nil {
+		log.Printf("Failed to cache cover: %s", err)
+	}
+}
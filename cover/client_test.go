@@ -0,0 +1,130 @@
+package cover_test
+
+import (
+	"bytes"
+	"context"
+	"image/jpeg"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/stretchr/testify/require"
+)
+
+const testCoverUrl = "https://m.media-amazon.com/images/I/61Ng-W9EhBL.jpg"
+
+func TestClientGet(t *testing.T) {
+	original := testCover(t, 400, 600)
+
+	var requests atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write(original.Data)
+	}), cover.WithCacheDir(t.TempDir()))
+
+	id, err := cover.ID(cover.ProviderKindle, testCoverUrl)
+	require.NoError(t, err)
+
+	coverImage, err := client.Get(context.Background(), cover.ProviderKindle, id, cover.Options{})
+	require.NoError(t, err)
+	require.Equal(t, original, coverImage)
+
+	options := cover.Options{MaxHeight: 300, Format: &cover.FormatJPEG}
+	coverImage, err = client.Get(context.Background(), cover.ProviderKindle, id, options)
+	require.NoError(t, err)
+	require.Equal(t, "image/jpeg", coverImage.ContentType)
+
+	config, err := jpeg.DecodeConfig(bytes.NewReader(coverImage.Data))
+	require.NoError(t, err)
+	require.Equal(t, 200, config.Width)
+	require.Equal(t, 300, config.Height)
+
+	// Covers should be cached, so only fetched once
+	cachedCoverImage, err := client.Get(context.Background(), cover.ProviderKindle, id, options)
+	require.NoError(t, err)
+	require.Equal(t, coverImage, cachedCoverImage)
+	require.EqualValues(t, 1, requests.Load())
+}
+
+func TestClientGetNotFound(t *testing.T) {
+	client := newTestClient(t, http.NotFoundHandler())
+
+	id, err := cover.ID(cover.ProviderKindle, testCoverUrl)
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), cover.ProviderKindle, id, cover.Options{})
+	require.ErrorIs(t, err, cover.ErrNotFound)
+}
+
+func TestClientGetNotImage(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<html></html>"))
+	}))
+
+	id, err := cover.ID(cover.ProviderKindle, testCoverUrl)
+	require.NoError(t, err)
+
+	_, err = client.Get(context.Background(), cover.ProviderKindle, id, cover.Options{})
+	require.Error(t, err)
+}
+
+func TestClientGetRedirect(t *testing.T) {
+	original := testCover(t, 400, 600)
+
+	var otherRequests atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/images/I/61Ng-W9EhBL.jpg":
+			http.Redirect(w, r, "https://m.media-amazon.com/images/I/redirected.jpg", http.StatusFound)
+		case "/images/I/redirected.jpg":
+			_, _ = w.Write(original.Data)
+		case "/images/I/other.jpg":
+			http.Redirect(w, r, "http://internal.example.com/secret", http.StatusFound)
+		default:
+			otherRequests.Add(1)
+		}
+	}))
+
+	// Covers can be redirected to the hosts of their provider
+	id, err := cover.ID(cover.ProviderKindle, testCoverUrl)
+	require.NoError(t, err)
+	coverImage, err := client.Get(context.Background(), cover.ProviderKindle, id, cover.Options{})
+	require.NoError(t, err)
+	require.Equal(t, original, coverImage)
+
+	// But not to other hosts
+	id, err = cover.ID(cover.ProviderKindle, "https://m.media-amazon.com/images/I/other.jpg")
+	require.NoError(t, err)
+	_, err = client.Get(context.Background(), cover.ProviderKindle, id, cover.Options{})
+	require.Error(t, err)
+	require.Zero(t, otherRequests.Load())
+}
+
+// newTestClient creates a client whose requests are all made to a server using the handler
+func newTestClient(t *testing.T, handler http.Handler, options ...cover.ClientOption) *cover.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	transport := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		request = request.Clone(request.Context())
+		request.URL.Scheme = serverUrl.Scheme
+		request.URL.Host = serverUrl.Host
+		return http.DefaultTransport.RoundTrip(request)
+	})
+
+	options = append(options, cover.WithHTTPClient(&http.Client{Transport: transport}))
+	client, err := cover.NewClient(options...)
+	require.NoError(t, err)
+
+	return client
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) { return f(request) }
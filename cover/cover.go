@@ -0,0 +1,112 @@
+package cover
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/orsinium-labs/enum"
+)
+
+var (
+	ErrInvalidID = errors.New("invalid cover id")
+	ErrNotFound  = errors.New("cover not found")
+)
+
+// Provider is a provider of book covers.
+type Provider enum.Member[string]
+
+var (
+	providerEnum = enum.NewBuilder[string, Provider]()
+
+	ProviderGoodreads   = providerEnum.Add(Provider{"goodreads"})
+	ProviderKindle      = providerEnum.Add(Provider{"kindle"})
+	ProviderOpenLibrary = providerEnum.Add(Provider{"openlibrary"})
+
+	Providers = providerEnum.Enum()
+)
+
+// providerHosts are the hosts that covers of each provider are served from.
+// Only covers from these hosts can be fetched, so covers can not be used to proxy other urls.
+var providerHosts = map[Provider][]string{
+	ProviderGoodreads: {
+		"i.gr-assets.com",
+		"images.gr-assets.com",
+		"s.gr-assets.com",
+		"images-na.ssl-images-amazon.com",
+	},
+	ProviderKindle: {
+		"m.media-amazon.com",
+		"images-na.ssl-images-amazon.com",
+		"images-eu.ssl-images-amazon.com",
+		"images-fe.ssl-images-amazon.com",
+	},
+	ProviderOpenLibrary: {
+		"covers.openlibrary.org",
+	},
+}
+
+// providerRedirectDomains are the domains (including their subdomains) that covers of each provider
+// can be redirected to, in addition to the hosts of the provider.
+var providerRedirectDomains = map[Provider][]string{
+	ProviderOpenLibrary: {"archive.org"},
+}
+
+// ID gets the id of a cover of a provider from its url.
+// Will return an error if the url is not a cover url of the provider.
+func ID(provider Provider, coverUrl string) (string, error) {
+	err := validateURL(provider, coverUrl)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(coverUrl)), nil
+}
+
+// URL gets the url of a cover of a provider from its id.
+// Will return ErrInvalidID if the id is not the id of a cover of the provider.
+func URL(provider Provider, id string) (string, error) {
+	coverUrl, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return "", ErrInvalidID
+	}
+
+	err = validateURL(provider, string(coverUrl))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidID, err)
+	}
+
+	return string(coverUrl), nil
+}
+
+// validateURL validates a url is a cover url of a provider.
+func validateURL(provider Provider, coverUrl string) error {
+	parsedCoverUrl, err := url.Parse(coverUrl)
+	if err != nil {
+		return fmt.Errorf("invalid cover url: %w", err)
+	}
+
+	if parsedCoverUrl.Scheme != "https" && parsedCoverUrl.Scheme != "http" {
+		return fmt.Errorf("invalid cover url %s: must be a http or https url", coverUrl)
+	}
+
+	if !slices.Contains(providerHosts[provider], parsedCoverUrl.Hostname()) {
+		return fmt.Errorf("invalid cover url %s: not a %s cover url", coverUrl, provider.Value)
+	}
+
+	return nil
+}
+
+// validateRedirect validates a url a cover of a provider is redirected to is a cover url of the provider,
+// or is in a domain covers of the provider are redirected to.
+func validateRedirect(provider Provider, redirectUrl *url.URL) error {
+	host := redirectUrl.Hostname()
+	for _, domain := range providerRedirectDomains[provider] {
+		if redirectUrl.Scheme == "https" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+			return nil
+		}
+	}
+	return validateURL(provider, redirectUrl.String())
+}
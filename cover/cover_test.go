@@ -0,0 +1,36 @@
+package cover_test
+
+import (
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDAndURL(t *testing.T) {
+	coverUrl := "https://i.gr-assets.com/images/S/compressed.photo.goodreads.com/books/1546071216l/5907.jpg"
+
+	id, err := cover.ID(cover.ProviderGoodreads, coverUrl)
+	require.NoError(t, err)
+
+	parsedCoverUrl, err := cover.URL(cover.ProviderGoodreads, id)
+	require.NoError(t, err)
+	require.Equal(t, coverUrl, parsedCoverUrl)
+
+	// Goodreads cover should not be a kindle cover
+	_, err = cover.URL(cover.ProviderKindle, id)
+	require.ErrorIs(t, err, cover.ErrInvalidID)
+}
+
+func TestIDInvalid(t *testing.T) {
+	_, err := cover.ID(cover.ProviderKindle, "https://example.com/cover.jpg")
+	require.Error(t, err)
+
+	_, err = cover.ID(cover.ProviderKindle, "file://m.media-amazon.com/images/I/61Ng-W9EhBL.jpg")
+	require.Error(t, err)
+}
+
+func TestURLInvalid(t *testing.T) {
+	_, err := cover.URL(cover.ProviderKindle, "not base64!")
+	require.ErrorIs(t, err, cover.ErrInvalidID)
+}
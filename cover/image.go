@@ -0,0 +1,200 @@
+package cover
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+
+	_ "image/gif" // Register gif decoder
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/orsinium-labs/enum"
+)
+
+// jpegQuality is the quality covers are encoded as jpegs with
+const jpegQuality = 85
+
+// maxPixels is the maximum number of pixels of a cover that will be decoded to be transformed.
+// Small files can decode to huge images, so their size is checked before they are decoded.
+const maxPixels = 40_000_000
+
+// Format is a format covers can be converted to.
+// WebP covers are encoded losslessly.
+type Format enum.Member[string]
+
+var (
+	formatEnum = enum.NewBuilder[string, Format]()
+
+	FormatJPEG = formatEnum.Add(Format{"jpeg"})
+	FormatPNG  = formatEnum.Add(Format{"png"})
+	FormatWebP = formatEnum.Add(Format{"webp"})
+
+	Formats = formatEnum.Enum()
+)
+
+// Image is a cover image.
+type Image struct {
+	Data        []byte
+	ContentType string
+}
+
+// Options are options for transforming a cover.
+type Options struct {
+	// MaxWidth is the maximum width of the cover. 0 for no maximum.
+	MaxWidth int
+	// MaxHeight is the maximum height of the cover. 0 for no maximum.
+	MaxHeight int
+	// Format is the format to convert the cover to. nil to keep the format of the cover.
+	Format *Format
+	// StripMetadata strips any metadata (e.g. EXIF) from the cover.
+	StripMetadata bool
+}
+
+// transforms returns whether the options transform a cover.
+func (o Options) transforms() bool {
+	return o.MaxWidth > 0 || o.MaxHeight > 0 || o.Format != nil || o.StripMetadata
+}
+
+// key returns a key that is unique to the options, for use in cache keys.
+func (o Options) key() string {
+	format := ""
+	if o.Format != nil {
+		format = o.Format.Value
+	}
+	return strings.Join([]string{
+		strconv.Itoa(o.MaxWidth),
+		strconv.Itoa(o.MaxHeight),
+		format,
+		strconv.FormatBool(o.StripMetadata),
+	}, "-")
+}
+
+// Transform transforms a cover using the options.
+// Covers are only ever shrunk to fit the maximum size, keeping their aspect ratio.
+// As covers are re-encoded, any metadata is always stripped.
+// Will return an error if the cover is too large (in pixels) to be decoded.
+func Transform(cover Image, options Options) (Image, error) {
+	if !options.transforms() {
+		return cover, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(cover.Data))
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to decode cover: %w", err)
+	}
+	if config.Width*config.Height > maxPixels {
+		return Image{}, fmt.Errorf("cover is larger than %d pixels: %dx%d", maxPixels, config.Width, config.Height)
+	}
+
+	img, imgFormat, err := image.Decode(bytes.NewReader(cover.Data))
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to decode cover: %w", err)
+	}
+
+	width, height := fit(img.Bounds().Dx(), img.Bounds().Dy(), options.MaxWidth, options.MaxHeight)
+	if width != img.Bounds().Dx() || height != img.Bounds().Dy() {
+		img = resize(img, width, height)
+	}
+
+	format := FormatPNG
+	if imgFormat == "jpeg" {
+		format = FormatJPEG
+	}
+	if options.Format != nil {
+		format = *options.Format
+	}
+
+	var buffer bytes.Buffer
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buffer, flatten(img), &jpeg.Options{Quality: jpegQuality})
+	case FormatPNG:
+		err = png.Encode(&buffer, img)
+	case FormatWebP:
+		err = nativewebp.Encode(&buffer, img, nil)
+	}
+	if err != nil {
+		return Image{}, fmt.Errorf("failed to encode cover: %w", err)
+	}
+
+	return Image{
+		Data:        buffer.Bytes(),
+		ContentType: http.DetectContentType(buffer.Bytes()),
+	}, nil
+}
+
+// fit returns the size of an image shrunk to fit a maximum size, keeping its aspect ratio.
+// Images are never enlarged. A maximum of 0 is no maximum.
+func fit(width, height, maxWidth, maxHeight int) (int, int) {
+	scale := 1.0
+	if maxWidth > 0 && width > maxWidth {
+		scale = min(scale, float64(maxWidth)/float64(width))
+	}
+	if maxHeight > 0 && height > maxHeight {
+		scale = min(scale, float64(maxHeight)/float64(height))
+	}
+	if scale == 1 {
+		return width, height
+	}
+
+	scaledWidth := max(int(float64(width)*scale+0.5), 1)
+	scaledHeight := max(int(float64(height)*scale+0.5), 1)
+	return scaledWidth, scaledHeight
+}
+
+// resize shrinks an image to a size, averaging the pixels of the image that make up each resized pixel.
+func resize(src image.Image, width, height int) image.Image {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+
+	dst := image.NewRGBA64(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0 := bounds.Min.Y + y*srcHeight/height
+		y1 := max(bounds.Min.Y+(y+1)*srcHeight/height, y0+1)
+
+		for x := range width {
+			x0 := bounds.Min.X + x*srcWidth/width
+			x1 := max(bounds.Min.X+(x+1)*srcWidth/width, x0+1)
+
+			var r, g, b, a, count uint64
+			for srcY := y0; srcY < y1; srcY++ {
+				for srcX := x0; srcX < x1; srcX++ {
+					pixelR, pixelG, pixelB, pixelA := src.At(srcX, srcY).RGBA()
+					r += uint64(pixelR)
+					g += uint64(pixelG)
+					b += uint64(pixelB)
+					a += uint64(pixelA)
+					count++
+				}
+			}
+
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / count),
+				G: uint16(g / count),
+				B: uint16(b / count),
+				A: uint16(a / count),
+			})
+		}
+	}
+
+	return dst
+}
+
+// flatten draws an image over a white background, as jpegs do not support transparency.
+func flatten(src image.Image) image.Image {
+	if opaque, ok := src.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		return src
+	}
+
+	dst := image.NewRGBA(src.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, src.Bounds().Min, draw.Over)
+	return dst
+}
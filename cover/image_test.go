@@ -0,0 +1,110 @@
+package cover_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformResize(t *testing.T) {
+	original := testCover(t, 400, 600)
+
+	transformed, err := cover.Transform(original, cover.Options{MaxWidth: 200, MaxHeight: 200})
+	require.NoError(t, err)
+	require.Equal(t, "image/png", transformed.ContentType)
+
+	config, err := png.DecodeConfig(bytes.NewReader(transformed.Data))
+	require.NoError(t, err)
+	require.Equal(t, 133, config.Width)
+	require.Equal(t, 200, config.Height)
+}
+
+func TestTransformNoEnlarge(t *testing.T) {
+	original := testCover(t, 400, 600)
+
+	transformed, err := cover.Transform(original, cover.Options{MaxWidth: 800})
+	require.NoError(t, err)
+
+	config, err := png.DecodeConfig(bytes.NewReader(transformed.Data))
+	require.NoError(t, err)
+	require.Equal(t, 400, config.Width)
+	require.Equal(t, 600, config.Height)
+}
+
+func TestTransformFormat(t *testing.T) {
+	original := testCover(t, 40, 60)
+
+	transformed, err := cover.Transform(original, cover.Options{Format: &cover.FormatJPEG})
+	require.NoError(t, err)
+	require.Equal(t, "image/jpeg", transformed.ContentType)
+
+	config, err := jpeg.DecodeConfig(bytes.NewReader(transformed.Data))
+	require.NoError(t, err)
+	require.Equal(t, 40, config.Width)
+	require.Equal(t, 60, config.Height)
+}
+
+func TestTransformFormatWebP(t *testing.T) {
+	original := testCover(t, 40, 60)
+
+	transformed, err := cover.Transform(original, cover.Options{Format: &cover.FormatWebP})
+	require.NoError(t, err)
+	require.Equal(t, "image/webp", transformed.ContentType)
+
+	// Lossless webp header, containing the width and height minus one as 14 bit integers
+	require.Equal(t, "VP8L", string(transformed.Data[12:16]))
+	size := binary.LittleEndian.Uint32(transformed.Data[21:25])
+	require.EqualValues(t, 40, size&0x3FFF+1)
+	require.EqualValues(t, 60, size>>14&0x3FFF+1)
+}
+
+func TestTransformNoOptions(t *testing.T) {
+	original := testCover(t, 40, 60)
+
+	transformed, err := cover.Transform(original, cover.Options{})
+	require.NoError(t, err)
+	require.Equal(t, original, transformed)
+}
+
+func TestTransformInvalid(t *testing.T) {
+	_, err := cover.Transform(
+		cover.Image{Data: []byte("not an image"), ContentType: "text/plain"},
+		cover.Options{StripMetadata: true},
+	)
+	require.Error(t, err)
+}
+
+func TestTransformTooLarge(t *testing.T) {
+	// Png claiming to be 10000x10000, which would decode to 400MB
+	data := testCover(t, 1, 1).Data
+	binary.BigEndian.PutUint32(data[16:], 10000)
+	binary.BigEndian.PutUint32(data[20:], 10000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := cover.Transform(cover.Image{Data: data, ContentType: "image/png"}, cover.Options{StripMetadata: true})
+	require.ErrorContains(t, err, "10000x10000")
+}
+
+// testCover creates a png cover of a size
+func testCover(t *testing.T, width, height int) cover.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buffer bytes.Buffer
+	err := png.Encode(&buffer, img)
+	require.NoError(t, err)
+
+	return cover.Image{Data: buffer.Bytes(), ContentType: "image/png"}
+}
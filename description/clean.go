@@ -0,0 +1,130 @@
+package description
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Maximum length of boilerplate lines. Longer lines are assumed to be part of the description.
+const maxBoilerplateLength = 200
+
+var (
+	// notePatterns match notes about the edition of a book, which are removed wherever they are.
+	// e.g. "Alternate cover edition of ISBN 9780261102217" or "Librarian's note: ..."
+	notePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^\W*(note:?\s*)?(an?\s+|this\s+is\s+an?\s+)?alternat(e|ive)[\s-]+cover`),
+		regexp.MustCompile(`(?i)^\W*librarian'?s'?\s+note`),
+	}
+
+	// boilerplatePatterns match boilerplate lines such as isbn notices and promotional blurbs,
+	// which are removed from the start and end of descriptions.
+	// e.g. "Previously published as ISBN 9780261102217" or "#1 NEW YORK TIMES BESTSELLER"
+	boilerplatePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\bisbn\b`),
+		regexp.MustCompile(
+			`(?i)^\W*(#\d+\s+)?` +
+				`((international|instant|national|new\s+york\s+times|usa\s+today|sunday\s+times)\s+)*best\s*sell`,
+		),
+		regexp.MustCompile(`(?i)^\W*(now|soon\s+to\s+be)\s+an?\s`),
+		regexp.MustCompile(`(?i)^\W*(an?\s+)?[\w'’ ]*book\s+club\s+(pick|selection)\b`),
+		regexp.MustCompile(`(?i)^\W*includes?\s+(an?\s+)?(bonus\s+|exclusive\s+)?(excerpt|preview|sneak\s+peek)\b`),
+	}
+)
+
+// clean removes notes about the edition of a book from anywhere in a description,
+// and boilerplate such as isbn notices and promotional blurbs from its start and end.
+func clean(blocks []block) []block {
+	blocks = removeNotes(blocks)
+
+	// Remove boilerplate from the start
+	for len(blocks) != 0 && blocks[0].kind == paragraphBlock && isBoilerplate(blocks[0].lines[0]) {
+		blocks[0].lines = blocks[0].lines[1:]
+		if len(blocks[0].lines) == 0 {
+			blocks = blocks[1:]
+		}
+	}
+
+	// Remove boilerplate from the end
+	for len(blocks) != 0 {
+		lastBlock := &blocks[len(blocks)-1]
+		if lastBlock.kind != paragraphBlock || !isBoilerplate(lastBlock.lines[len(lastBlock.lines)-1]) {
+			break
+		}
+		lastBlock.lines = lastBlock.lines[:len(lastBlock.lines)-1]
+		if len(lastBlock.lines) == 0 {
+			blocks = blocks[:len(blocks)-1]
+		}
+	}
+
+	return blocks
+}
+
+// removeNotes removes lines of paragraphs that are notes about the edition of a book.
+// Notes at the start of a line in italics or bold are removed from the line.
+func removeNotes(blocks []block) []block {
+	cleaned := make([]block, 0, len(blocks))
+	for _, paragraph := range blocks {
+		if paragraph.kind != paragraphBlock {
+			cleaned = append(cleaned, paragraph)
+			continue
+		}
+
+		lines := make([]line, 0, len(paragraph.lines))
+		for _, paragraphLine := range paragraph.lines {
+			paragraphLine = removeLineNote(paragraphLine)
+			if len(paragraphLine) != 0 {
+				lines = append(lines, paragraphLine)
+			}
+		}
+
+		if len(lines) != 0 {
+			paragraph.lines = lines
+			cleaned = append(cleaned, paragraph)
+		}
+	}
+
+	return cleaned
+}
+
+// removeLineNote removes a note from a line. If the whole line is a note, an empty line is returned.
+func removeLineNote(l line) line {
+	// Find the styled runs at the start of the line. If they are a note on their own, rather
+	// than a label of a note that continues after them (e.g. "Librarian's note:"), only they are removed.
+	styledRuns := 0
+	for styledRuns < len(l) && (l[styledRuns].italic || l[styledRuns].bold) {
+		styledRuns++
+	}
+	styledText := strings.TrimSpace(l[:styledRuns].text())
+	if styledRuns != 0 && styledRuns != len(l) && isNote(styledText) && !strings.HasSuffix(styledText, ":") {
+		return removeLineNote(trimLine(l[styledRuns:]))
+	}
+
+	if isNote(l.text()) {
+		return nil
+	}
+
+	return l
+}
+
+func isNote(text string) bool {
+	for _, pattern := range notePatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func isBoilerplate(l line) bool {
+	text := strings.TrimSpace(l.text())
+	if len(text) > maxBoilerplateLength {
+		return false
+	}
+
+	for _, pattern := range boilerplatePatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
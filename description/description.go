@@ -0,0 +1,38 @@
+package description
+
+import "github.com/orsinium-labs/enum"
+
+// Format is a format descriptions can be converted to.
+type Format enum.Member[string]
+
+var (
+	formatEnum = enum.NewBuilder[string, Format]()
+
+	// FormatPlain is plain text, with paragraphs separated by blank lines.
+	FormatPlain = formatEnum.Add(Format{"plain"})
+	// FormatMarkdown is markdown, keeping italics, bold, links, lists and quotes.
+	FormatMarkdown = formatEnum.Add(Format{"markdown"})
+	// FormatHTML is sanitised html, only containing paragraphs, line breaks,
+	// italics, bold, links, lists and quotes.
+	FormatHTML = formatEnum.Add(Format{"html"})
+
+	Formats = formatEnum.Enum()
+
+	DefaultFormat = FormatPlain
+)
+
+// Convert converts a html description to a format. The description is cleaned, removing
+// notes about the edition (e.g. alternate cover notes and librarian's notes) and
+// boilerplate (e.g. isbn notices and promotional blurbs).
+func Convert(htmlDescription string, format Format) string {
+	blocks := clean(parse(htmlDescription))
+
+	switch format {
+	case FormatMarkdown:
+		return renderMarkdown(blocks)
+	case FormatHTML:
+		return renderHTML(blocks)
+	default:
+		return renderPlain(blocks)
+	}
+}
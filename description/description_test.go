@@ -0,0 +1,61 @@
+package description_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/description"
+	"github.com/stretchr/testify/require"
+)
+
+// UpdateGoldenEnvVar is the environment variable that, if true, updates the golden files
+// of the tests with the output of the tests, instead of comparing against them.
+const UpdateGoldenEnvVar = "UPDATE_GOLDEN"
+
+// goldenFiles are the names of the golden files of each format
+var goldenFiles = map[description.Format]string{
+	description.FormatPlain:    "plain.txt",
+	description.FormatMarkdown: "markdown.md",
+	description.FormatHTML:     "html.html",
+}
+
+// TestConvertGolden converts the input.html description of each directory in testdata
+// to each format, comparing against the golden file of the format.
+func TestConvertGolden(t *testing.T) {
+	update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenEnvVar))
+
+	testDirs, err := filepath.Glob(filepath.Join("testdata", "*"))
+	require.NoError(t, err)
+	require.NotEmpty(t, testDirs)
+
+	for _, testDir := range testDirs {
+		t.Run(filepath.Base(testDir), func(t *testing.T) {
+			input, err := os.ReadFile(filepath.Join(testDir, "input.html"))
+			require.NoError(t, err)
+
+			for format, goldenFile := range goldenFiles {
+				converted := description.Convert(string(input), format) + "\n"
+
+				goldenPath := filepath.Join(testDir, goldenFile)
+				if update {
+					err = os.WriteFile(goldenPath, []byte(converted), 0o644)
+					require.NoError(t, err)
+					continue
+				}
+
+				golden, err := os.ReadFile(goldenPath)
+				require.NoError(t, err)
+				require.Equal(t, string(golden), converted, goldenPath)
+			}
+		})
+	}
+}
+
+func TestConvertEmpty(t *testing.T) {
+	for _, format := range description.Formats.Members() {
+		require.Empty(t, description.Convert("", format))
+		require.Empty(t, description.Convert("<br /><br />  ", format))
+	}
+}
@@ -0,0 +1,264 @@
+package description
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var whitespaceRegex = regexp.MustCompile(`\s+`)
+
+// run is a run of text with the same style.
+type run struct {
+	text   string
+	italic bool
+	bold   bool
+	href   string
+}
+
+// line is a line of text, made of runs of styled text.
+type line []run
+
+// text is the plain text of the line.
+func (l line) text() string {
+	var builder strings.Builder
+	for _, run := range l {
+		builder.WriteString(run.text)
+	}
+	return builder.String()
+}
+
+type blockKind int
+
+const (
+	paragraphBlock blockKind = iota
+	listBlock
+	orderedListBlock
+	quoteBlock
+)
+
+// block is a block of a description.
+type block struct {
+	kind blockKind
+	// lines are the lines of a paragraph, or the items of a list
+	lines []line
+	// blocks are the blocks of a quote
+	blocks []block
+}
+
+// style is the style of text at a position in the html.
+type style struct {
+	italic bool
+	bold   bool
+	href   string
+}
+
+// parser parses html into blocks.
+type parser struct {
+	blocks    []block
+	lines     []line
+	line      line
+	lineBreak bool // Whether the last line ended with a line break
+}
+
+// parse parses a html description into blocks.
+// Only the structure (paragraphs, lines, lists and quotes) and the
+// style (italic, bold and links) of the description is kept.
+func parse(htmlDescription string) []block {
+	nodes, err := html.ParseFragment(
+		strings.NewReader(htmlDescription),
+		&html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body},
+	)
+	if err != nil {
+		// Parsing html fragments only fails if reading fails, which strings can not
+		return nil
+	}
+
+	var p parser
+	for _, node := range nodes {
+		p.node(node, style{})
+	}
+	p.endParagraph()
+
+	return p.blocks
+}
+
+func (p *parser) node(node *html.Node, nodeStyle style) {
+	switch node.Type {
+	case html.TextNode:
+		p.text(node.Data, nodeStyle)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch node.DataAtom {
+	case atom.Script, atom.Style, atom.Img:
+		return
+
+	case atom.Br:
+		// Two line breaks in a row are a new paragraph
+		if len(p.line) == 0 && p.lineBreak {
+			p.endParagraph()
+			return
+		}
+		p.endLine()
+		p.lineBreak = true
+		return
+
+	case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		p.endParagraph()
+		p.children(node, nodeStyle)
+		p.endParagraph()
+		return
+
+	case atom.Ul, atom.Ol:
+		p.endParagraph()
+		kind := listBlock
+		if node.DataAtom == atom.Ol {
+			kind = orderedListBlock
+		}
+		p.list(node, kind, nodeStyle)
+		return
+
+	case atom.Blockquote:
+		p.endParagraph()
+		var quoteParser parser
+		quoteParser.children(node, nodeStyle)
+		quoteParser.endParagraph()
+		if len(quoteParser.blocks) != 0 {
+			p.blocks = append(p.blocks, block{kind: quoteBlock, blocks: quoteParser.blocks})
+		}
+		return
+
+	case atom.I, atom.Em, atom.Cite:
+		nodeStyle.italic = true
+	case atom.B, atom.Strong:
+		nodeStyle.bold = true
+	case atom.A:
+		href := attr(node, "href")
+		if isLink(href) {
+			nodeStyle.href = href
+		}
+	}
+
+	// Other elements are unwrapped, keeping their content
+	p.children(node, nodeStyle)
+}
+
+func (p *parser) children(node *html.Node, nodeStyle style) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		p.node(child, nodeStyle)
+	}
+}
+
+// list parses a list, where the content of each list item is a single line.
+func (p *parser) list(node *html.Node, kind blockKind, nodeStyle style) {
+	list := block{kind: kind}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.DataAtom != atom.Li {
+			continue
+		}
+
+		var itemParser parser
+		itemParser.children(child, nodeStyle)
+		itemParser.endParagraph()
+
+		var item line
+		for _, itemBlock := range itemParser.blocks {
+			for _, itemLine := range itemBlock.lines {
+				if len(item) != 0 {
+					item = append(item, run{text: " "})
+				}
+				item = append(item, itemLine...)
+			}
+		}
+		if len(item) != 0 {
+			list.lines = append(list.lines, item)
+		}
+	}
+
+	if len(list.lines) != 0 {
+		p.blocks = append(p.blocks, list)
+	}
+}
+
+func (p *parser) text(text string, textStyle style) {
+	// Whitespace is collapsed to a single space, as in html. Spaces at the
+	// start and end are kept as they separate the words of adjacent text.
+	text = whitespaceRegex.ReplaceAllString(text, " ")
+	if text == "" || (text == " " && len(p.line) == 0) {
+		return
+	}
+
+	p.lineBreak = false
+	p.line = append(p.line, run{
+		text:   text,
+		italic: textStyle.italic,
+		bold:   textStyle.bold,
+		href:   textStyle.href,
+	})
+}
+
+// endLine ends the current line.
+func (p *parser) endLine() {
+	line := trimLine(p.line)
+	if len(line) != 0 {
+		p.lines = append(p.lines, line)
+	}
+	p.line = nil
+}
+
+// endParagraph ends the current line and paragraph.
+func (p *parser) endParagraph() {
+	p.endLine()
+	if len(p.lines) != 0 {
+		p.blocks = append(p.blocks, block{kind: paragraphBlock, lines: p.lines})
+	}
+	p.lines = nil
+	p.lineBreak = false
+}
+
+// trimLine trims the whitespace from the start and end of a line,
+// and removes the spaces duplicated between runs.
+func trimLine(l line) line {
+	trimmed := make(line, 0, len(l))
+	for _, lineRun := range l {
+		if len(trimmed) == 0 || strings.HasSuffix(trimmed[len(trimmed)-1].text, " ") {
+			lineRun.text = strings.TrimLeft(lineRun.text, " ")
+		}
+		if lineRun.text != "" {
+			trimmed = append(trimmed, lineRun)
+		}
+	}
+
+	for len(trimmed) != 0 {
+		last := &trimmed[len(trimmed)-1]
+		last.text = strings.TrimRight(last.text, " ")
+		if last.text != "" {
+			break
+		}
+		trimmed = trimmed[:len(trimmed)-1]
+	}
+
+	return trimmed
+}
+
+func attr(node *html.Node, key string) string {
+	for _, attribute := range node.Attr {
+		if attribute.Key == key {
+			return attribute.Val
+		}
+	}
+	return ""
+}
+
+// isLink returns whether a href is a http or https link.
+func isLink(href string) bool {
+	parsedHref, err := url.Parse(href)
+	return err == nil && (parsedHref.Scheme == "http" || parsedHref.Scheme == "https") && parsedHref.Host != ""
+}
@@ -0,0 +1,200 @@
+package description
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`,
+		`*`, `\*`,
+		`_`, `\_`,
+		`[`, `\[`,
+		`]`, `\]`,
+		"`", "\\`",
+	)
+
+	htmlEscaper     = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	htmlAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+	// Regex to match the start of a line that would be parsed as markdown block syntax.
+	// e.g. headings, quotes, lists and numbered lists
+	markdownBlockStartRegex = regexp.MustCompile(`^([#>+-]|\d+\.)`)
+)
+
+// renderPlain renders blocks as plain text.
+func renderPlain(blocks []block) string {
+	renderedBlocks := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		var renderedLines []string
+		switch b.kind {
+		case paragraphBlock:
+			for _, blockLine := range b.lines {
+				renderedLines = append(renderedLines, blockLine.text())
+			}
+		case listBlock, orderedListBlock:
+			for idx, item := range b.lines {
+				renderedLines = append(renderedLines, listMarker(b.kind, idx)+item.text())
+			}
+		case quoteBlock:
+			renderedLines = append(renderedLines, renderPlain(b.blocks))
+		}
+		renderedBlocks = append(renderedBlocks, strings.Join(renderedLines, "\n"))
+	}
+
+	return strings.Join(renderedBlocks, "\n\n")
+}
+
+// renderMarkdown renders blocks as markdown.
+func renderMarkdown(blocks []block) string {
+	renderedBlocks := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		var renderedLines []string
+		switch b.kind {
+		case paragraphBlock:
+			for _, blockLine := range b.lines {
+				renderedLines = append(renderedLines, escapeMarkdownBlockStart(renderMarkdownLine(blockLine)))
+			}
+			// Lines of a paragraph are separated by hard line breaks
+			renderedBlocks = append(renderedBlocks, strings.Join(renderedLines, "\\\n"))
+			continue
+		case listBlock, orderedListBlock:
+			for idx, item := range b.lines {
+				renderedLines = append(renderedLines, listMarker(b.kind, idx)+renderMarkdownLine(item))
+			}
+		case quoteBlock:
+			for _, quoteLine := range strings.Split(renderMarkdown(b.blocks), "\n") {
+				renderedLines = append(renderedLines, strings.TrimRight("> "+quoteLine, " "))
+			}
+		}
+		renderedBlocks = append(renderedBlocks, strings.Join(renderedLines, "\n"))
+	}
+
+	return strings.Join(renderedBlocks, "\n\n")
+}
+
+func renderMarkdownLine(l line) string {
+	var builder strings.Builder
+	for _, group := range groupRuns(l) {
+		// Spaces can not be inside emphasis markers, so are moved outside
+		text := group.text
+		trimmedText := strings.TrimSpace(text)
+		if trimmedText == "" {
+			builder.WriteString(text)
+			continue
+		}
+		leading := text[:strings.Index(text, trimmedText)]
+		trailing := text[len(leading)+len(trimmedText):]
+
+		rendered := markdownEscaper.Replace(trimmedText)
+		switch {
+		case group.bold && group.italic:
+			rendered = "***" + rendered + "***"
+		case group.bold:
+			rendered = "**" + rendered + "**"
+		case group.italic:
+			rendered = "*" + rendered + "*"
+		}
+		if group.href != "" {
+			rendered = fmt.Sprintf("[%s](%s)", rendered, markdownLinkEscaper.Replace(group.href))
+		}
+
+		builder.WriteString(leading)
+		builder.WriteString(rendered)
+		builder.WriteString(trailing)
+	}
+
+	return builder.String()
+}
+
+var markdownLinkEscaper = strings.NewReplacer("(", "%28", ")", "%29", " ", "%20")
+
+// escapeMarkdownBlockStart escapes the start of a line that would be parsed as markdown block syntax.
+func escapeMarkdownBlockStart(renderedLine string) string {
+	match := markdownBlockStartRegex.FindString(renderedLine)
+	if match == "" {
+		return renderedLine
+	}
+	if strings.HasSuffix(match, ".") {
+		return strings.TrimSuffix(match, ".") + `\.` + renderedLine[len(match):]
+	}
+	return `\` + renderedLine
+}
+
+// renderHTML renders blocks as html.
+func renderHTML(blocks []block) string {
+	renderedBlocks := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		var builder strings.Builder
+		switch b.kind {
+		case paragraphBlock:
+			builder.WriteString("<p>")
+			for idx, blockLine := range b.lines {
+				if idx != 0 {
+					builder.WriteString("<br>")
+				}
+				builder.WriteString(renderHTMLLine(blockLine))
+			}
+			builder.WriteString("</p>")
+		case listBlock, orderedListBlock:
+			tag := "ul"
+			if b.kind == orderedListBlock {
+				tag = "ol"
+			}
+			builder.WriteString("<" + tag + ">")
+			for _, item := range b.lines {
+				builder.WriteString("<li>" + renderHTMLLine(item) + "</li>")
+			}
+			builder.WriteString("</" + tag + ">")
+		case quoteBlock:
+			builder.WriteString("<blockquote>" + renderHTML(b.blocks) + "</blockquote>")
+		}
+		renderedBlocks = append(renderedBlocks, builder.String())
+	}
+
+	return strings.Join(renderedBlocks, "\n")
+}
+
+func renderHTMLLine(l line) string {
+	var builder strings.Builder
+	for _, group := range groupRuns(l) {
+		rendered := htmlEscaper.Replace(group.text)
+		if group.italic {
+			rendered = "<i>" + rendered + "</i>"
+		}
+		if group.bold {
+			rendered = "<b>" + rendered + "</b>"
+		}
+		if group.href != "" {
+			rendered = fmt.Sprintf(`<a href="%s">%s</a>`, htmlAttrEscaper.Replace(group.href), rendered)
+		}
+		builder.WriteString(rendered)
+	}
+	return builder.String()
+}
+
+// groupRuns merges adjacent runs of a line that have the same style.
+func groupRuns(l line) []run {
+	groups := make([]run, 0, len(l))
+	for _, lineRun := range l {
+		if len(groups) != 0 {
+			last := &groups[len(groups)-1]
+			if last.italic == lineRun.italic && last.bold == lineRun.bold && last.href == lineRun.href {
+				last.text += lineRun.text
+				continue
+			}
+		}
+		groups = append(groups, lineRun)
+	}
+	return groups
+}
+
+func listMarker(kind blockKind, idx int) string {
+	if kind == orderedListBlock {
+		return strconv.Itoa(idx+1) + ". "
+	}
+	return "- "
+}
@@ -0,0 +1,218 @@
+// Package fixture provides recorded http fixtures, allowing tests of upstream providers to be run offline.
+//
+// In replay mode (the default), a test server serves responses previously recorded to a directory.
+// In record mode (enabled by setting the RECORD_FIXTURES environment variable to true), the test server
+// instead proxies requests to the real upstream server, saving the responses to the directory.
+package fixture
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+)
+
+const (
+	RecordEnvVar  = "RECORD_FIXTURES"
+	fileExtension = ".http"
+
+	// HandWrittenHeader labels fixtures written by hand rather than recorded, with the reason they were.
+	// e.g. "error-case" for responses that can not be recorded on demand. It is not served when replaying.
+	HandWrittenHeader = "X-Fixture-Hand-Written"
+
+	// Maximum length of a fixture name before it is shortened using a hash
+	maxNameLength = 100
+)
+
+var (
+	// Query parameters that are not used to identify fixtures. These are usually secrets such as api keys.
+	ignoredQueryParams = []string{"key"}
+
+	// Request headers forwarded to the upstream server when recording
+	forwardedHeaders = []string{"Accept", "Accept-Language", "User-Agent"}
+
+	unsafeNameCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+)
+
+// Recording returns whether fixtures are being recorded.
+func Recording() bool {
+	recording, _ := strconv.ParseBool(os.Getenv(RecordEnvVar))
+	return recording
+}
+
+// NewServer creates a test server that serves fixtures from a directory.
+// If recording, requests are proxied to the upstream url, and their responses saved
+// to the directory as fixtures. The server is closed when the test finishes.
+func NewServer(t testing.TB, dir, upstreamUrl string) *httptest.Server {
+	t.Helper()
+
+	parsedUpstreamUrl, err := url.Parse(upstreamUrl)
+	if err != nil {
+		t.Fatalf("invalid upstream url: %s", err)
+	}
+
+	var handler http.Handler = &replayHandler{t: t, dir: dir}
+	if Recording() {
+		handler = &recordHandler{t: t, dir: dir, upstreamUrl: parsedUpstreamUrl}
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// Name returns the name of the fixture file for a request.
+// Names are derived from the request method, path and query parameters.
+func Name(request *http.Request) string {
+	query := request.URL.Query()
+	for _, param := range ignoredQueryParams {
+		query.Del(param)
+	}
+
+	name := strings.Join(
+		[]string{request.Method, strings.Trim(request.URL.Path, "/"), query.Encode()},
+		"_",
+	)
+	name = unsafeNameCharsRegex.ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+
+	// Shorten long names, using a hash of the full name to keep them unique
+	if len(name) > maxNameLength {
+		hash := sha256.Sum256([]byte(name))
+		name = name[:maxNameLength] + "_" + hex.EncodeToString(hash[:])[:12]
+	}
+
+	return name + fileExtension
+}
+
+// replayHandler serves recorded fixtures
+type replayHandler struct {
+	t   testing.TB
+	dir string
+}
+
+func (h *replayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fixturePath := filepath.Join(h.dir, Name(r))
+
+	fixture, err := os.ReadFile(fixturePath)
+	if err != nil {
+		h.t.Errorf("missing fixture %s for %s. Set %s=true to record it", fixturePath, r.URL, RecordEnvVar)
+		http.Error(w, "missing fixture", http.StatusNotFound)
+		return
+	}
+
+	response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(fixture)), r)
+	if err != nil {
+		h.t.Errorf("invalid fixture %s: %s", fixturePath, err)
+		http.Error(w, "invalid fixture", http.StatusInternalServerError)
+		return
+	}
+	defer response.Body.Close()
+
+	response.Header.Del(HandWrittenHeader)
+	for key, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(response.StatusCode)
+	_, _ = io.Copy(w, response.Body)
+}
+
+// recordHandler proxies requests to an upstream server, saving responses as fixtures
+type recordHandler struct {
+	t           testing.TB
+	dir         string
+	upstreamUrl *url.URL
+}
+
+func (h *recordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fixturePath := filepath.Join(h.dir, Name(r))
+
+	response, body, err := h.proxy(r)
+	if err != nil {
+		h.t.Errorf("failed to record fixture %s: %s", fixturePath, err)
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	err = saveFixture(fixturePath, response, body)
+	if err != nil {
+		h.t.Errorf("failed to save fixture %s: %s", fixturePath, err)
+	}
+
+	w.Header().Set("Content-Type", response.Header.Get("Content-Type"))
+	w.WriteHeader(response.StatusCode)
+	_, _ = w.Write(body)
+}
+
+// proxy makes a request to the upstream server, returning the response and its body.
+func (h *recordHandler) proxy(r *http.Request) (*http.Response, []byte, error) {
+	upstreamUrl := utils.CloneURL(h.upstreamUrl)
+	upstreamUrl.Path = r.URL.Path
+	upstreamUrl.RawQuery = r.URL.RawQuery
+
+	request, err := http.NewRequestWithContext(r.Context(), r.Method, upstreamUrl.String(), http.NoBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, header := range forwardedHeaders {
+		if value := r.Header.Get(header); value != "" {
+			request.Header.Set(header, value)
+		}
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, body, nil
+}
+
+// saveFixture saves a response as a fixture, only keeping the status, content type and body.
+func saveFixture(fixturePath string, response *http.Response, body []byte) error {
+	fixtureResponse := &http.Response{
+		StatusCode:    response.StatusCode,
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+	if contentType := response.Header.Get("Content-Type"); contentType != "" {
+		fixtureResponse.Header.Set("Content-Type", contentType)
+	}
+
+	fixture, err := httputil.DumpResponse(fixtureResponse, true)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(fixturePath), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fixturePath, fixture, 0o644)
+}
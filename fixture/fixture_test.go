@@ -0,0 +1,91 @@
+package fixture_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/fixture"
+	"github.com/stretchr/testify/require"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		url          string
+		expectedName string
+	}{
+		{url: "/book/show.xml?id=5907", expectedName: "GET_book_show.xml_id_5907.http"},
+		{url: "/book/show.xml?key=secret&id=5907", expectedName: "GET_book_show.xml_id_5907.http"},
+		{url: "/s?k=The+Hobbit&i=digital-text", expectedName: "GET_s_i_digital-text_k_The_Hobbit.http"},
+		{url: "/", expectedName: "GET.http"},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, test.url, http.NoBody)
+		require.Equal(t, test.expectedName, fixture.Name(request), test.url)
+	}
+}
+
+func TestNameLong(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/search?q="+strings.Repeat("a", 200), http.NoBody)
+	otherRequest := httptest.NewRequest(http.MethodGet, "/search?q="+strings.Repeat("b", 200), http.NoBody)
+
+	name := fixture.Name(request)
+	require.LessOrEqual(t, len(name), 120)
+	require.NotEqual(t, name, fixture.Name(otherRequest))
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = w.Write([]byte("hello " + r.URL.Query().Get("name")))
+	}))
+	defer upstream.Close()
+
+	dir := t.TempDir()
+
+	// Record fixture from upstream
+	t.Setenv(fixture.RecordEnvVar, "true")
+	recordServer := fixture.NewServer(t, dir, upstream.URL)
+	body := getBody(t, recordServer.URL+"/greet?name=world")
+	require.Equal(t, "hello world", body)
+
+	// Only the content type should be recorded
+	fixtureContents, err := os.ReadFile(filepath.Join(dir, "GET_greet_name_world.http"))
+	require.NoError(t, err)
+	require.Contains(t, string(fixtureContents), "Content-Type: text/plain")
+	require.NotContains(t, string(fixtureContents), "secret")
+
+	// Replay fixture, with upstream closed
+	upstream.Close()
+	t.Setenv(fixture.RecordEnvVar, "false")
+	replayServer := fixture.NewServer(t, dir, upstream.URL)
+	body = getBody(t, replayServer.URL+"/greet?name=world")
+	require.Equal(t, "hello world", body)
+
+	// Labels of hand-written fixtures are not served
+	handWrittenFixture := "HTTP/1.1 200 OK\r\nContent-Length: 2\r\n" + fixture.HandWrittenHeader + ": error-case\r\n\r\nhi"
+	err = os.WriteFile(filepath.Join(dir, "GET_hand-written.http"), []byte(handWrittenFixture), 0o600)
+	require.NoError(t, err)
+	response, err := http.Get(replayServer.URL + "/hand-written") //nolint:noctx
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Empty(t, response.Header.Get(fixture.HandWrittenHeader))
+}
+
+func getBody(t *testing.T, url string) string {
+	response, err := http.Get(url) //nolint:noctx
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
@@ -1,5 +1,14 @@
 package goodreads
 
+import (
+	"strings"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/description"
+)
+
+const authorDateLayout = "2006/01/02"
+
 type authorCommon struct {
 	Id   string `xml:"id"`
 	Name string `xml:"name"`
@@ -19,3 +28,82 @@ type AuthorDetails struct {
 	RatingsCount     string `xml:"ratings_count"`
 	TextReviewsCount string `xml:"text_reviews_count"`
 }
+
+// Author is the full information about an author
+type Author struct {
+	authorCommon
+	Link          string `xml:"link"`
+	ImageURL      string `xml:"image_url"`
+	LargeImageURL string `xml:"large_image_url"`
+	Biography     string `xml:"about"`
+	BiographyHTML string `xml:"-"` // Original html biography, before sanitisation
+	Gender        string `xml:"gender"`
+	Hometown      string `xml:"hometown"`
+	BornAt        string `xml:"born_at"`
+	DiedAt        string `xml:"died_at"`
+	WorksCount    int    `xml:"works_count"`
+	FansCount     int    `xml:"fans_count"`
+}
+
+func (a *Author) Sanitise() {
+	a.Name = strings.TrimSpace(a.Name)
+	a.Link = strings.TrimSpace(a.Link)
+	a.Gender = strings.TrimSpace(a.Gender)
+	a.Hometown = strings.TrimSpace(a.Hometown)
+	a.BornAt = strings.TrimSpace(a.BornAt)
+	a.DiedAt = strings.TrimSpace(a.DiedAt)
+
+	// Biography is html in the same format as book descriptions
+	if a.BiographyHTML == "" {
+		a.BiographyHTML = a.Biography
+	}
+	a.Biography = description.Convert(a.BiographyHTML, description.FormatPlain)
+
+	// Authors without a photo have a "nophoto" placeholder image
+	a.ImageURL = sanitiseImageURL(a.ImageURL)
+	a.LargeImageURL = sanitiseImageURL(a.LargeImageURL)
+}
+
+// PhotoURL is the url of the largest available photo of the author.
+// If the author does not have a photo, an empty string is returned.
+func (a Author) PhotoURL() string {
+	if a.LargeImageURL != "" {
+		return a.LargeImageURL
+	}
+	return a.ImageURL
+}
+
+// BirthDate is the date the author was born (if known)
+func (a Author) BirthDate() *time.Time {
+	return parseAuthorDate(a.BornAt)
+}
+
+// DeathDate is the date the author died (if known)
+func (a Author) DeathDate() *time.Time {
+	return parseAuthorDate(a.DiedAt)
+}
+
+// AuthorBooks is a page of books written by an author
+type AuthorBooks struct {
+	Author AuthorSummary
+	Start  int
+	End    int
+	Total  int
+	Books  []Edition
+}
+
+func (a *AuthorBooks) Sanitise() {
+	a.Author.Name = strings.TrimSpace(a.Author.Name)
+	for idx, book := range a.Books {
+		book.Sanitise()
+		a.Books[idx] = book
+	}
+}
+
+func parseAuthorDate(date string) *time.Time {
+	parsedDate, err := time.Parse(authorDateLayout, date)
+	if err != nil {
+		return nil
+	}
+	return &parsedDate
+}
@@ -0,0 +1,74 @@
+package goodreads_test
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalAuthor(t *testing.T) {
+	testXML := `
+	<GoodreadsResponse>
+		<author>
+			<id>656983</id>
+			<name>J.R.R. Tolkien</name>
+			<link><![CDATA[https://www.goodreads.com/author/show/656983.J_R_R_Tolkien]]></link>
+			<fans_count type="integer">100</fans_count>
+			<image_url><![CDATA[https://images.gr-assets.com/authors/1542819402p5/656983.jpg]]></image_url>
+			<large_image_url><![CDATA[https://images.gr-assets.com/authors/1542819402p7/656983.jpg]]></large_image_url>
+			<about><![CDATA[Tolkien was an English writer.<br /><br />He is best known for <i>The Hobbit</i>.]]></about>
+			<works_count>500</works_count>
+			<gender>male</gender>
+			<hometown>Bloemfontein, Orange Free State</hometown>
+			<born_at>1892/01/03</born_at>
+			<died_at>1973/09/02</died_at>
+		</author>
+	</GoodreadsResponse>
+	`
+
+	var response struct {
+		Author goodreads.Author `xml:"author"`
+	}
+	err := xml.Unmarshal([]byte(testXML), &response)
+	require.NoError(t, err)
+
+	author := response.Author
+	author.Sanitise()
+
+	require.Equal(t, "656983", author.Id)
+	require.Equal(t, "J.R.R. Tolkien", author.Name)
+	require.Equal(t, "https://images.gr-assets.com/authors/1542819402p7/656983.jpg", author.PhotoURL())
+	require.Equal(t, "Tolkien was an English writer.\n\nHe is best known for The Hobbit.", author.Biography)
+	require.Equal(t, "Bloemfontein, Orange Free State", author.Hometown)
+	require.Equal(t, 500, author.WorksCount)
+
+	require.NotNil(t, author.BirthDate())
+	require.Equal(t, time.Date(1892, time.January, 3, 0, 0, 0, 0, time.UTC), *author.BirthDate())
+	require.NotNil(t, author.DeathDate())
+	require.Equal(t, time.Date(1973, time.September, 2, 0, 0, 0, 0, time.UTC), *author.DeathDate())
+}
+
+func TestUnmarshalAuthorWithoutPhotoOrDates(t *testing.T) {
+	testXML := `
+	<author>
+		<id>1</id>
+		<name>Test Author</name>
+		<image_url><![CDATA[https://s.gr-assets.com/assets/nophoto/user/u_200x266.png]]></image_url>
+		<large_image_url><![CDATA[https://s.gr-assets.com/assets/nophoto/user/u_200x266.png]]></large_image_url>
+		<born_at></born_at>
+	</author>
+	`
+
+	var author goodreads.Author
+	err := xml.Unmarshal([]byte(testXML), &author)
+	require.NoError(t, err)
+
+	author.Sanitise()
+
+	require.Empty(t, author.PhotoURL())
+	require.Nil(t, author.BirthDate())
+	require.Nil(t, author.DeathDate())
+}
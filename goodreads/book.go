@@ -4,10 +4,11 @@ import (
 	"encoding/xml"
 	"math"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/ahobsonsayers/abs-tract/description"
 	"github.com/ahobsonsayers/abs-tract/utils"
-	"github.com/k3a/html2text"
 	"golang.org/x/text/language"
 	"golang.org/x/text/language/display"
 )
@@ -18,18 +19,13 @@ var (
 	// Regex to match last brackets from title. This is the series.
 	// e.g. Harry Potter and the Chamber of Secrets (Harry Potter, #2)
 	titleSeriesRegex = regexp.MustCompile(`\([^)]*#\d+(\.\d+)?\)$`)
-
-	// Regex to match alternative cover preamble in description.
-	// e.g. Harry Potter and the Chamber of Secrets (Harry Potter, #2)
-	descriptionAlternativeCoverRegex = regexp.MustCompile(`^<i>.*?[Aa]lternat(iv)?e [Cc]over.*?</i>`)
-
-	breakTagRegex = regexp.MustCompile(`<br\s*/?>`)
 )
 
 type BookOverview struct {
 	Id        string `xml:"id"`
 	FullTitle string `xml:"title"`
 	Author    string `xml:"author>name"`
+	ImageURL  string `xml:"image_url"`
 }
 
 // Title is the full title with any subtitle and series removed.
@@ -42,12 +38,18 @@ func (o BookOverview) Subtitle() string {
 	return extractSubtitle(o.FullTitle)
 }
 
+func (o *BookOverview) Sanitise() {
+	o.ImageURL = sanitiseImageURL(o.ImageURL)
+}
+
 type Book struct {
 	Work        Work            `xml:"work"`
 	BestEdition Edition         // Unmarshalled using the custom unmarshaler below
 	Authors     []AuthorDetails `xml:"authors>author"`
 	Series      []SeriesBook    `xml:"series_works>series_work"`
-	Genres      Genres          `xml:"popular_shelves"` // The first "genre" shelves
+	Shelves     []Shelf         `xml:"popular_shelves>shelf"`
+	Genres      Genres          `xml:"-"` // Extracted from the shelves using the genre taxonomy of the client
+	Tags        Tags            `xml:"-"` // Extracted from the shelves using the tag extractor of the client
 }
 
 func (b *Book) Sanitise() {
@@ -78,6 +80,7 @@ func (b *Book) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 }
 
 type Work struct {
+	Id            string `xml:"id"`
 	FullTitle     string `xml:"original_title"`
 	MediaType     string `xml:"media_type"`
 	EditionsCount int    `xml:"books_count"`
@@ -104,16 +107,69 @@ func (w Work) Subtitle() string {
 	return extractSubtitle(w.FullTitle)
 }
 
+// PublicationDate is the date the work was originally published in ISO 8601 format.
+// Only the known parts of the date are included. e.g. "2006-01-02", "2006-01" or "2006"
+// If the publication date is unknown, an empty string is returned.
+func (w Work) PublicationDate() string {
+	return utils.FormatPartialDate(w.PublicationYear, w.PublicationMonth, w.PublicationDay)
+}
+
+// AverageRating is the average star rating of the work, rounded to two decimal places.
+// If the work has no ratings, 0 is returned.
 func (w Work) AverageRating() float64 {
+	if w.RatingsCount == 0 {
+		return 0
+	}
 	averageRating := float64(w.RatingsSum) / float64(w.RatingsCount)
 	return math.Round(averageRating*100) / 100 // Round to two decimal places
 }
 
+// StarRatings is the number of ratings given for each star rating (1 to 5).
+// This is parsed from the rating distribution. See test for expected format.
+// Malformed parts of the distribution are ignored.
+func (w Work) StarRatings() StarRatings {
+	var starRatings StarRatings
+	for _, part := range strings.Split(w.RatingDistribution, "|") {
+		key, value, found := strings.Cut(part, ":")
+		if !found {
+			continue
+		}
+
+		stars, err := strconv.Atoi(strings.TrimSpace(key))
+		if err != nil || stars < 1 || stars > 5 {
+			continue
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+
+		starRatings[stars-1] = count
+	}
+
+	return starRatings
+}
+
+// StarRatings is the number of ratings given for each star rating.
+// Index 0 is the number of 1 star ratings, index 4 is the number of 5 star ratings.
+type StarRatings [5]int
+
+// Stars returns the number of ratings given for a star rating (1 to 5).
+// If stars is out of range, 0 is returned.
+func (s StarRatings) Stars(stars int) int {
+	if stars < 1 || stars > 5 {
+		return 0
+	}
+	return s[stars-1]
+}
+
 type Edition struct {
 	Id               string  `xml:"id"`
 	ISBN             *string `xml:"isbn13"`
 	FullTitle        string  `xml:"title"`
 	Description      string  `xml:"description"`
+	DescriptionHTML  string  `xml:"-"` // Original html description, before sanitisation
 	NumPages         string  `xml:"num_pages"`
 	ImageURL         string  `xml:"image_url"`
 	URL              string  `xml:"url"`
@@ -124,6 +180,7 @@ type Edition struct {
 	Publisher        string  `xml:"publisher"`
 	CountryCode      string  `xml:"country_code"`
 	Language         string  `xml:"language_code"`
+	LanguageCode     string  `xml:"-"` // Original language code, before conversion to a name
 }
 
 // Title is the full title with any subtitle and series removed.
@@ -136,30 +193,30 @@ func (e Edition) Subtitle() string {
 	return extractSubtitle(e.FullTitle)
 }
 
+// PublicationDate is the date the edition was published in ISO 8601 format.
+// Only the known parts of the date are included. e.g. "2006-01-02", "2006-01" or "2006"
+// If the publication date is unknown, an empty string is returned.
+func (e Edition) PublicationDate() string {
+	return utils.FormatPartialDate(e.PublicationYear, e.PublicationMonth, e.PublicationDay)
+}
+
 func (e *Edition) Sanitise() {
-	// Description is html and can contain preamble about alternative covers.
-	// Break tags need to be specially handled to add new lines as html2text does
-	// not convert them to new lines properly
-	e.Description = descriptionAlternativeCoverRegex.ReplaceAllString(e.Description, "")
-	// HACK: html2text only handles <br> and <br/>, goodreads uses <br />
-	// replace unsupported br tag with a supported one
-	e.Description = breakTagRegex.ReplaceAllString(e.Description, "<br>")
-	e.Description = html2text.HTML2TextWithOptions(e.Description, html2text.WithUnixLineBreaks())
-	e.Description = strings.TrimSpace(e.Description)
-
-	// Get original cover image by cleaning the url
-	if strings.Contains(e.ImageURL, "nophoto") {
-		e.ImageURL = ""
-	} else {
-		e.ImageURL = utils.SanitiseImageURL(e.ImageURL)
+	if e.DescriptionHTML == "" {
+		e.DescriptionHTML = e.Description
 	}
+	e.Description = description.Convert(e.DescriptionHTML, description.FormatPlain)
+
+	e.ImageURL = sanitiseImageURL(e.ImageURL)
 
 	// Convert language from code to name (if possible)
-	lang, err := language.Parse(e.Language)
+	if e.LanguageCode == "" {
+		e.LanguageCode = e.Language
+	}
+	lang, err := language.Parse(e.LanguageCode)
 	if err == nil {
 		e.Language = display.English.Languages().Name(lang)
 	} else {
-		e.Language = strings.ToTitle(e.Language)
+		e.Language = strings.ToTitle(e.LanguageCode)
 	}
 }
 
@@ -179,6 +236,15 @@ func BookTitles(books []BookOverview) []string {
 	return titles
 }
 
+// sanitiseImageURL gets the original cover image by cleaning the url.
+// Placeholder "nophoto" images are removed.
+func sanitiseImageURL(imageURL string) string {
+	if strings.Contains(imageURL, "nophoto") {
+		return ""
+	}
+	return utils.SanitiseImageURL(imageURL)
+}
+
 // extractTitle extracts the title from the full title with any subtitle and series removed.
 func extractTitle(fullTitle string) string {
 	titleParts := strings.Split(fullTitle, ":")
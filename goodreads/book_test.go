@@ -8,36 +8,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestUnmarshalGenres(t *testing.T) {
-	// XML of popular shelves for The Hobbit
-	xmlString := `
-	<popular_shelves>
-		<shelf name="to-read" count="1161504"/>
-		<shelf name="currently-reading" count="93764"/>
-		<shelf name="fantasy" count="64931"/>
-		<shelf name="classics" count="22453"/>
-		<shelf name="fiction" count="18170"/>
-		<shelf name="owned" count="9096"/>
-		<shelf name="books-i-own" count="6320"/>
-		<shelf name="classic" count="4094"/>
-		<shelf name="adventure" count="3954"/>
-		<shelf name="young-adult" count="3184"/>
-		<shelf name="favourites" count="3050"/>
-		<shelf name="tolkien" count="2839"/>
-		<shelf name="physical-tbr" count="2417"/>
-		<shelf name="high-fantasy" count="2078"/>
-		<shelf name="owned-books" count="2007"/>
-	</popular_shelves>
-	`
-
-	var genres goodreads.Genres
-	err := xml.Unmarshal([]byte(xmlString), &genres)
-	require.NoError(t, err)
-
-	expectedGenres := goodreads.Genres{"Fantasy", "Classic", "Fiction"}
-	require.Equal(t, expectedGenres, genres)
-}
-
 func TestBookUnmarshalBrTagReplacement(t *testing.T) {
 	testXML := `
 	<GoodreadsResponse>
@@ -74,3 +44,39 @@ func TestBookUnmarshalBrTagReplacement(t *testing.T) {
 	require.NotContains(t, description, "<br")
 	require.NotContains(t, description, "br>")
 }
+
+func TestWorkAverageRating(t *testing.T) {
+	work := goodreads.Work{RatingsSum: 1284, RatingsCount: 300}
+	require.InDelta(t, 4.28, work.AverageRating(), 0.001)
+
+	// Works without ratings should not divide by zero
+	work = goodreads.Work{}
+	require.Zero(t, work.AverageRating())
+}
+
+func TestWorkStarRatings(t *testing.T) {
+	work := goodreads.Work{RatingDistribution: "5:1500|4:900|3:400|2:100|1:50|total:2950"}
+	starRatings := work.StarRatings()
+	require.Equal(t, goodreads.StarRatings{50, 100, 400, 900, 1500}, starRatings)
+	require.Equal(t, 1500, starRatings.Stars(5))
+	require.Equal(t, 50, starRatings.Stars(1))
+	require.Zero(t, starRatings.Stars(6))
+
+	// Malformed distributions should be handled gracefully
+	work = goodreads.Work{RatingDistribution: "5:abc|4|x:1|3:7"}
+	require.Equal(t, goodreads.StarRatings{0, 0, 7, 0, 0}, work.StarRatings())
+
+	work = goodreads.Work{}
+	require.Equal(t, goodreads.StarRatings{}, work.StarRatings())
+}
+
+func TestPublicationDate(t *testing.T) {
+	work := goodreads.Work{PublicationYear: 1937, PublicationMonth: 9, PublicationDay: 21}
+	require.Equal(t, "1937-09-21", work.PublicationDate())
+
+	edition := goodreads.Edition{PublicationYear: 2012, PublicationMonth: 2}
+	require.Equal(t, "2012-02", edition.PublicationDate())
+
+	edition = goodreads.Edition{}
+	require.Empty(t, edition.PublicationDate())
+}
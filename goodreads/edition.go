@@ -0,0 +1,146 @@
+package goodreads
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"golang.org/x/net/html"
+	"golang.org/x/text/language"
+)
+
+var (
+	editionsExpr        = xpath.MustCompile(`//div[contains(@class, "elementList")][.//a[contains(@class, "bookTitle")]]`)
+	editionTitleExpr    = xpath.MustCompile(`.//a[contains(@class, "bookTitle")]`)
+	editionImageExpr    = xpath.MustCompile(`.//div[contains(@class, "leftAlignedImage")]//img`)
+	editionLanguageExpr = xpath.MustCompile(
+		`.//div[contains(@class, "dataRow")][div[contains(@class, "dataTitle")][contains(., "language")]]` +
+			`/div[contains(@class, "dataValue")]`,
+	)
+
+	// Regex to match the id of a book in its url.
+	// e.g. /book/show/5907.The_Hobbit
+	bookUrlIdRegex = regexp.MustCompile(`/book/show/(\d+)`)
+)
+
+// EditionOverview is an overview of an edition of a work, from the editions page of the work.
+type EditionOverview struct {
+	Id       string
+	Title    string
+	Language string // Name of the language of the edition e.g. "German"
+	ImageURL string // Url of the cover of the edition. Empty if the edition has no cover
+}
+
+// ListWorkEditions gets the editions of a work, most popular first.
+// Goodreads has no api for editions, so they are parsed from the editions page of the work.
+func (c *Client) ListWorkEditions(ctx context.Context, workId string) ([]EditionOverview, error) {
+	queryParams := map[string]string{
+		"expanded": "true",
+		"per_page": "100",
+	}
+	editionsNode, err := c.getHTML(ctx, "work/editions/"+workId, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return EditionsFromHTML(editionsNode), nil
+}
+
+// EditionsFromHTML parses the editions from the html of the editions page of a work.
+func EditionsFromHTML(editionsNode *html.Node) []EditionOverview {
+	editionNodes := htmlquery.QuerySelectorAll(editionsNode, editionsExpr)
+
+	editions := make([]EditionOverview, 0, len(editionNodes))
+	for _, editionNode := range editionNodes {
+		titleNode := htmlquery.QuerySelector(editionNode, editionTitleExpr)
+		match := bookUrlIdRegex.FindStringSubmatch(htmlquery.SelectAttr(titleNode, "href"))
+		if match == nil {
+			continue
+		}
+
+		var editionLanguage string
+		languageNode := htmlquery.QuerySelector(editionNode, editionLanguageExpr)
+		if languageNode != nil {
+			editionLanguage = strings.TrimSpace(htmlquery.InnerText(languageNode))
+		}
+
+		var editionImageURL string
+		imageNode := htmlquery.QuerySelector(editionNode, editionImageExpr)
+		if imageNode != nil {
+			editionImageURL = sanitiseImageURL(htmlquery.SelectAttr(imageNode, "src"))
+		}
+
+		editions = append(editions, EditionOverview{
+			Id:       match[1],
+			Title:    strings.TrimSpace(htmlquery.InnerText(titleNode)),
+			Language: editionLanguage,
+			ImageURL: editionImageURL,
+		})
+	}
+
+	return editions
+}
+
+// LocaliseBook gets a book with its best edition replaced by its edition in the most preferred
+// of the languages, falling back through the languages in order. Returns whether the book has
+// an edition in one of the languages. If it does not, the book is returned unchanged.
+func (c *Client) LocaliseBook(ctx context.Context, book Book, languages []language.Tag) (Book, bool, error) {
+	bestEditionPreference := editionLanguagePreference(
+		book.BestEdition.LanguageCode,
+		book.BestEdition.Language,
+		languages,
+	)
+	if len(languages) == 0 || bestEditionPreference == 0 {
+		return book, true, nil
+	}
+	if book.Work.Id == "" {
+		return book, bestEditionPreference != -1, errors.New("book has no work id")
+	}
+
+	editions, err := c.ListWorkEditions(ctx, book.Work.Id)
+	if err != nil {
+		return book, bestEditionPreference != -1, err
+	}
+
+	// Find the most popular edition in the most preferred language.
+	// Editions only replace the best edition if they are in a more preferred language
+	var preferredEdition *EditionOverview
+	preferredEditionPreference := bestEditionPreference
+	for idx, edition := range editions {
+		preference := editionLanguagePreference("", edition.Language, languages)
+		if preference != -1 && (preferredEditionPreference == -1 || preference < preferredEditionPreference) {
+			preferredEdition = &editions[idx]
+			preferredEditionPreference = preference
+		}
+	}
+	if preferredEdition == nil || preferredEdition.Id == book.BestEdition.Id {
+		return book, bestEditionPreference != -1, nil
+	}
+
+	localisedBook, err := c.GetBookById(ctx, preferredEdition.Id)
+	if err != nil {
+		return book, bestEditionPreference != -1, err
+	}
+
+	return localisedBook, true, nil
+}
+
+// editionLanguagePreference gets the position of the language of an edition (its code or name) in the languages.
+// If the language of the edition is not one of the languages, -1 is returned.
+func editionLanguagePreference(languageCode, languageName string, languages []language.Tag) int {
+	editionLanguage, err := language.Parse(languageCode)
+	for idx, preferredLanguage := range languages {
+		if err == nil && utils.SameLanguage(editionLanguage, preferredLanguage) {
+			return idx
+		}
+		preferredLanguageName := utils.LanguageName(preferredLanguage)
+		if preferredLanguageName != "" && strings.EqualFold(languageName, preferredLanguageName) {
+			return idx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,213 @@
+package goodreads_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/antchfx/htmlquery"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+const (
+	TheHobbitWorkId = "1540236"
+
+	// theHobbitEditionsHTML is a cut down editions page of the hobbit
+	theHobbitEditionsHTML = `<html><body><div class="workEditions">
+<div class="elementList clearFix">
+  <div class="leftAlignedImage"><a href="/book/show/5907.The_Hobbit"><img alt="The Hobbit"
+    src="https://i.gr-assets.com/images/S/compressed.photo.goodreads.com/books/1546071216l/5907._SY75_.jpg" /></a></div>
+  <div class="editionData">
+    <div class="dataRow"><a class="bookTitle"
+      href="/book/show/5907.The_Hobbit">The Hobbit, or There and Back Again</a></div>
+    <div class="dataRow">Paperback, 366 pages</div>
+    <div class="moreDetails">
+      <div class="dataRow"><div class="dataTitle">Edition language:</div><div class="dataValue">English</div></div>
+    </div>
+  </div>
+</div>
+<div class="elementList clearFix">
+  <div class="leftAlignedImage"><a href="/book/show/1111.Der_Hobbit"><img alt="Der Hobbit"
+    src="https://s.gr-assets.com/assets/nophoto/book/50x75-a91bf249278a81aabab721ef782c4a74.png" /></a></div>
+  <div class="editionData">
+    <div class="dataRow"><a class="bookTitle" href="/book/show/1111.Der_Hobbit">Der Hobbit</a></div>
+    <div class="moreDetails">
+      <div class="dataRow"><div class="dataTitle">Edition language:</div><div class="dataValue">
+        German
+      </div></div>
+    </div>
+  </div>
+</div>
+<div class="elementList clearFix">
+  <div class="leftAlignedImage"><a href="/book/show/2222.Bilbo_le_Hobbit"><img alt="Bilbo le Hobbit"
+    src="https://i.gr-assets.com/images/S/compressed.photo.goodreads.com/books/1390000000l/2222._SX50_.jpg" /></a></div>
+  <div class="editionData">
+    <div class="dataRow"><a class="bookTitle" href="/book/show/2222.Bilbo_le_Hobbit">Bilbo le Hobbit</a></div>
+    <div class="moreDetails">
+      <div class="dataRow"><div class="dataTitle">Edition language:</div><div class="dataValue">French</div></div>
+    </div>
+  </div>
+</div>
+<div class="elementList clearFix">
+  <div class="editionData">
+    <div class="dataRow"><a class="bookTitle" href="/book/show/3333.Der_kleine_Hobbit">Der kleine Hobbit</a></div>
+    <div class="moreDetails">
+      <div class="dataRow"><div class="dataTitle">Edition language:</div><div class="dataValue">German</div></div>
+    </div>
+  </div>
+</div>
+<div class="elementList clearFix">
+  <div class="editionData">
+    <div class="dataRow"><a class="bookTitle" href="/book/show/4444.The_Hobbit">The Hobbit</a></div>
+  </div>
+</div>
+</div></body></html>`
+)
+
+// theHobbitEditions are the titles and language codes of the editions on the editions page
+var theHobbitEditions = map[string][2]string{
+	"1111": {"Der Hobbit", "ger"},
+	"2222": {"Bilbo le Hobbit", "fre"},
+	"3333": {"Der kleine Hobbit", "ger"},
+}
+
+func TestEditionsFromHTML(t *testing.T) {
+	editionsNode, err := htmlquery.Parse(strings.NewReader(theHobbitEditionsHTML))
+	require.NoError(t, err)
+
+	editions := goodreads.EditionsFromHTML(editionsNode)
+	require.Equal(t, []goodreads.EditionOverview{
+		{
+			Id:       "5907",
+			Title:    "The Hobbit, or There and Back Again",
+			Language: "English",
+			ImageURL: "https://i.gr-assets.com/images/S/compressed.photo.goodreads.com/books/1546071216l/5907.jpg",
+		},
+		// Placeholder covers are removed
+		{Id: "1111", Title: "Der Hobbit", Language: "German"},
+		{
+			Id:       "2222",
+			Title:    "Bilbo le Hobbit",
+			Language: "French",
+			ImageURL: "https://i.gr-assets.com/images/S/compressed.photo.goodreads.com/books/1390000000l/2222.jpg",
+		},
+		{Id: "3333", Title: "Der kleine Hobbit", Language: "German"},
+		{Id: "4444", Title: "The Hobbit"},
+	}, editions)
+}
+
+func TestLocaliseBook(t *testing.T) {
+	tests := map[string]struct {
+		languages          []language.Tag
+		expectedTitle      string
+		expectedInLanguage bool
+	}{
+		"most preferred language": {
+			languages:          []language.Tag{language.German, language.English},
+			expectedTitle:      "Der Hobbit",
+			expectedInLanguage: true,
+		},
+		"fallback language": {
+			languages:          []language.Tag{language.Japanese, language.French, language.German},
+			expectedTitle:      "Bilbo le Hobbit",
+			expectedInLanguage: true,
+		},
+		"best edition language": {
+			languages:          []language.Tag{language.BritishEnglish, language.German},
+			expectedTitle:      TheHobbitTitle,
+			expectedInLanguage: true,
+		},
+		"best edition language is preferred over less preferred languages": {
+			languages:          []language.Tag{language.Japanese, language.English, language.German},
+			expectedTitle:      TheHobbitTitle,
+			expectedInLanguage: true,
+		},
+		"no edition in language": {
+			languages:          []language.Tag{language.Japanese},
+			expectedTitle:      TheHobbitTitle,
+			expectedInLanguage: false,
+		},
+		"no languages": {
+			expectedTitle:      TheHobbitTitle,
+			expectedInLanguage: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newEditionsTestClient(t)
+
+			book, inLanguage, err := client.LocaliseBook(context.Background(), theHobbitBook(), test.languages)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedTitle, book.BestEdition.Title())
+			require.Equal(t, test.expectedInLanguage, inLanguage)
+		})
+	}
+}
+
+func TestLocaliseBookEditionsUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := goodreads.NewClient(goodreads.WithURL(server.URL))
+	require.NoError(t, err)
+
+	book, inLanguage, err := client.LocaliseBook(
+		context.Background(),
+		theHobbitBook(),
+		[]language.Tag{language.German, language.English},
+	)
+	require.Error(t, err)
+	require.Equal(t, TheHobbitTitle, book.BestEdition.Title())
+	require.True(t, inLanguage)
+}
+
+// theHobbitBook is the hobbit, with its english best edition
+func theHobbitBook() goodreads.Book {
+	return goodreads.Book{
+		Work: goodreads.Work{Id: TheHobbitWorkId},
+		BestEdition: goodreads.Edition{
+			Id:           TheHobbitId,
+			FullTitle:    TheHobbitTitle,
+			Language:     "English",
+			LanguageCode: "eng",
+		},
+	}
+}
+
+// newEditionsTestClient creates a client whose goodreads serves the editions of the hobbit
+func newEditionsTestClient(t *testing.T) *goodreads.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/work/editions/"+TheHobbitWorkId {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(theHobbitEditionsHTML))
+			return
+		}
+
+		edition, ok := theHobbitEditions[r.URL.Query().Get("id")]
+		if r.URL.Path != "/book/show.xml" || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = fmt.Fprintf(
+			w,
+			`<GoodreadsResponse><book><id>%s</id><title>%s</title><language_code>%s</language_code>`+
+				`<work><id>%s</id></work></book></GoodreadsResponse>`,
+			r.URL.Query().Get("id"), edition[0], edition[1], TheHobbitWorkId,
+		)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := goodreads.NewClient(goodreads.WithURL(server.URL))
+	require.NoError(t, err)
+
+	return client
+}
@@ -1,131 +1,153 @@
 package goodreads
 
 import (
-	"encoding/xml"
+	_ "embed"
+	"fmt"
+	"os"
 	"strings"
 
 	"github.com/jinzhu/inflection"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
 
 	mapset "github.com/deckarep/golang-set/v2"
 )
 
-func init() {
-	// Add genre shelves to uncountable inflections
-	inflection.AddUncountable(genreShelves.ToSlice()...)
-}
+const DefaultMaxGenres = 3
+
+var (
+	//go:embed genres.yaml
+	defaultGenreTaxonomyYAML []byte
 
-// None exhaustive list of popular genre shelves. More should be added.
-// Most of these are obtained from https://www.goodreads.com/genres and made singular.
-// Used to allow extraction of genres from goodreads shelves.
-// TODO is there a better way to recognise genres?
-var genreShelves = mapset.NewSet(
-	"adventure",
-	"art",
-	"biography",
-	"business",
-	"chick-lit",
-	"childrens",
-	"christian",
-	"classic",
-	"comedy",
-	"comic",
-	"contemporary",
-	"cookbook",
-	"crime",
-	"fantasy",
-	"fiction",
-	"gay-and-lesbian",
-	"graphic-novel",
-	"high-fantasy",
-	"historical-fiction",
-	"historical",
-	"history",
-	"horror",
-	"humor-and-comedy",
-	"humour",
-	"manga",
-	"memoir",
-	"music",
-	"mystery",
-	"non-fiction",
-	"nonfiction",
-	"paranormal",
-	"philosophy",
-	"picture-book",
-	"poetry",
-	"politics",
-	"psychology",
-	"religion",
-	"romance",
-	"science-fiction",
-	"science",
-	"self-help",
-	"spirituality",
-	"sport",
-	"suspense",
-	"thriller",
-	"travel",
-	"young-adult",
+	// DefaultGenreTaxonomy is the genre taxonomy used to extract genres from shelves
+	// by clients not given a genre taxonomy. It should be copied rather than modified.
+	DefaultGenreTaxonomy = lo.Must(ParseGenreTaxonomy(defaultGenreTaxonomyYAML))
 )
 
+// Shelf is a goodreads shelf that a book has been added to by users.
+type Shelf struct {
+	Name  string `xml:"name,attr"`
+	Count int    `xml:"count,attr"` // Number of users that added the book to the shelf
+}
+
 type Genres []string
 
-func (g *Genres) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	// unmarshaller is a struct matching the goodreads response xml
-	var unmarshaller struct {
-		Shelves []struct { // nolint
-			Name string `xml:"name,attr"`
-		} `xml:"shelf"`
+// GenreTaxonomy maps goodreads shelves to canonical genres.
+type GenreTaxonomy struct {
+	// MaxGenres is the maximum number of genres to extract from shelves.
+	// If <= 0, there is no maximum.
+	MaxGenres int
+
+	// MinShelfCount is the minimum number of users that must have added a book
+	// to a shelf for the shelf to be considered as a genre.
+	MinShelfCount int
+
+	// shelfGenres is a map of normalised shelf name to canonical genre
+	shelfGenres map[string]string
+}
+
+// NewGenreTaxonomy creates a new genre taxonomy from a map of canonical genre to shelf names (aliases).
+// The canonical genre is always an alias of itself. Will return an error if a shelf name is an
+// alias of multiple genres.
+func NewGenreTaxonomy(genreShelves map[string][]string) (*GenreTaxonomy, error) {
+	shelfGenres := make(map[string]string)
+	for genre, shelves := range genreShelves {
+		genre = strings.TrimSpace(genre)
+		if genre == "" {
+			return nil, fmt.Errorf("empty genre in genre taxonomy")
+		}
+
+		for _, shelf := range append(shelves, genre) {
+			shelf = normaliseShelfName(shelf)
+			if shelf == "" {
+				continue
+			}
+
+			existingGenre, ok := shelfGenres[shelf]
+			if ok && existingGenre != genre {
+				return nil, fmt.Errorf("shelf %q is an alias of multiple genres: %s, %s", shelf, existingGenre, genre)
+			}
+
+			shelfGenres[shelf] = genre
+		}
 	}
-	err := d.DecodeElement(&unmarshaller, &start)
+
+	return &GenreTaxonomy{
+		MaxGenres:   DefaultMaxGenres,
+		shelfGenres: shelfGenres,
+	}, nil
+}
+
+// ParseGenreTaxonomy parses a genre taxonomy from yaml.
+// The yaml should be a map of canonical genre to a list of shelf names (aliases).
+// See genres.yaml for an example.
+func ParseGenreTaxonomy(data []byte) (*GenreTaxonomy, error) {
+	var genreShelves map[string][]string
+	err := yaml.Unmarshal(data, &genreShelves)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to parse genre taxonomy: %w", err)
 	}
 
-	// Get shelf names
-	shelfNames := make([]string, 0, len(unmarshaller.Shelves))
-	for _, shelf := range unmarshaller.Shelves {
-		shelfNames = append(shelfNames, shelf.Name)
+	return NewGenreTaxonomy(genreShelves)
+}
+
+// LoadGenreTaxonomy loads a genre taxonomy from a yaml file.
+// See ParseGenreTaxonomy for the expected format.
+func LoadGenreTaxonomy(path string) (*GenreTaxonomy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genre taxonomy: %w", err)
 	}
 
-	// Convert shelf names to genres
-	genres := shelvesToGenres(shelfNames)
+	return ParseGenreTaxonomy(data)
+}
 
-	// Only use first (up to) three genres
-	if len(genres) < 3 {
-		*g = genres
-	} else {
-		*g = genres[:3]
+// Genre returns the canonical genre of a shelf.
+// If the shelf is not a genre, an empty string is returned.
+func (t *GenreTaxonomy) Genre(shelf string) string {
+	shelf = normaliseShelfName(shelf)
+	if genre, ok := t.shelfGenres[shelf]; ok {
+		return genre
 	}
 
-	return nil
+	// Make shelf singular and try again
+	return t.shelfGenres[inflection.Singular(shelf)]
 }
 
-func shelvesToGenres(shelves []string) []string {
+// Genres converts shelves to canonical genres, in the order of the shelves.
+// Shelves that are not genres or that have too few users are skipped.
+// Genres are deduplicated and limited to the maximum number of genres.
+func (t *GenreTaxonomy) Genres(shelves []Shelf) Genres {
 	genres := make(Genres, 0, len(shelves))
-	seenGenreShelves := mapset.NewSetWithSize[string](len(shelves))
+	seenGenres := mapset.NewSetWithSize[string](len(shelves))
 	for _, shelf := range shelves {
-		// Make shelf singular for easier comparison
-		shelf := inflection.Singular(shelf)
+		if t.MaxGenres > 0 && len(genres) >= t.MaxGenres {
+			break
+		}
 
-		// Skip non genre shelves and already seen genre shelves
-		if !genreShelves.Contains(shelf) || seenGenreShelves.Contains(shelf) {
+		// Skip shelves with too few users
+		if shelf.Count < t.MinShelfCount {
 			continue
 		}
 
-		// Get genre from shelf name, making it human readable
-		genre := strings.ReplaceAll(shelf, "-", " ")
-		genreWords := strings.Fields(genre)
-		for i, word := range genreWords {
-			genreWords[i] = cases.Title(language.Und).String(word) // Capitalize each word
+		// Skip non genre shelves and already seen genres
+		genre := t.Genre(shelf.Name)
+		if genre == "" || seenGenres.Contains(genre) {
+			continue
 		}
-		genre = strings.Join(genreWords, " ")
 
 		genres = append(genres, genre)
-		seenGenreShelves.Add(shelf)
+		seenGenres.Add(genre)
 	}
 
 	return genres
 }
+
+// normaliseShelfName normalises a shelf name by converting it to lowercase
+// and replacing whitespace and underscores with hyphens.
+func normaliseShelfName(shelf string) string {
+	shelf = strings.ToLower(strings.TrimSpace(shelf))
+	shelf = strings.ReplaceAll(shelf, "_", " ")
+	shelf = strings.Join(strings.Fields(shelf), "-")
+	return shelf
+}
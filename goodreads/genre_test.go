@@ -0,0 +1,72 @@
+package goodreads_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultGenreTaxonomyAliases(t *testing.T) {
+	taxonomy := goodreads.DefaultGenreTaxonomy
+	require.Equal(t, "Science Fiction", taxonomy.Genre("sci-fi"))
+	require.Equal(t, "Science Fiction", taxonomy.Genre("scifi"))
+	require.Equal(t, "Science Fiction", taxonomy.Genre("SF"))
+	require.Equal(t, "Science Fiction", taxonomy.Genre("Science Fiction"))
+	require.Equal(t, "Classic", taxonomy.Genre("classics"))
+	require.Equal(t, "Thriller", taxonomy.Genre("thrillers"))
+	require.Equal(t, "Nonfiction", taxonomy.Genre("non-fiction"))
+	require.Empty(t, taxonomy.Genre("to-read"))
+	require.Empty(t, taxonomy.Genre("owned"))
+}
+
+func TestGenreTaxonomyGenres(t *testing.T) {
+	taxonomy, err := goodreads.ParseGenreTaxonomy([]byte(`
+Science Fiction: [sci-fi, scifi, sf]
+Fantasy: [fantasy-fiction]
+Horror: []
+`))
+	require.NoError(t, err)
+
+	shelves := []goodreads.Shelf{
+		{Name: "to-read", Count: 1000},
+		{Name: "sci-fi", Count: 500},
+		{Name: "fantasy", Count: 400},
+		{Name: "scifi", Count: 300}, // Duplicate genre
+		{Name: "horror", Count: 10},
+	}
+
+	taxonomy.MaxGenres = 0
+	require.Equal(t, goodreads.Genres{"Science Fiction", "Fantasy", "Horror"}, taxonomy.Genres(shelves))
+
+	taxonomy.MaxGenres = 2
+	require.Equal(t, goodreads.Genres{"Science Fiction", "Fantasy"}, taxonomy.Genres(shelves))
+
+	taxonomy.MaxGenres = 0
+	taxonomy.MinShelfCount = 100
+	require.Equal(t, goodreads.Genres{"Science Fiction", "Fantasy"}, taxonomy.Genres(shelves))
+}
+
+func TestGenreTaxonomyDuplicateAlias(t *testing.T) {
+	_, err := goodreads.ParseGenreTaxonomy([]byte(`
+Science Fiction: [sf]
+Speculative Fiction: [sf]
+`))
+	require.Error(t, err)
+}
+
+func TestLoadGenreTaxonomy(t *testing.T) {
+	taxonomyPath := filepath.Join(t.TempDir(), "genres.yaml")
+	err := os.WriteFile(taxonomyPath, []byte("Cozy Mystery: [cozy, cosy]\n"), 0o600)
+	require.NoError(t, err)
+
+	taxonomy, err := goodreads.LoadGenreTaxonomy(taxonomyPath)
+	require.NoError(t, err)
+	require.Equal(t, "Cozy Mystery", taxonomy.Genre("cosy"))
+	require.Equal(t, goodreads.DefaultMaxGenres, taxonomy.MaxGenres)
+
+	_, err = goodreads.LoadGenreTaxonomy(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
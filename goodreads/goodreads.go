@@ -1,6 +1,7 @@
 package goodreads
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
 	"errors"
@@ -9,11 +10,13 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"sync"
 
 	"github.com/ahobsonsayers/abs-tract/utils"
 	"github.com/orsinium-labs/enum"
 	"github.com/samber/lo"
+	"golang.org/x/net/html"
 )
 
 const (
@@ -24,19 +27,30 @@ const (
 var (
 	defaultGoodreadsUrl = lo.Must(url.Parse(DefaultGoodreadsUrl))
 
+	numericIdRegex = regexp.MustCompile(`^\d+$`)
+	isbnRegex      = regexp.MustCompile(`^[0-9A-Za-z]+$`)
+
 	DefaultClient = &Client{
-		client:       http.DefaultClient,
-		goodreadsUrl: utils.CloneURL(defaultGoodreadsUrl),
-		apiKey:       DefaultAPIKey,
+		client:           http.DefaultClient,
+		goodreadsUrl:     utils.CloneURL(defaultGoodreadsUrl),
+		apiKey:           DefaultAPIKey,
+		searchStrategies: DefaultSearchStrategies,
+		searchConfidence: DefaultSearchConfidence,
+		genreTaxonomy:    DefaultGenreTaxonomy,
+		tagExtractor:     DefaultTagExtractor,
 	}
 )
 
 type BookSearchType enum.Member[string]
 
 type Client struct {
-	client       *http.Client
-	goodreadsUrl *url.URL
-	apiKey       string
+	client           *http.Client
+	goodreadsUrl     *url.URL
+	apiKey           string
+	searchStrategies []SearchStrategy
+	searchConfidence float64
+	genreTaxonomy    *GenreTaxonomy
+	tagExtractor     *TagExtractor
 }
 
 // URL returns a clone of of the amazon url used by the client
@@ -48,6 +62,43 @@ func (c *Client) get(
 	parameters map[string]string,
 	target any,
 ) error {
+	body, err := c.getBody(ctx, path, parameters)
+	if err != nil {
+		return err
+	}
+
+	err = xml.Unmarshal(body, target)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return nil
+}
+
+// getHTML gets a goodreads page, for information that is not available through the api.
+func (c *Client) getHTML(
+	ctx context.Context,
+	path string,
+	parameters map[string]string,
+) (*html.Node, error) {
+	body, err := c.getBody(ctx, path, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response body: %w", err)
+	}
+
+	return node, nil
+}
+
+func (c *Client) getBody(
+	ctx context.Context,
+	path string,
+	parameters map[string]string,
+) ([]byte, error) {
 	queryParams := url.Values{}
 	queryParams.Add("key", c.apiKey)
 	for key, value := range parameters {
@@ -60,33 +111,33 @@ func (c *Client) get(
 	requestUrl = requestUrl.JoinPath(path)
 	requestUrl.RawQuery = queryParams.Encode()
 
+	err := utils.TakeRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl.String(), http.NoBody)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	response, err := c.client.Do(request)
 	if err != nil {
-		return fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer response.Body.Close()
 
 	httpError := utils.HTTPResponseError(response)
 	if httpError != nil {
-		return httpError
+		return nil, httpError
 	}
 
 	body, err := io.ReadAll(response.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	err = xml.Unmarshal(body, target)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal response body: %w", err)
-	}
-
-	return nil
+	return body, nil
 }
 
 // GetBookById gets a book by its id.
@@ -101,6 +152,7 @@ func (c *Client) GetBookById(ctx context.Context, bookId string) (Book, error) {
 	if err != nil {
 		return Book{}, err
 	}
+	c.setGenresAndTags(&result.Book)
 
 	return result.Book, nil
 }
@@ -142,6 +194,27 @@ func (c *Client) GetBooksByIds(ctx context.Context, bookIds []string) ([]Book, e
 	return books, nil
 }
 
+// GetBookByISBN gets a book by the isbn (or asin) of one of its editions, with that edition as its best edition.
+// https://www.goodreads.com/api/index#book.show_by_isbn
+func (c *Client) GetBookByISBN(ctx context.Context, isbn string) (Book, error) {
+	if !isbnRegex.MatchString(isbn) {
+		return Book{}, fmt.Errorf("invalid isbn %q: must be alphanumeric", isbn)
+	}
+
+	queryParams := map[string]string{"format": "xml"}
+
+	var result struct {
+		Book Book `xml:"book"`
+	}
+	err := c.get(ctx, "book/isbn/"+isbn, queryParams, &result)
+	if err != nil {
+		return Book{}, err
+	}
+	c.setGenresAndTags(&result.Book)
+
+	return result.Book, nil
+}
+
 // GetBookByTitle gets a book by its title and optionally an author (which can give a better match)
 // https://www.goodreads.com/api/index#book.title
 func (c *Client) GetBookByTitle(ctx context.Context, title string, author *string) (Book, error) {
@@ -157,6 +230,95 @@ func (c *Client) GetBookByTitle(ctx context.Context, title string, author *strin
 	if err != nil {
 		return Book{}, err
 	}
+	c.setGenresAndTags(&result.Work)
 
 	return result.Work, nil
 }
+
+// setGenresAndTags sets the genres and tags of a book, extracted from its shelves.
+func (c *Client) setGenresAndTags(book *Book) {
+	book.Genres = c.genreTaxonomy.Genres(book.Shelves)
+	book.Tags = c.tagExtractor.Tags(book.Shelves)
+}
+
+// GetAuthor gets an author by their id.
+// https://www.goodreads.com/api/index#author.show
+func (c *Client) GetAuthor(ctx context.Context, authorId string) (Author, error) {
+	queryParams := map[string]string{"id": authorId}
+
+	var result struct {
+		Author Author `xml:"author"`
+	}
+	err := c.get(ctx, "author/show.xml", queryParams, &result)
+	if err != nil {
+		return Author{}, err
+	}
+
+	result.Author.Sanitise()
+
+	return result.Author, nil
+}
+
+// ListAuthorBooks gets a page of books written by an author.
+// If page is < 1, the first page of books is returned.
+// https://www.goodreads.com/api/index#author.books
+func (c *Client) ListAuthorBooks(ctx context.Context, authorId string, page int) (AuthorBooks, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	queryParams := map[string]string{
+		"id":   authorId,
+		"page": strconv.Itoa(page),
+	}
+
+	var result struct {
+		Author struct {
+			AuthorSummary
+			Books struct {
+				Start int       `xml:"start,attr"`
+				End   int       `xml:"end,attr"`
+				Total int       `xml:"total,attr"`
+				Books []Edition `xml:"book"`
+			} `xml:"books"`
+		} `xml:"author"`
+	}
+	err := c.get(ctx, "author/list.xml", queryParams, &result)
+	if err != nil {
+		return AuthorBooks{}, err
+	}
+
+	authorBooks := AuthorBooks{
+		Author: result.Author.AuthorSummary,
+		Start:  result.Author.Books.Start,
+		End:    result.Author.Books.End,
+		Total:  result.Author.Books.Total,
+		Books:  result.Author.Books.Books,
+	}
+	authorBooks.Sanitise()
+
+	return authorBooks, nil
+}
+
+// GetSeries gets a series by its (numeric) id, including all works in the series.
+// https://www.goodreads.com/api/index#series.show
+func (c *Client) GetSeries(ctx context.Context, seriesId string) (SeriesDetails, error) {
+	// The id is part of the path, so must not contain anything that would change the path
+	if !numericIdRegex.MatchString(seriesId) {
+		return SeriesDetails{}, fmt.Errorf("invalid series id %q: must be numeric", seriesId)
+	}
+
+	queryParams := map[string]string{"format": "xml"}
+
+	var result struct {
+		Series SeriesDetails `xml:"series"`
+	}
+	err := c.get(ctx, "series/show/"+seriesId, queryParams, &result)
+	if err != nil {
+		return SeriesDetails{}, err
+	}
+
+	result.Series.Sanitise()
+
+	return result.Series, nil
+}
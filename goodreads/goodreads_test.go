@@ -2,8 +2,11 @@ package goodreads_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/ahobsonsayers/abs-tract/fixture"
 	"github.com/ahobsonsayers/abs-tract/goodreads"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
@@ -16,25 +19,49 @@ const (
 )
 
 func TestGetBookById(t *testing.T) {
-	book, err := goodreads.DefaultClient.GetBookById(context.Background(), TheHobbitId)
+	book, err := newTestClient(t).GetBookById(context.Background(), TheHobbitId)
 	require.NoError(t, err)
 	checkTheHobbitBookDetails(t, book)
 }
 
 func TestGetBookByTitle(t *testing.T) {
-	book, err := goodreads.DefaultClient.GetBookByTitle(context.Background(), TheHobbitTitle, nil)
+	book, err := newTestClient(t).GetBookByTitle(context.Background(), TheHobbitTitle, nil)
 	require.NoError(t, err)
 	checkTheHobbitBookDetails(t, book)
 }
 
+func TestGetBookByISBN(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/book/isbn/9780261103344" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`<GoodreadsResponse><book><id>5907</id></book></GoodreadsResponse>`))
+	}))
+	defer server.Close()
+
+	client, err := goodreads.NewClient(goodreads.WithURL(server.URL))
+	require.NoError(t, err)
+
+	book, err := client.GetBookByISBN(context.Background(), "9780261103344")
+	require.NoError(t, err)
+	require.Equal(t, TheHobbitId, book.BestEdition.Id)
+
+	// Isbns that would change the path requested are never requested
+	for _, isbn := range []string{"../show/5907", "9780261103344?id=1", ""} {
+		_, err := client.GetBookByISBN(context.Background(), isbn)
+		require.Error(t, err, isbn)
+	}
+}
+
 func TestSearchTitle(t *testing.T) {
-	books, err := goodreads.DefaultClient.SearchBooks(context.Background(), TheHobbitTitle, nil)
+	books, err := newTestClient(t).SearchBooks(context.Background(), TheHobbitTitle, nil)
 	require.NoError(t, err)
 	checkTheHobbitBookDetails(t, books[0])
 }
 
 func TestSearchTitleAndAuthor(t *testing.T) {
-	books, err := goodreads.DefaultClient.SearchBooks(
+	books, err := newTestClient(t).SearchBooks(
 		context.Background(),
 		TheHobbitTitle,
 		lo.ToPtr(TheHobbitAuthor),
@@ -43,6 +70,14 @@ func TestSearchTitleAndAuthor(t *testing.T) {
 	checkTheHobbitBookDetails(t, books[0])
 }
 
+// newTestClient creates a client that makes requests to a server serving recorded fixtures
+func newTestClient(t *testing.T) *goodreads.Client {
+	server := fixture.NewServer(t, "../testdata/goodreads", goodreads.DefaultGoodreadsUrl)
+	client, err := goodreads.NewClient(goodreads.WithURL(server.URL))
+	require.NoError(t, err)
+	return client
+}
+
 func checkTheHobbitBookDetails(t *testing.T, book goodreads.Book) {
 	require.Equal(t, TheHobbitTitle, book.BestEdition.Title())
 	require.Equal(t, TheHobbitId, book.BestEdition.Id)
@@ -1,40 +1,227 @@
 package goodreads
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/ahobsonsayers/abs-tract/utils"
 )
 
+// ClientOption configures a client created using NewClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	httpClient  *http.Client
+	url         string
+	apiKey      string
+	transport   http.RoundTripper
+	userAgent   string
+	timeout     time.Duration
+	proxyUrl    string
+	retryPolicy utils.RetryPolicy
+	breaker     *utils.CircuitBreaker
+
+	searchStrategies []SearchStrategy
+	searchConfidence *float64
+
+	genreTaxonomy *GenreTaxonomy
+	tagExtractor  *TagExtractor
+}
+
+// WithHTTPClient sets the http client used to make requests.
+// The http client is copied, so it is not modified by other options.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(o *clientOptions) { o.httpClient = client }
+}
+
+// WithURL sets the url of goodreads. This is mostly useful for testing.
+func WithURL(goodreadsUrl string) ClientOption {
+	return func(o *clientOptions) { o.url = goodreadsUrl }
+}
+
+// WithAPIKey sets the goodreads api key used to make requests.
+func WithAPIKey(apiKey string) ClientOption {
+	return func(o *clientOptions) { o.apiKey = apiKey }
+}
+
+// WithTransport sets the transport used to make requests,
+// replacing the transport of the http client.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(o *clientOptions) { o.transport = transport }
+}
+
+// WithUserAgent sets the user agent of requests.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *clientOptions) { o.userAgent = userAgent }
+}
+
+// WithTimeout sets the time limit of requests. 0 for no time limit.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) { o.timeout = timeout }
+}
+
+// WithProxy sets the url of a proxy to make requests through.
+// The transport of the client must be an *http.Transport.
+func WithProxy(proxyUrl string) ClientOption {
+	return func(o *clientOptions) { o.proxyUrl = proxyUrl }
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests.
+func WithRetryPolicy(policy utils.RetryPolicy) ClientOption {
+	return func(o *clientOptions) { o.retryPolicy = policy }
+}
+
+// WithCircuitBreaker sets a circuit breaker that stops requests being made
+// after too many consecutive failures. Retried requests count as a single request.
+func WithCircuitBreaker(breaker *utils.CircuitBreaker) ClientOption {
+	return func(o *clientOptions) { o.breaker = breaker }
+}
+
+// WithSearchStrategies sets the strategies used to search for books, in the order they are tried.
+func WithSearchStrategies(strategies ...SearchStrategy) ClientOption {
+	return func(o *clientOptions) { o.searchStrategies = strategies }
+}
+
+// WithSearchConfidence sets the confidence (between 0 and 1) a search strategy must find a book with
+// for searching to stop, without trying the remaining strategies. 0 stops at the first strategy to find a book.
+func WithSearchConfidence(confidence float64) ClientOption {
+	return func(o *clientOptions) { o.searchConfidence = &confidence }
+}
+
+// WithGenreTaxonomy sets the genre taxonomy used to extract the genres of books from their shelves.
+func WithGenreTaxonomy(taxonomy *GenreTaxonomy) ClientOption {
+	return func(o *clientOptions) { o.genreTaxonomy = taxonomy }
+}
+
+// WithTagExtractor sets the tag extractor used to extract the tags of books from their shelves.
+func WithTagExtractor(extractor *TagExtractor) ClientOption {
+	return func(o *clientOptions) { o.tagExtractor = extractor }
+}
+
 // NewClient creates a new goodreads client.
-// If client is nil, the default http client will be used.
-// If goodreads url is nil or unset, the default goodreads url will be used.
-// Will return an error if the goodreads url is invalid.
-func NewClient(client *http.Client, goodreadsUrl, apiKey *string) (*Client, error) {
-	if client == nil {
-		client = http.DefaultClient
+// If no options are given, the client will be the same as the default client.
+// Will return an error if an option is invalid.
+func NewClient(options ...ClientOption) (*Client, error) {
+	var opts clientOptions
+	for _, option := range options {
+		option(&opts)
 	}
 
 	goodreadsUrlStruct := defaultGoodreadsUrl
-	if goodreadsUrl != nil && *goodreadsUrl != "" {
-		parsedGoodreadsUrl, err := url.Parse(strings.Trim(*goodreadsUrl, "/"))
+	if opts.url != "" {
+		parsedGoodreadsUrl, err := url.Parse(strings.Trim(opts.url, "/"))
 		if err != nil {
 			return nil, fmt.Errorf("invalid goodreads url: %w", err)
 		}
 		goodreadsUrlStruct = parsedGoodreadsUrl
 	}
 
-	apiKeyString := DefaultAPIKey
-	if apiKey != nil && *apiKey != "" {
-		apiKeyString = strings.TrimSpace(*apiKey)
+	apiKey := DefaultAPIKey
+	if opts.apiKey != "" {
+		apiKey = strings.TrimSpace(opts.apiKey)
+	}
+
+	searchStrategies := DefaultSearchStrategies
+	if len(opts.searchStrategies) != 0 {
+		searchStrategies = opts.searchStrategies
+	}
+
+	searchConfidence := DefaultSearchConfidence
+	if opts.searchConfidence != nil {
+		if *opts.searchConfidence < 0 || *opts.searchConfidence > 1 {
+			return nil, errors.New("invalid search confidence: must be between 0 and 1")
+		}
+		searchConfidence = *opts.searchConfidence
+	}
+
+	genreTaxonomy := DefaultGenreTaxonomy
+	if opts.genreTaxonomy != nil {
+		genreTaxonomy = opts.genreTaxonomy
+	}
+
+	tagExtractor := DefaultTagExtractor
+	if opts.tagExtractor != nil {
+		tagExtractor = opts.tagExtractor
+	}
+
+	httpClient, err := opts.newHTTPClient()
+	if err != nil {
+		return nil, err
 	}
 
 	return &Client{
-		client:       client,
-		goodreadsUrl: utils.CloneURL(goodreadsUrlStruct),
-		apiKey:       apiKeyString,
+		client:           httpClient,
+		goodreadsUrl:     utils.CloneURL(goodreadsUrlStruct),
+		apiKey:           apiKey,
+		searchStrategies: searchStrategies,
+		searchConfidence: searchConfidence,
+		genreTaxonomy:    genreTaxonomy,
+		tagExtractor:     tagExtractor,
 	}, nil
 }
+
+// newHTTPClient creates the http client of a goodreads client using the options.
+func (o clientOptions) newHTTPClient() (*http.Client, error) {
+	client := &http.Client{}
+	if o.httpClient != nil {
+		*client = *o.httpClient
+	}
+	if o.timeout > 0 {
+		client.Timeout = o.timeout
+	}
+
+	transport := client.Transport
+	if o.transport != nil {
+		transport = o.transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if o.proxyUrl != "" {
+		proxyUrl, err := url.Parse(o.proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+
+		httpTransport, ok := transport.(*http.Transport)
+		if !ok {
+			return nil, errors.New("proxy can only be used with an *http.Transport")
+		}
+		httpTransport = httpTransport.Clone()
+		httpTransport.Proxy = http.ProxyURL(proxyUrl)
+		transport = httpTransport
+	}
+
+	if o.userAgent != "" {
+		transport = &userAgentTransport{transport: transport, userAgent: o.userAgent}
+	}
+
+	if o.retryPolicy.MaxRetries > 0 {
+		transport = utils.NewRetryTransport(transport, o.retryPolicy)
+	}
+
+	if o.breaker != nil {
+		transport = utils.NewCircuitBreakerTransport(transport, o.breaker)
+	}
+
+	client.Transport = transport
+
+	return client, nil
+}
+
+// userAgentTransport sets the user agent of requests made using a transport
+type userAgentTransport struct {
+	transport http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.Header.Set("User-Agent", t.userAgent)
+	return t.transport.RoundTrip(request)
+}
@@ -1,24 +1,104 @@
 package goodreads_test
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/ahobsonsayers/abs-tract/utils"
 	"github.com/stretchr/testify/require"
 )
 
-func TestNewNoParameters(t *testing.T) {
-	client, err := goodreads.NewClient(nil, nil, nil)
+func TestNewNoOptions(t *testing.T) {
+	client, err := goodreads.NewClient()
 	require.NoError(t, err)
 	require.Equal(t, goodreads.DefaultGoodreadsUrl, client.URL().String())
 }
 
-func TestNewWithParameters(t *testing.T) {
-	httpClient := &http.Client{}
+func TestNewWithOptions(t *testing.T) {
 	goodreadUrl := "http://example.com"
-	apiKey := "test"
-	client, err := goodreads.NewClient(httpClient, &goodreadUrl, &apiKey)
+	client, err := goodreads.NewClient(
+		goodreads.WithHTTPClient(&http.Client{}),
+		goodreads.WithURL(goodreadUrl),
+		goodreads.WithAPIKey("test"),
+		goodreads.WithUserAgent("test"),
+		goodreads.WithTimeout(time.Second),
+		goodreads.WithProxy("http://proxy:3128"),
+		goodreads.WithRetryPolicy(utils.DefaultRetryPolicy),
+	)
 	require.NoError(t, err)
 	require.Equal(t, goodreadUrl, client.URL().String())
 }
+
+func TestNewProxyWithCustomTransport(t *testing.T) {
+	_, err := goodreads.NewClient(
+		goodreads.WithTransport(utils.NewRetryTransport(nil, utils.DefaultRetryPolicy)),
+		goodreads.WithProxy("http://proxy:3128"),
+	)
+	require.Error(t, err)
+}
+
+func TestClientOptions(t *testing.T) {
+	// Server fails the first request, then returns an empty book
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "test-agent", r.UserAgent())
+		require.Equal(t, "test-key", r.URL.Query().Get("key"))
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`<GoodreadsResponse><book><id>1</id></book></GoodreadsResponse>`))
+	}))
+	defer server.Close()
+
+	client, err := goodreads.NewClient(
+		goodreads.WithURL(server.URL),
+		goodreads.WithAPIKey("test-key"),
+		goodreads.WithUserAgent("test-agent"),
+		goodreads.WithRetryPolicy(utils.RetryPolicy{MaxRetries: 1}),
+	)
+	require.NoError(t, err)
+
+	book, err := client.GetBookById(context.Background(), "1")
+	require.NoError(t, err)
+	require.Equal(t, "1", book.BestEdition.Id)
+	require.EqualValues(t, 2, requests.Load())
+}
+
+func TestClientGenresAndTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<GoodreadsResponse><book><id>1</id><popular_shelves>
+			<shelf name="to-read" count="1000"/>
+			<shelf name="fantasy" count="500"/>
+			<shelf name="cosy" count="400"/>
+			<shelf name="dragons" count="300"/>
+		</popular_shelves></book></GoodreadsResponse>`))
+	}))
+	defer server.Close()
+
+	client, err := goodreads.NewClient(goodreads.WithURL(server.URL))
+	require.NoError(t, err)
+	book, err := client.GetBookById(context.Background(), "1")
+	require.NoError(t, err)
+	require.Equal(t, goodreads.Genres{"Fantasy"}, book.Genres)
+	require.Equal(t, goodreads.Tags{"Cosy", "Dragons"}, book.Tags)
+
+	// Genres and tags are extracted using the taxonomy and extractor of the client
+	genreTaxonomy, err := goodreads.ParseGenreTaxonomy([]byte("Cozy Mystery: [cozy, cosy]\n"))
+	require.NoError(t, err)
+	client, err = goodreads.NewClient(
+		goodreads.WithURL(server.URL),
+		goodreads.WithGenreTaxonomy(genreTaxonomy),
+		goodreads.WithTagExtractor(&goodreads.TagExtractor{MaxTags: 1, GenreTaxonomy: genreTaxonomy}),
+	)
+	require.NoError(t, err)
+	book, err = client.GetBookById(context.Background(), "1")
+	require.NoError(t, err)
+	require.Equal(t, goodreads.Genres{"Cozy Mystery"}, book.Genres)
+	require.Equal(t, goodreads.Tags{"Fantasy"}, book.Tags)
+}
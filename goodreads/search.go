@@ -22,7 +22,8 @@ var (
 )
 
 // SearchBooks search for a book by its title and optionally an author (which can give better ordered results).
-// Returns the first 10 pages of books.
+// Books are searched for using the search strategies of the client in turn, until books are found that match
+// the title and author with the search confidence of the client. Each strategy returns up to 10 pages of books.
 // See: https://www.goodreads.com/api/index#search.books
 func (c *Client) SearchBooks(ctx context.Context, title string, author *string) ([]Book, error) {
 	// Normalise title and author to make searching more consistent
@@ -30,13 +31,9 @@ func (c *Client) SearchBooks(ctx context.Context, title string, author *string)
 	normalisedAuthor := normaliseString(lo.FromPtr(author))
 
 	switch {
-	case normalisedTitle != "" && normalisedAuthor != "":
-		// If both title and author are set
-		return c.searchBooksByTitleAndAuthor(ctx, title, normalisedAuthor)
-
 	case normalisedTitle != "":
-		// If only title is set
-		return c.searchBooksByTitle(ctx, normalisedTitle)
+		// If title is set, with or without an author
+		return c.searchBooksByStrategies(ctx, strings.TrimSpace(title), strings.TrimSpace(lo.FromPtr(author)))
 
 	case normalisedAuthor != "":
 		// If only author is set
@@ -74,25 +71,6 @@ func (c *Client) searchBooksByAuthor(ctx context.Context, author string) ([]Book
 	return c.GetBooksByIds(ctx, BookIds(bookOverviews))
 }
 
-func (c *Client) searchBooksByTitleAndAuthor(
-	ctx context.Context,
-	title string,
-	author string,
-) ([]Book, error) {
-	// If searching by title and author, search by title ONLY first.
-	// We will then sort books but author similarity.
-	// We do NOT search goodreads by title AND author together as goodreads
-	// returns awful results, including sometimes none at all.
-	books, err := c.searchBooksByTitle(ctx, title)
-	if err != nil {
-		return nil, err
-	}
-
-	sortBookByAuthorSimilarity(books, author)
-
-	return books, nil
-}
-
 type searchBooksManyPagesInput struct {
 	Query      string
 	SearchType BookSearchType
@@ -1,7 +1,14 @@
 package goodreads
 
 import (
+	"cmp"
+	"encoding/xml"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
+
+	"github.com/samber/lo"
 )
 
 type Series struct {
@@ -26,3 +33,146 @@ type SeriesBook struct {
 func (s *SeriesBook) Sanitise() {
 	s.Series.Sanitise()
 }
+
+// Primary returns whether the book is a primary book of the series.
+// See SeriesPosition.Primary
+func (s SeriesBook) Primary() bool {
+	return ParseSeriesPosition(s.BookPosition).Primary()
+}
+
+// SeriesDetails is a series along with all of the works in the series.
+type SeriesDetails struct {
+	Series
+	Works []SeriesWork `xml:"series_works>series_work"`
+}
+
+func (s *SeriesDetails) Sanitise() {
+	s.Series.Sanitise()
+	for idx, work := range s.Works {
+		work.Sanitise()
+		s.Works[idx] = work
+	}
+
+	// Sort works by their position in the series.
+	// Works without a position are moved to the end.
+	slices.SortStableFunc(s.Works, func(i, j SeriesWork) int {
+		return ParseSeriesPosition(i.BookPosition).Compare(ParseSeriesPosition(j.BookPosition))
+	})
+}
+
+type SeriesWork struct {
+	BookPosition *string
+	Work         Work
+	BestBook     BookOverview // The best book (edition) of the work
+}
+
+func (s *SeriesWork) Sanitise() {
+	s.BestBook.Sanitise()
+	if s.BookPosition != nil {
+		s.BookPosition = lo.ToPtr(strings.TrimSpace(*s.BookPosition))
+	}
+}
+
+// Primary returns whether the work is a primary work of the series.
+// See SeriesPosition.Primary
+func (s SeriesWork) Primary() bool {
+	return ParseSeriesPosition(s.BookPosition).Primary()
+}
+
+func (s *SeriesWork) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	// unmarshaller is a struct matching the goodreads response xml
+	var unmarshaller struct {
+		BookPosition *string `xml:"user_position"`
+		Work         struct {
+			Work
+			BestBook BookOverview `xml:"best_book"`
+		} `xml:"work"`
+	}
+	err := d.DecodeElement(&unmarshaller, &start)
+	if err != nil {
+		return err
+	}
+
+	*s = SeriesWork{
+		BookPosition: unmarshaller.BookPosition,
+		Work:         unmarshaller.Work.Work,
+		BestBook:     unmarshaller.Work.BestBook,
+	}
+
+	return nil
+}
+
+// SeriesPosition is a parsed position of a book in a series.
+// Positions can be a single number (e.g. "1" or "0.5") or a range of numbers (e.g. "1-3"),
+// in which case Start and End will be different. If the position could not be parsed
+// (e.g. it was missing), Valid will be false.
+type SeriesPosition struct {
+	Start float64
+	End   float64
+	Valid bool
+}
+
+// ParseSeriesPosition parses a position of a book in a series.
+// Goodreads positions can be whole numbers, decimals or ranges. e.g. "1", "0.5", "1-3"
+func ParseSeriesPosition(position *string) SeriesPosition {
+	if position == nil {
+		return SeriesPosition{}
+	}
+
+	startString, endString, isRange := strings.Cut(strings.TrimSpace(*position), "-")
+
+	start, err := strconv.ParseFloat(strings.TrimSpace(startString), 64)
+	if err != nil {
+		return SeriesPosition{}
+	}
+
+	end := start
+	if isRange {
+		end, err = strconv.ParseFloat(strings.TrimSpace(endString), 64)
+		if err != nil || end < start {
+			return SeriesPosition{}
+		}
+	}
+
+	return SeriesPosition{Start: start, End: end, Valid: true}
+}
+
+// Primary returns whether the position is of a primary book in a series.
+// Primary books have a single whole numbered position.
+// Novellas (e.g. "0.5") and omnibuses (e.g. "1-3") are not primary books.
+func (p SeriesPosition) Primary() bool {
+	return p.Valid && p.Start == p.End && p.Start == math.Trunc(p.Start)
+}
+
+// Compare compares two positions, ordering by the start and then end position.
+// Invalid positions are ordered after all valid positions.
+func (p SeriesPosition) Compare(other SeriesPosition) int {
+	switch {
+	case !p.Valid && !other.Valid:
+		return 0
+	case !p.Valid:
+		return 1
+	case !other.Valid:
+		return -1
+	}
+
+	if p.Start != other.Start {
+		return cmp.Compare(p.Start, other.Start)
+	}
+	return cmp.Compare(p.End, other.End)
+}
+
+// String returns the sanitised position. e.g. "1", "0.5", "1-3".
+// If the position is invalid, an empty string is returned.
+func (p SeriesPosition) String() string {
+	if !p.Valid {
+		return ""
+	}
+
+	start := strconv.FormatFloat(p.Start, 'f', -1, 64)
+	if p.Start == p.End {
+		return start
+	}
+
+	return start + "-" + strconv.FormatFloat(p.End, 'f', -1, 64)
+}
@@ -0,0 +1,149 @@
+package goodreads_test
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeriesPosition(t *testing.T) {
+	tests := []struct {
+		position        *string
+		expectedValid   bool
+		expectedString  string
+		expectedPrimary bool
+	}{
+		{position: lo.ToPtr("1"), expectedValid: true, expectedString: "1", expectedPrimary: true},
+		{position: lo.ToPtr(" 2 "), expectedValid: true, expectedString: "2", expectedPrimary: true},
+		{position: lo.ToPtr("0.5"), expectedValid: true, expectedString: "0.5", expectedPrimary: false},
+		{position: lo.ToPtr("1-3"), expectedValid: true, expectedString: "1-3", expectedPrimary: false},
+		{position: lo.ToPtr("1 - 3"), expectedValid: true, expectedString: "1-3", expectedPrimary: false},
+		{position: lo.ToPtr("3-1"), expectedValid: false},
+		{position: lo.ToPtr("prequel"), expectedValid: false},
+		{position: lo.ToPtr(""), expectedValid: false},
+		{position: nil, expectedValid: false},
+	}
+
+	for _, test := range tests {
+		position := goodreads.ParseSeriesPosition(test.position)
+		require.Equal(t, test.expectedValid, position.Valid, lo.FromPtr(test.position))
+		require.Equal(t, test.expectedString, position.String(), lo.FromPtr(test.position))
+		require.Equal(t, test.expectedPrimary, position.Primary(), lo.FromPtr(test.position))
+	}
+}
+
+func TestUnmarshalSeriesDetails(t *testing.T) {
+	testXML := `
+	<GoodreadsResponse>
+		<series>
+			<id>1</id>
+			<title><![CDATA[
+				Test Series
+			]]></title>
+			<description><![CDATA[A test series]]></description>
+			<series_works_count>3</series_works_count>
+			<primary_work_count>2</primary_work_count>
+			<numbered>true</numbered>
+			<series_works>
+				<series_work>
+					<user_position>2</user_position>
+					<work>
+						<id>20</id>
+						<original_title>Book Two</original_title>
+						<original_publication_year>2002</original_publication_year>
+						<best_book>
+							<id>200</id>
+							<title>Book Two (Test Series, #2)</title>
+							<author><id>1</id><name>Test Author</name></author>
+							<image_url>https://i.gr-assets.com/images/S/compressed.photo.goodreads.com/books/1234s/200._SX98_.jpg</image_url>
+						</best_book>
+					</work>
+				</series_work>
+				<series_work>
+					<user_position>1-2</user_position>
+					<work>
+						<id>30</id>
+						<original_title>Omnibus</original_title>
+						<best_book>
+							<id>300</id>
+							<title>Omnibus (Test Series, #1-2)</title>
+							<author><id>1</id><name>Test Author</name></author>
+							<image_url>https://s.gr-assets.com/assets/nophoto/book/111x148.png</image_url>
+						</best_book>
+					</work>
+				</series_work>
+				<series_work>
+					<user_position>1</user_position>
+					<work>
+						<id>10</id>
+						<original_title>Book One</original_title>
+						<best_book>
+							<id>100</id>
+							<title>Book One (Test Series, #1)</title>
+							<author><id>1</id><name>Test Author</name></author>
+						</best_book>
+					</work>
+				</series_work>
+			</series_works>
+		</series>
+	</GoodreadsResponse>
+	`
+
+	var response struct {
+		Series goodreads.SeriesDetails `xml:"series"`
+	}
+	err := xml.Unmarshal([]byte(testXML), &response)
+	require.NoError(t, err)
+
+	series := response.Series
+	series.Sanitise()
+
+	require.Equal(t, "Test Series", series.Title)
+	require.Equal(t, 3, series.TotalBookCount)
+	require.Equal(t, 2, series.PrimaryBookCount)
+	require.True(t, series.Numbered)
+
+	// Works should be sorted by position
+	require.Len(t, series.Works, 3)
+	require.Equal(t, "100", series.Works[0].BestBook.Id)
+	require.Equal(t, "300", series.Works[1].BestBook.Id)
+	require.Equal(t, "200", series.Works[2].BestBook.Id)
+
+	require.True(t, series.Works[0].Primary())
+	require.False(t, series.Works[1].Primary())
+
+	require.Equal(t, "Book Two", series.Works[2].BestBook.Title())
+	require.Equal(t, "Test Author", series.Works[2].BestBook.Author)
+	require.Equal(t, 2002, series.Works[2].Work.PublicationYear)
+	require.Equal(
+		t,
+		"https://i.gr-assets.com/images/S/compressed.photo.goodreads.com/books/1234s/200.jpg",
+		series.Works[2].BestBook.ImageURL,
+	)
+	require.Empty(t, series.Works[1].BestBook.ImageURL)
+}
+
+func TestGetSeriesInvalidId(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requested = true
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := goodreads.NewClient(goodreads.WithURL(server.URL))
+	require.NoError(t, err)
+
+	// Ids that would change the path requested are never requested
+	for _, seriesId := range []string{"../book/show/5907", "40910?id=1", "40910/", ""} {
+		_, err := client.GetSeries(context.Background(), seriesId)
+		require.Error(t, err, seriesId)
+	}
+	require.False(t, requested)
+}
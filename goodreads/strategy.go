@@ -0,0 +1,218 @@
+package goodreads
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/orsinium-labs/enum"
+	"github.com/samber/lo"
+)
+
+// SearchStrategy is a way of searching for a book by its title and author.
+type SearchStrategy enum.Member[string]
+
+var (
+	searchStrategyEnum = enum.NewBuilder[string, SearchStrategy]()
+
+	// SearchStrategyTitle searches by title, sorting books by the similarity of their author
+	SearchStrategyTitle = searchStrategyEnum.Add(SearchStrategy{"title"})
+	// SearchStrategyTitleWithoutSubtitle searches by title with any subtitle or series removed
+	SearchStrategyTitleWithoutSubtitle = searchStrategyEnum.Add(SearchStrategy{"title-without-subtitle"})
+	// SearchStrategyTitleAndAuthor gets the single book goodreads matches to the title and author
+	SearchStrategyTitleAndAuthor = searchStrategyEnum.Add(SearchStrategy{"title-and-author"})
+	// SearchStrategyAuthor searches by author, keeping books with a title similar to the title
+	SearchStrategyAuthor = searchStrategyEnum.Add(SearchStrategy{"author"})
+	// SearchStrategyAll searches all fields by the title and author
+	SearchStrategyAll = searchStrategyEnum.Add(SearchStrategy{"all"})
+
+	SearchStrategies = searchStrategyEnum.Enum()
+
+	// DefaultSearchStrategies are the strategies used by searches, from the most to the least precise
+	DefaultSearchStrategies = []SearchStrategy{
+		SearchStrategyTitle,
+		SearchStrategyTitleWithoutSubtitle,
+		SearchStrategyTitleAndAuthor,
+		SearchStrategyAuthor,
+		SearchStrategyAll,
+	}
+)
+
+const (
+	// DefaultSearchConfidence is the confidence a search strategy must find a book with
+	// for searching to stop, without trying the remaining strategies.
+	DefaultSearchConfidence = 0.8
+
+	// Minimum similarity of the titles of books found by searching by author to the searched title
+	minAuthorSearchTitleSimilarity = 0.7
+)
+
+// searchBooksByStrategies searches for books by a title and optionally an author using each search strategy
+// of the client in turn, until a strategy finds a book with at least the search confidence of the client.
+// If no strategy does, the books found with the best confidence are returned. Unless the context is already
+// limited, searching makes at most utils.SearchRequestLimit requests over all strategies, after which the
+// books found with the best confidence so far are returned.
+func (c *Client) searchBooksByStrategies(ctx context.Context, title string, author string) ([]Book, error) {
+	if !utils.HasRequestLimit(ctx) {
+		ctx = utils.WithRequestLimit(ctx, utils.SearchRequestLimit)
+	}
+
+	searchQuery := query.Parse(title, author)
+
+	var bestBooks []Book
+	bestConfidence := -1.0
+	for idx, strategy := range c.searchStrategies {
+		books, err := c.searchBooksByStrategy(ctx, strategy, title, author)
+		if errors.Is(err, utils.ErrRequestLimit) {
+			log.Printf("Goodreads search for %q reached its request limit during the %s strategy", title, strategy.Value)
+			break
+		}
+		if err != nil {
+			// If the first strategy fails, goodreads is likely unavailable so searching stops.
+			// Other strategies can fail when they find nothing e.g. getting a book by its title
+			if idx == 0 || ctx.Err() != nil {
+				return nil, err
+			}
+			continue
+		}
+		if len(books) == 0 {
+			continue
+		}
+
+		confidence := lo.Max(lo.Map(books, func(book Book, _ int) float64 {
+			return matchConfidence(searchQuery, book)
+		}))
+		if confidence > bestConfidence {
+			bestBooks = books
+			bestConfidence = confidence
+		}
+		if confidence >= c.searchConfidence {
+			break
+		}
+	}
+
+	return bestBooks, nil
+}
+
+// matchConfidence is how confident it is that a book is the book searched for by a query, between 0 and 1.
+func matchConfidence(searchQuery query.Query, book Book) float64 {
+	var year string
+	if book.Work.PublicationYear != 0 {
+		year = strconv.Itoa(book.Work.PublicationYear)
+	}
+
+	return searchQuery.Confidence(query.Match{
+		Title:    book.BestEdition.Title(),
+		Subtitle: book.BestEdition.Subtitle(),
+		Author:   strings.Join(lo.Map(book.Authors, func(author AuthorDetails, _ int) string { return author.Name }), ", "),
+		Series:   lo.Map(book.Series, func(series SeriesBook, _ int) string { return series.Series.Title }),
+		Year:     year,
+	})
+}
+
+// searchBooksByStrategy searches for books by a title and optionally an author using a search strategy.
+// Strategies that need an author, or a title with a subtitle, find no books if there is not one.
+func (c *Client) searchBooksByStrategy(
+	ctx context.Context,
+	strategy SearchStrategy,
+	title string,
+	author string,
+) ([]Book, error) {
+	var books []Book
+	var err error
+	switch strategy {
+	case SearchStrategyTitle:
+		books, err = c.searchBooksByTitle(ctx, title)
+
+	case SearchStrategyTitleWithoutSubtitle:
+		titleWithoutSubtitle := extractTitle(title)
+		if titleWithoutSubtitle == "" || titleWithoutSubtitle == title {
+			return nil, nil
+		}
+		books, err = c.searchBooksByTitle(ctx, titleWithoutSubtitle)
+
+	case SearchStrategyTitleAndAuthor:
+		if author == "" {
+			return nil, nil
+		}
+		book, err := c.GetBookByTitle(ctx, title, &author)
+		if err != nil {
+			return nil, err
+		}
+		if book.BestEdition.Id != "" {
+			books = []Book{book}
+		}
+
+	case SearchStrategyAuthor:
+		if author == "" {
+			return nil, nil
+		}
+		books, err = c.searchBooksByAuthorWithTitle(ctx, author, title)
+
+	case SearchStrategyAll:
+		books, err = c.searchBooksAll(ctx, strings.TrimSpace(title+" "+author))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if author != "" && strategy != SearchStrategyAuthor {
+		sortBookByAuthorSimilarity(books, author)
+	}
+
+	return books, nil
+}
+
+// searchBooksByAuthorWithTitle searches for books by an author, only getting the books with a title
+// similar to the title. Books are sorted by the similarity of their title.
+func (c *Client) searchBooksByAuthorWithTitle(ctx context.Context, author string, title string) ([]Book, error) {
+	bookOverviews, err := c.searchBooksManyPages(ctx, searchBooksManyPagesInput{
+		Query:      author,
+		SearchType: BookSearchTypeAuthor,
+		Page:       1,
+		NumPages:   10,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	titleSimilarities := make(map[string]float64, len(bookOverviews))
+	for _, bookOverview := range bookOverviews {
+		titleSimilarities[bookOverview.Id] = titleSimilarity(bookOverview.FullTitle, title)
+	}
+
+	bookOverviews = lo.Filter(bookOverviews, func(bookOverview BookOverview, _ int) bool {
+		return titleSimilarities[bookOverview.Id] >= minAuthorSearchTitleSimilarity
+	})
+	slices.SortStableFunc(bookOverviews, func(i, j BookOverview) int {
+		switch {
+		case titleSimilarities[i.Id] > titleSimilarities[j.Id]:
+			return -1
+		case titleSimilarities[i.Id] < titleSimilarities[j.Id]:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return c.GetBooksByIds(ctx, BookIds(bookOverviews))
+}
+
+func (c *Client) searchBooksAll(ctx context.Context, query string) ([]Book, error) {
+	bookOverviews, err := c.searchBooksManyPages(ctx, searchBooksManyPagesInput{
+		Query:      query,
+		SearchType: BookSearchTypeAll,
+		Page:       1,
+		NumPages:   10,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetBooksByIds(ctx, BookIds(bookOverviews))
+}
@@ -0,0 +1,221 @@
+package goodreads_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+// strategyBooks are the titles and authors of the books of the strategy test server
+var strategyBooks = map[string][2]string{
+	"1": {"The Final Empire (Mistborn, #1)", "Brandon Sanderson"},
+	"2": {"The Well of Ascension (Mistborn, #2)", "Brandon Sanderson"},
+	"3": {"Final Empire Fan Guide", "Someone Else"},
+	"4": {"Elantris", "Brandon Sanderson"},
+	"5": {"Warbreaker", "Brandon Sanderson"},
+}
+
+// strategySearches are the ids of the books found by searches of the strategy test server, by field and query
+var strategySearches = map[string][]string{
+	"title|The Final Empire":         {"3"},
+	"title|The Well of Ascension":    {"2"},
+	"author|Brandon Sanderson":       {"2", "1"},
+	"all|Elantris Brandon Sanderson": {"4"},
+}
+
+func TestSearchStrategies(t *testing.T) {
+	tests := map[string]struct {
+		title              string
+		author             string
+		options            []goodreads.ClientOption
+		expectedId         string
+		expectedStrategies []string
+	}{
+		"title": {
+			title:              "The Well of Ascension",
+			expectedId:         "2",
+			expectedStrategies: []string{"title"},
+		},
+		"title without subtitle": {
+			title:              "The Well of Ascension: Mistborn Book Two",
+			expectedId:         "2",
+			expectedStrategies: []string{"title", "title"},
+		},
+		"title and author": {
+			title:              "Warbreaker",
+			author:             "Brandon Sanderson",
+			expectedId:         "5",
+			expectedStrategies: []string{"title", "title-and-author"},
+		},
+		"author": {
+			title:              "Well of Ascension",
+			author:             "Brandon Sanderson",
+			expectedId:         "2",
+			expectedStrategies: []string{"title", "title-and-author", "author"},
+		},
+		"all": {
+			title:              "Elantris",
+			author:             "Brandon Sanderson",
+			expectedId:         "4",
+			expectedStrategies: []string{"title", "title-and-author", "author", "all"},
+		},
+		"low confidence falls through": {
+			title:              "The Final Empire",
+			author:             "Brandon Sanderson",
+			expectedId:         "1",
+			expectedStrategies: []string{"title", "title-and-author", "author"},
+		},
+		"no confidence stops at first books found": {
+			title:              "The Final Empire",
+			author:             "Brandon Sanderson",
+			options:            []goodreads.ClientOption{goodreads.WithSearchConfidence(0)},
+			expectedId:         "3",
+			expectedStrategies: []string{"title"},
+		},
+		"best books if confidence is not met": {
+			title:  "The Final Empire",
+			author: "Brandon Sanderson",
+			options: []goodreads.ClientOption{
+				goodreads.WithSearchStrategies(goodreads.SearchStrategyTitle, goodreads.SearchStrategyAll),
+			},
+			expectedId:         "3",
+			expectedStrategies: []string{"title", "all"},
+		},
+		"no books": {
+			title:              "Elantris",
+			author:             "Brandon Sanderson",
+			options:            []goodreads.ClientOption{goodreads.WithSearchStrategies(goodreads.SearchStrategyTitle)},
+			expectedStrategies: []string{"title"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, strategies := newStrategyTestClient(t, test.options...)
+
+			books, err := client.SearchBooks(context.Background(), test.title, lo.EmptyableToPtr(test.author))
+			require.NoError(t, err)
+			require.Equal(t, test.expectedStrategies, *strategies)
+
+			if test.expectedId == "" {
+				require.Empty(t, books)
+				return
+			}
+			require.NotEmpty(t, books)
+			require.Equal(t, test.expectedId, books[0].BestEdition.Id)
+		})
+	}
+}
+
+func TestSearchStrategiesUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := goodreads.NewClient(goodreads.WithURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.SearchBooks(context.Background(), "The Final Empire", lo.ToPtr("Brandon Sanderson"))
+	require.Error(t, err)
+}
+
+func TestNewInvalidSearchConfidence(t *testing.T) {
+	_, err := goodreads.NewClient(goodreads.WithSearchConfidence(1.5))
+	require.Error(t, err)
+}
+
+func TestSearchStrategiesRequestLimit(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`<GoodreadsResponse></GoodreadsResponse>`))
+	}))
+	defer server.Close()
+
+	client, err := goodreads.NewClient(goodreads.WithURL(server.URL))
+	require.NoError(t, err)
+
+	// Searching stops once the limit of the context is reached
+	ctx := utils.WithRequestLimit(context.Background(), 5)
+	books, err := client.SearchBooks(ctx, "Elantris", lo.ToPtr("Brandon Sanderson"))
+	require.NoError(t, err)
+	require.Empty(t, books)
+	require.EqualValues(t, 5, requests.Load())
+
+	// The books found with the best confidence before the limit was reached are returned
+	strategyClient, _ := newStrategyTestClient(t)
+	ctx = utils.WithRequestLimit(context.Background(), 15)
+	books, err = strategyClient.SearchBooks(ctx, "The Final Empire", lo.ToPtr("Brandon Sanderson"))
+	require.NoError(t, err)
+	require.NotEmpty(t, books)
+	require.Equal(t, "3", books[0].BestEdition.Id)
+}
+
+// newStrategyTestClient creates a client whose goodreads serves the strategy books. The search
+// fields and book/title.xml requests (as "title-and-author") made by the client are recorded.
+func newStrategyTestClient(t *testing.T, options ...goodreads.ClientOption) (*goodreads.Client, *[]string) {
+	strategies := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		w.Header().Set("Content-Type", "application/xml")
+
+		switch r.URL.Path {
+		case "/search/index.xml":
+			// Only record the first page of each search
+			if query.Get("page") != "1" {
+				_, _ = w.Write([]byte(`<GoodreadsResponse></GoodreadsResponse>`))
+				return
+			}
+			strategies = append(strategies, query.Get("search[field]"))
+
+			var results strings.Builder
+			for _, bookId := range strategySearches[query.Get("search[field]")+"|"+query.Get("q")] {
+				_, _ = fmt.Fprintf(
+					&results,
+					`<work><best_book><id>%s</id><title>%s</title><author><name>%s</name></author></best_book></work>`,
+					bookId, strategyBooks[bookId][0], strategyBooks[bookId][1],
+				)
+			}
+			_, _ = fmt.Fprintf(w, `<GoodreadsResponse><search><results>%s</results></search></GoodreadsResponse>`, &results)
+
+		case "/book/title.xml":
+			strategies = append(strategies, "title-and-author")
+			if query.Get("title") != "Warbreaker" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeStrategyBook(w, "5")
+
+		case "/book/show.xml":
+			writeStrategyBook(w, query.Get("id"))
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := goodreads.NewClient(append([]goodreads.ClientOption{goodreads.WithURL(server.URL)}, options...)...)
+	require.NoError(t, err)
+
+	return client, &strategies
+}
+
+func writeStrategyBook(w http.ResponseWriter, bookId string) {
+	_, _ = fmt.Fprintf(
+		w,
+		`<GoodreadsResponse><book><id>%s</id><title>%s</title>`+
+			`<authors><author><name>%s</name></author></authors></book></GoodreadsResponse>`,
+		bookId, strategyBooks[bookId][0], strategyBooks[bookId][1],
+	)
+}
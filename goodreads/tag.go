@@ -0,0 +1,159 @@
+package goodreads
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	mapset "github.com/deckarep/golang-set/v2"
+)
+
+const (
+	DefaultMaxTags           = 10
+	DefaultMinTagShelfWeight = 0.03
+)
+
+var (
+	// DefaultTagExtractor is the tag extractor used to extract tags from shelves
+	// by clients not given a tag extractor. It should be copied rather than modified.
+	DefaultTagExtractor = &TagExtractor{
+		MaxTags:        DefaultMaxTags,
+		MinShelfWeight: DefaultMinTagShelfWeight,
+	}
+
+	// Patterns matching personal shelves that users use to organise their own books.
+	// These shelves say nothing about the book itself so should never be tags.
+	defaultBlockedShelfPatterns = []*regexp.Regexp{
+		// Years read, but not years the book is about. e.g. 2019-reads or read-in-2020, but not 1984
+		regexp.MustCompile(`(read|reads|reading)-?(in-)?\d{4}|\d{4}-(reads?|reading)`),
+		regexp.MustCompile(`^(currently-)?read(ing)?$|-read$`),
+		regexp.MustCompile(`(^|-)(to-?read|tbr|to-?buy|wish-?list|want-to-read|on-hold)($|-)`),
+		// Books owned, but not own voices books. e.g. owned, books-i-own or owned-books, but not own-voices
+		regexp.MustCompile(`(^|-)(i-)?own(ed|s)?($|-(books?|copy|copies|shelf)($|-))`),
+		regexp.MustCompile(`(^|-)(fav(ou?rite)?s?|faves|all-time-fav.*)($|-)`),
+		regexp.MustCompile(`(^|-)(re-?reads?|dnf|did-not-finish|abandoned|unfinished|gave-up)($|-)`),
+		regexp.MustCompile(`(^|-)(kindle|nook|kobo|e-?books?|audible|library|borrowed|default)($|-)`),
+		regexp.MustCompile(`(^|-)(book-?club|my-|shelfari|goodreads|series|books?$)`),
+	}
+)
+
+type Tags []string
+
+// TagExtractor extracts tags from the goodreads shelves of a book.
+// Tags are the shelves that are neither genres nor personal shelves (e.g. "to-read").
+type TagExtractor struct {
+	// MaxTags is the maximum number of tags to extract from shelves.
+	// If <= 0, there is no maximum.
+	MaxTags int
+
+	// MinShelfWeight is the minimum popularity of a shelf for it to be used as a tag,
+	// relative to the most popular (non personal) shelf of a book. Between 0 and 1.
+	MinShelfWeight float64
+
+	// GenreTaxonomy is used to exclude genre shelves from tags.
+	// If nil, DefaultGenreTaxonomy is used.
+	GenreTaxonomy *GenreTaxonomy
+
+	// blockedShelves are patterns of shelves that are blocked
+	// in addition to the default personal shelf patterns
+	blockedShelves []*regexp.Regexp
+}
+
+// BlockShelves adds patterns (regular expressions) of shelf names that should never be tags.
+// These are in addition to the default patterns of personal shelves.
+// Patterns are matched against normalised shelf names. e.g. "to-read", "read-in-2019"
+func (t *TagExtractor) BlockShelves(patterns ...string) error {
+	blockedShelves := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		blockedShelf, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid blocked shelf pattern: %w", err)
+		}
+		blockedShelves = append(blockedShelves, blockedShelf)
+	}
+
+	t.blockedShelves = append(t.blockedShelves, blockedShelves...)
+
+	return nil
+}
+
+// Blocked returns whether a shelf is blocked from being a tag.
+func (t *TagExtractor) Blocked(shelf string) bool {
+	shelf = normaliseShelfName(shelf)
+	for _, blockedShelves := range [][]*regexp.Regexp{defaultBlockedShelfPatterns, t.blockedShelves} {
+		for _, blockedShelf := range blockedShelves {
+			if blockedShelf.MatchString(shelf) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Tags extracts tags from shelves, in the order of the shelves.
+// Blocked shelves, genre shelves and unpopular shelves are skipped.
+// Tags are deduplicated and limited to the maximum number of tags.
+func (t *TagExtractor) Tags(shelves []Shelf) Tags {
+	genreTaxonomy := t.GenreTaxonomy
+	if genreTaxonomy == nil {
+		genreTaxonomy = DefaultGenreTaxonomy
+	}
+
+	// Remove blocked shelves and get the count of the most popular shelf.
+	// Personal shelves (e.g. to-read) are always the most popular, so must
+	// be removed before the popularity of other shelves is calculated.
+	candidateShelves := make([]Shelf, 0, len(shelves))
+	maxShelfCount := 0
+	for _, shelf := range shelves {
+		if t.Blocked(shelf.Name) {
+			continue
+		}
+		candidateShelves = append(candidateShelves, shelf)
+		maxShelfCount = max(maxShelfCount, shelf.Count)
+	}
+
+	tags := make(Tags, 0, len(candidateShelves))
+	seenShelves := mapset.NewSetWithSize[string](len(candidateShelves))
+	for _, shelf := range candidateShelves {
+		if t.MaxTags > 0 && len(tags) >= t.MaxTags {
+			break
+		}
+
+		// Skip unpopular shelves
+		if maxShelfCount > 0 && float64(shelf.Count)/float64(maxShelfCount) < t.MinShelfWeight {
+			continue
+		}
+
+		// Skip genre shelves and already seen shelves
+		singularShelf := inflection.Singular(normaliseShelfName(shelf.Name))
+		if genreTaxonomy.Genre(shelf.Name) != "" || seenShelves.Contains(singularShelf) {
+			continue
+		}
+
+		tags = append(tags, shelfToTag(shelf.Name))
+		seenShelves.Add(singularShelf)
+	}
+
+	return tags
+}
+
+var vowelRegex = regexp.MustCompile(`[aeiouy]`)
+
+// shelfToTag converts a shelf name to a human readable tag.
+// e.g. "time-travel" -> "Time Travel", "lgbtq" -> "LGBTQ"
+func shelfToTag(shelf string) string {
+	words := strings.Split(normaliseShelfName(shelf), "-")
+	for idx, word := range words {
+		if !vowelRegex.MatchString(word) {
+			// Words without vowels are most likely acronyms
+			words[idx] = strings.ToUpper(word)
+		} else {
+			words[idx] = cases.Title(language.Und).String(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
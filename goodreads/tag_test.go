@@ -0,0 +1,67 @@
+package goodreads_test
+
+import (
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagExtractorBlocked(t *testing.T) {
+	tagExtractor := goodreads.DefaultTagExtractor
+
+	blockedShelves := []string{
+		"to-read", "currently-reading", "read", "owned", "books-i-own", "owned-books",
+		"favourites", "favorites", "all-time-favorites", "kindle", "ebooks", "2019-reads",
+		"read-in-2020", "read-2021", "books-read-in-2022", "2023-reading", "tbr", "physical-tbr", "dnf",
+		"library", "book-club", "wish-list", "i-own", "own-books", "owned-copy",
+	}
+	for _, shelf := range blockedShelves {
+		require.True(t, tagExtractor.Blocked(shelf), shelf)
+	}
+
+	allowedShelves := []string{
+		"dystopian", "dragons", "time-travel", "lgbtq", "audiobook", "crown", "reading-challenge",
+		"1984", "set-in-1920s", "own-voices", "ownvoices",
+	}
+	for _, shelf := range allowedShelves {
+		require.False(t, tagExtractor.Blocked(shelf), shelf)
+	}
+}
+
+func TestTagExtractorTags(t *testing.T) {
+	shelves := []goodreads.Shelf{
+		{Name: "to-read", Count: 100000},
+		{Name: "fantasy", Count: 1000},
+		{Name: "dystopian", Count: 800},
+		{Name: "owned", Count: 700},
+		{Name: "dragons", Count: 600},
+		{Name: "dragon", Count: 500}, // Duplicate tag
+		{Name: "2019-reads", Count: 400},
+		{Name: "time-travel", Count: 300},
+		{Name: "lgbtq", Count: 200},
+		{Name: "audiobook", Count: 100},
+		{Name: "obscure", Count: 10},
+	}
+
+	tagExtractor := &goodreads.TagExtractor{MinShelfWeight: 0.05}
+	tags := tagExtractor.Tags(shelves)
+	require.Equal(t, goodreads.Tags{"Dystopian", "Dragons", "Time Travel", "LGBTQ", "Audiobook"}, tags)
+
+	tagExtractor = &goodreads.TagExtractor{MaxTags: 2}
+	tags = tagExtractor.Tags(shelves)
+	require.Equal(t, goodreads.Tags{"Dystopian", "Dragons"}, tags)
+
+	tagExtractor = &goodreads.TagExtractor{}
+	err := tagExtractor.BlockShelves("^dys")
+	require.NoError(t, err)
+	tags = tagExtractor.Tags(shelves)
+	require.NotContains(t, tags, "Dystopian")
+	require.Contains(t, tags, "Obscure")
+}
+
+func TestTagExtractorInvalidBlockedShelf(t *testing.T) {
+	tagExtractor := &goodreads.TagExtractor{}
+	err := tagExtractor.BlockShelves("(")
+	require.Error(t, err)
+}
@@ -10,23 +10,10 @@ import (
 )
 
 func sortBookByAuthorSimilarity(books []Book, author string) {
-	normalisedDesiredAuthor := normaliseString(author)
-
 	// Get the best similarity of all authors of the book
 	authorSimilarities := make(map[string]float64)
 	for _, book := range books {
-		bestAuthorSimilarity := 0.0
-		for _, author := range book.Authors {
-			authorSimilarity := strutil.Similarity(
-				normaliseString(author.Name),
-				normalisedDesiredAuthor,
-				metrics.NewJaroWinkler(),
-			)
-			if authorSimilarity > bestAuthorSimilarity {
-				bestAuthorSimilarity = authorSimilarity
-			}
-		}
-		authorSimilarities[book.BestEdition.Id] = bestAuthorSimilarity
+		authorSimilarities[book.BestEdition.Id] = authorSimilarity(book, author)
 	}
 
 	slices.SortStableFunc(books, func(i, j Book) int {
@@ -42,6 +29,45 @@ func sortBookByAuthorSimilarity(books []Book, author string) {
 	})
 }
 
+// authorSimilarity is the best similarity of the authors of a book to an author, between 0 and 1.
+func authorSimilarity(book Book, author string) float64 {
+	normalisedDesiredAuthor := normaliseString(author)
+
+	bestAuthorSimilarity := 0.0
+	for _, bookAuthor := range book.Authors {
+		similarity := strutil.Similarity(
+			normaliseString(bookAuthor.Name),
+			normalisedDesiredAuthor,
+			metrics.NewJaroWinkler(),
+		)
+		if similarity > bestAuthorSimilarity {
+			bestAuthorSimilarity = similarity
+		}
+	}
+
+	return bestAuthorSimilarity
+}
+
+// titleSimilarity is the similarity of a full title to a title, between 0 and 1.
+// Titles are compared with and without any subtitle or series, as either could include them.
+func titleSimilarity(fullTitle string, title string) float64 {
+	bestTitleSimilarity := 0.0
+	for _, fullTitleVariant := range []string{fullTitle, extractTitle(fullTitle)} {
+		for _, titleVariant := range []string{title, extractTitle(title)} {
+			similarity := strutil.Similarity(
+				normaliseString(fullTitleVariant),
+				normaliseString(titleVariant),
+				metrics.NewJaroWinkler(),
+			)
+			if similarity > bestTitleSimilarity {
+				bestTitleSimilarity = similarity
+			}
+		}
+	}
+
+	return bestTitleSimilarity
+}
+
 var (
 	spaceRegex        = regexp.MustCompile(`\s+`)
 	alphanumericRegex = regexp.MustCompile(`[^a-zA-Z0-9 ]`)
@@ -1,6 +1,7 @@
 package kindle
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -27,6 +28,7 @@ type Book struct {
 	Title       string
 	Author      string
 	Cover       string
+	Covers      []string // Original covers of all images in the cover set. Usually the same as the cover
 	PublishDate *time.Time
 }
 
@@ -67,15 +69,21 @@ func BookFromHTML(bookNode *html.Node) *Book {
 		return nil
 	}
 
-	cover := bookCover(bookNode)
+	covers := bookCovers(bookNode)
 	author, publishDate := bookInfo(bookNode)
 
+	var cover string
+	if len(covers) != 0 {
+		cover = covers[0]
+	}
+
 	return &Book{
 		ASIN:        asin,
 		Format:      format,
 		Title:       title,
 		Author:      author,
 		Cover:       cover,
+		Covers:      covers,
 		PublishDate: publishDate,
 	}
 }
@@ -103,9 +111,12 @@ func bookTitle(bookNode *html.Node) string {
 	return strings.TrimSpace(titleNodeValue)
 }
 
-// bookCover gets the book cover.
-func bookCover(bookNode *html.Node) string {
+// bookCovers gets the book covers.
+func bookCovers(bookNode *html.Node) []string {
 	coverSetAttr := htmlquery.QuerySelector(bookNode, bookCoverSetExpr)
+	if coverSetAttr == nil {
+		return nil
+	}
 	coverSetAttrValue := htmlquery.InnerText(coverSetAttr)
 	return parseBookCoversAttrValue(coverSetAttrValue)
 }
@@ -119,14 +130,25 @@ func bookInfo(bookNode *html.Node) (string, *time.Time) {
 }
 
 // parseBookCoversAttrValue parses the value of the book cover set attribute.
-// Returns the url of the original/full-size book cover.
+// Returns the urls of the original/full-size book covers of every entry in the set, without duplicates.
 // See test for expected value format.
-func parseBookCoversAttrValue(coverSetAttrValue string) string {
-	// Get first cover url from the cover set.
-	// This will not be original full size cover
-	modifiedCoverUrl := strings.Fields(coverSetAttrValue)[0]
-	originalCoverUrl := utils.SanitiseImageURL(modifiedCoverUrl)
-	return originalCoverUrl
+func parseBookCoversAttrValue(coverSetAttrValue string) []string {
+	var originalCoverUrls []string
+	for _, field := range strings.Fields(coverSetAttrValue) {
+		// Fields are urls of modified covers, each followed by its pixel density descriptor.
+		// Cover urls can contain commas, so entries can not be split on commas.
+		if !strings.HasPrefix(field, "http") {
+			continue
+		}
+
+		modifiedCoverUrl := strings.TrimSuffix(field, ",")
+		originalCoverUrl := utils.SanitiseImageURL(modifiedCoverUrl)
+		if originalCoverUrl != "" && !slices.Contains(originalCoverUrls, originalCoverUrl) {
+			originalCoverUrls = append(originalCoverUrls, originalCoverUrl)
+		}
+	}
+
+	return originalCoverUrls
 }
 
 // parseBookInfoNodeValue parses the value of the book info node.
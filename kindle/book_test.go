@@ -11,8 +11,24 @@ func TestParseBookCoversAttrValue(t *testing.T) {
 	coverUrl := "https://m.media-amazon.com/images/I/61Ng-W9EhBL.jpg"
 
 	coverSetAttrValue := "https://m.media-amazon.com/images/I/61Ng-W9EhBL._AC_UY218_.jpg 1x, https://m.media-amazon.com/images/I/61Ng-W9EhBL._AC_UY327_QL65_.jpg 1.5x, https://m.media-amazon.com/images/I/61Ng-W9EhBL._AC_UY436_QL65_.jpg 2x, https://m.media-amazon.com/images/I/61Ng-W9EhBL._AC_UY500_QL65_.jpg 2.2935x" // nolint
-	parsedCoverUrl := parseBookCoversAttrValue(coverSetAttrValue)
-	require.Equal(t, coverUrl, parsedCoverUrl)
+	parsedCoverUrls := parseBookCoversAttrValue(coverSetAttrValue)
+	require.Equal(t, []string{coverUrl}, parsedCoverUrls)
+}
+
+func TestParseBookCoversAttrValueMultiple(t *testing.T) {
+	coverSetAttrValue := "https://m.media-amazon.com/images/I/61Ng-W9EhBL._AC_SR160,160_.jpg 1x, " +
+		"https://m.media-amazon.com/images/I/71V2v2GtAtL._AC_SR320,320_.jpg 2x"
+	parsedCoverUrls := parseBookCoversAttrValue(coverSetAttrValue)
+	require.Equal(
+		t,
+		[]string{
+			"https://m.media-amazon.com/images/I/61Ng-W9EhBL.jpg",
+			"https://m.media-amazon.com/images/I/71V2v2GtAtL.jpg",
+		},
+		parsedCoverUrls,
+	)
+
+	require.Empty(t, parseBookCoversAttrValue(""))
 }
 
 func TestParseBookInfoNodeValue(t *testing.T) {
@@ -1,30 +1,53 @@
 package kindle
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/ahobsonsayers/abs-tract/utils"
 	"github.com/imroc/req/v3"
+	"github.com/orsinium-labs/enum"
 	"github.com/samber/lo"
 	"golang.org/x/net/html"
 )
 
-const DefaultAmazonURL = "https://www.amazon.com"
+const (
+	DefaultAmazonURL = "https://www.amazon.com"
+
+	// Maximum number of proxies a request is attempted through before giving up
+	maxProxyAttempts = 3
+)
 
 var (
 	defaultAmazonURL = lo.Must(url.Parse(DefaultAmazonURL))
 
-	DefaultClient = &Client{
-		client:    req.C().ImpersonateChrome(),
-		amazonUrl: utils.CloneURL(defaultAmazonURL),
-	}
+	DefaultClient = lo.Must(NewClient(nil))
+
+	ErrBlocked = errors.New("blocked by amazon")
+)
+
+// Department is a department of the amazon store that books are searched in.
+type Department enum.Member[string]
+
+var (
+	departmentEnum = enum.NewBuilder[string, Department]()
+
+	// DepartmentKindle is the kindle store, containing kindle ebooks.
+	DepartmentKindle = departmentEnum.Add(Department{"digital-text"})
+	// DepartmentBooks is the books store, containing print books.
+	DepartmentBooks = departmentEnum.Add(Department{"stripbooks"})
+
+	Departments = departmentEnum.Enum()
 )
 
 type Client struct {
-	client    *req.Client
+	sessions  *sessions
 	amazonUrl *url.URL
+	breaker   *utils.CircuitBreaker
 }
 
 // URL returns a clone of of the amazon url used by the client
@@ -44,26 +67,104 @@ func (c *Client) get(
 	requestUrl = requestUrl.JoinPath(path)
 	requestUrl.RawQuery = queryParams.Encode()
 
-	response, err := c.client.R().SetContext(ctx).Get(requestUrl.String())
+	err := utils.TakeRequest(ctx)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
-	if !response.IsSuccessState() {
-		return nil, fmt.Errorf("%s: %s", response.GetStatus(), response.String())
+
+	var trial bool
+	if c.breaker != nil {
+		var err error
+		trial, err = c.breaker.Allow()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	htmlResponse, err := html.Parse(response.Body)
-	if err != nil {
-		return nil, nil
+	for attempt := 1; ; attempt++ {
+		session, err := c.sessions.get()
+		if err != nil {
+			c.release(trial)
+			return nil, err
+		}
+
+		response, err := session.client.R().SetContext(ctx).Get(requestUrl.String())
+
+		// If using a proxy and the request failed or was blocked, retry using the next proxy.
+		// Failures of proxies are recorded on the health of the proxy, not the circuit breaker.
+		failed := err != nil || isBlocked(response)
+		if failed && session.proxy != nil && ctx.Err() == nil {
+			c.sessions.blocked(session)
+			if attempt < maxProxyAttempts {
+				continue
+			}
+			c.release(trial)
+		} else {
+			c.record(ctx, trial, response, err)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", err)
+		}
+		if isBlocked(response) {
+			if session.proxy == nil {
+				c.sessions.blocked(session)
+			}
+			return nil, fmt.Errorf("%w: %s", ErrBlocked, response.GetStatus())
+		}
+		if !response.IsSuccessState() {
+			return nil, fmt.Errorf("%s: %s", response.GetStatus(), response.String())
+		}
+
+		htmlResponse, err := html.Parse(bytes.NewReader(response.Bytes()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response body: %w", err)
+		}
+
+		return htmlResponse, nil
+	}
+}
+
+// record records the outcome of a request on the circuit breaker, if there is one.
+// Cancelled requests say nothing about amazon, so are not recorded.
+func (c *Client) record(ctx context.Context, trial bool, response *req.Response, err error) {
+	if c.breaker == nil {
+		return
+	}
+	if ctx.Err() != nil {
+		c.breaker.Release(trial)
+		return
 	}
 
-	return htmlResponse, nil
+	var httpResponse *http.Response
+	if response != nil {
+		httpResponse = response.Response
+	}
+	c.breaker.Record(trial, !utils.ShouldRetry(httpResponse, err))
+}
+
+// release releases a request from the circuit breaker without recording its outcome, if there is a breaker.
+func (c *Client) release(trial bool) {
+	if c.breaker != nil {
+		c.breaker.Release(trial)
+	}
 }
 
+// Search searches for books in the kindle store by their title and optionally an author.
 func (c *Client) Search(ctx context.Context, title string, author *string) ([]Book, error) {
+	return c.SearchDepartment(ctx, DepartmentKindle, title, author)
+}
+
+// SearchDepartment searches for books in a department of the amazon store
+// by their title and optionally an author.
+func (c *Client) SearchDepartment(
+	ctx context.Context,
+	department Department,
+	title string,
+	author *string,
+) ([]Book, error) {
 	parameters := map[string]string{
-		"i": "digital-text",
-		// "i": "stripbooks",
+		"i": department.Value,
 		"k": title,
 	}
 	if author != nil && *author != "" {
@@ -2,9 +2,15 @@ package kindle_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/ahobsonsayers/abs-tract/fixture"
 	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/ahobsonsayers/abs-tract/utils"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
@@ -15,9 +21,12 @@ const (
 )
 
 func TestSearchBook(t *testing.T) {
-	t.Skip("Fails in CI")
+	server := fixture.NewServer(t, "../testdata/kindle", kindle.DefaultAmazonURL)
+	client, err := kindle.NewClient(nil, kindle.WithURL(server.URL))
+	require.NoError(t, err)
+
 	// Should return https://www.amazon.com/dp/B007978NU6
-	books, err := kindle.DefaultClient.Search(
+	books, err := client.Search(
 		context.Background(),
 		TheHobbitTitle,
 		lo.ToPtr(TheHobbitAuthor),
@@ -30,4 +39,53 @@ func TestSearchBook(t *testing.T) {
 	require.Equal(t, "The Hobbit: 75th Anniversary Edition", book.Title)
 	require.Equal(t, "J.R.R. Tolkien and Christopher Tolkien", book.Author)
 	require.Equal(t, "https://m.media-amazon.com/images/I/61Ng-W9EhBL.jpg", book.Cover)
+
+	// Results that are not books should be skipped
+	for _, book := range books {
+		require.NotEmpty(t, book.Format)
+	}
+}
+
+func TestSearchBookDepartment(t *testing.T) {
+	var department string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		department = r.URL.Query().Get("i")
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><body></body></html>"))
+	}))
+	defer server.Close()
+
+	client, err := kindle.NewClient(nil, kindle.WithURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.Search(context.Background(), TheHobbitTitle, nil)
+	require.NoError(t, err)
+	require.Equal(t, kindle.DepartmentKindle.Value, department)
+
+	_, err = client.SearchDepartment(context.Background(), kindle.DepartmentBooks, TheHobbitTitle, nil)
+	require.NoError(t, err)
+	require.Equal(t, "stripbooks", department)
+}
+
+func TestSearchBookCircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	breaker := utils.NewCircuitBreaker("kindle", 1, time.Minute)
+	client, err := kindle.NewClient(nil, kindle.WithURL(server.URL), kindle.WithCircuitBreaker(breaker))
+	require.NoError(t, err)
+
+	// Failures of amazon should open the circuit, stopping requests being made
+	_, err = client.Search(context.Background(), TheHobbitTitle, nil)
+	require.Error(t, err)
+	require.Equal(t, utils.CircuitStateOpen, breaker.State())
+
+	_, err = client.Search(context.Background(), TheHobbitTitle, nil)
+	var circuitOpenErr *utils.CircuitOpenError
+	require.ErrorAs(t, err, &circuitOpenErr)
+	require.EqualValues(t, 1, requests.Load())
 }
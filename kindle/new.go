@@ -1,12 +1,16 @@
 package kindle
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/ahobsonsayers/abs-tract/utils"
 	"github.com/imroc/req/v3"
+	"github.com/orsinium-labs/enum"
 	"github.com/samber/lo"
 )
 
@@ -23,23 +27,200 @@ var countryAmazonURLs = map[string]*url.URL{
 	"us": defaultAmazonURL,
 }
 
+// Impersonation is a browser that requests are made to look like they are from.
+type Impersonation enum.Member[string]
+
+var (
+	impersonationEnum = enum.NewBuilder[string, Impersonation]()
+
+	ImpersonationNone    = impersonationEnum.Add(Impersonation{"none"})
+	ImpersonationChrome  = impersonationEnum.Add(Impersonation{"chrome"})
+	ImpersonationFirefox = impersonationEnum.Add(Impersonation{"firefox"})
+	ImpersonationSafari  = impersonationEnum.Add(Impersonation{"safari"})
+
+	Impersonations = impersonationEnum.Enum()
+
+	DefaultImpersonation = ImpersonationChrome
+)
+
+// ClientOption configures a client created using NewClient.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	url            string
+	transport      http.RoundTripper
+	impersonations []Impersonation
+	userAgent      string
+	timeout        time.Duration
+	proxyUrl       string
+	proxyPool      *ProxyPool
+	retryPolicy    utils.RetryPolicy
+	breaker        *utils.CircuitBreaker
+}
+
+// WithURL sets the url of amazon, used instead of the url of the country code.
+// This is mostly useful for testing.
+func WithURL(amazonUrl string) ClientOption {
+	return func(o *clientOptions) { o.url = amazonUrl }
+}
+
+// WithTransport sets the transport used to make requests.
+// Requests made using the transport will not be able to impersonate a browser.
+// The transport replaces the proxy handling of the client, so cannot be used with a proxy or proxy pool.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(o *clientOptions) { o.transport = transport }
+}
+
+// WithImpersonation sets the browser that requests are made to look like they are from.
+// If unset, the default impersonation is used.
+func WithImpersonation(impersonation Impersonation) ClientOption {
+	return WithImpersonations(impersonation)
+}
+
+// WithImpersonations sets the browsers that requests are made to look like they are from.
+// Impersonations are rotated when requests are blocked. If using a proxy pool, each proxy
+// is given the next impersonation, which is kept until the proxy is blocked.
+func WithImpersonations(impersonations ...Impersonation) ClientOption {
+	return func(o *clientOptions) { o.impersonations = impersonations }
+}
+
+// WithUserAgent sets the user agent of requests, overriding the user agent of any impersonation.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *clientOptions) { o.userAgent = userAgent }
+}
+
+// WithTimeout sets the time limit of requests.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) { o.timeout = timeout }
+}
+
+// WithProxy sets the url of a proxy to make requests through.
+func WithProxy(proxyUrl string) ClientOption {
+	return func(o *clientOptions) { o.proxyUrl = proxyUrl }
+}
+
+// WithProxyPool sets a pool of proxies to rotate requests between, used instead of any proxy url.
+// Requests that fail or are blocked are retried using the next proxy, with the failing proxy
+// marked unhealthy. Cookies are kept separately for each proxy.
+func WithProxyPool(pool *ProxyPool) ClientOption {
+	return func(o *clientOptions) { o.proxyPool = pool }
+}
+
+// WithRetryPolicy sets the policy for retrying failed requests.
+func WithRetryPolicy(policy utils.RetryPolicy) ClientOption {
+	return func(o *clientOptions) { o.retryPolicy = policy }
+}
+
+// WithCircuitBreaker sets a circuit breaker that stops requests being made
+// after too many consecutive failures. Retried requests count as a single request.
+// If using a proxy pool, failures of proxies are recorded on the health of the proxy instead.
+func WithCircuitBreaker(breaker *utils.CircuitBreaker) ClientOption {
+	return func(o *clientOptions) { o.breaker = breaker }
+}
+
 // Creates a new kindle client.
 // If country code is nil or unset, amazon.com will be used as the url.
-// Will return an error if the country code is invalid.
-func NewClient(countryCode *string) (*Client, error) {
-	client := req.C().ImpersonateFirefox()
+// Will return an error if the country code or an option is invalid.
+func NewClient(countryCode *string, options ...ClientOption) (*Client, error) {
+	var opts clientOptions
+	for _, option := range options {
+		option(&opts)
+	}
 
-	amazonUrl := defaultAmazonURL
+	amazonUrlStruct := defaultAmazonURL
 	if countryCode != nil && *countryCode != "" {
 		countryAmazonUrl, ok := countryAmazonURLs[strings.Trim(*countryCode, "/")]
 		if !ok {
 			return nil, fmt.Errorf("invalid country code: %s", *countryCode)
 		}
-		amazonUrl = countryAmazonUrl
+		amazonUrlStruct = countryAmazonUrl
+	}
+
+	if opts.url != "" {
+		parsedAmazonUrl, err := url.Parse(strings.Trim(opts.url, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid amazon url: %w", err)
+		}
+		amazonUrlStruct = parsedAmazonUrl
+	}
+
+	if len(opts.impersonations) == 0 {
+		opts.impersonations = []Impersonation{DefaultImpersonation}
+	}
+	for _, impersonation := range opts.impersonations {
+		if !Impersonations.Contains(impersonation) {
+			return nil, fmt.Errorf("invalid impersonation: %s", impersonation.Value)
+		}
+	}
+
+	if opts.transport != nil && (opts.proxyUrl != "" || opts.proxyPool != nil) {
+		return nil, errors.New("proxy can not be used with a custom transport")
+	}
+
+	if opts.proxyUrl != "" {
+		_, err := url.Parse(opts.proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
 	}
 
 	return &Client{
-		client:    client,
-		amazonUrl: utils.CloneURL(amazonUrl),
+		sessions:  newSessions(opts),
+		amazonUrl: utils.CloneURL(amazonUrlStruct),
+		breaker:   opts.breaker,
 	}, nil
 }
+
+// newReqClient creates the req client of a session using the options.
+// If proxy is set, it is used instead of the proxy url of the options.
+func (o clientOptions) newReqClient(proxy *Proxy, impersonation Impersonation) (*req.Client, error) {
+	client := req.C()
+
+	switch impersonation {
+	case ImpersonationNone:
+	case ImpersonationChrome:
+		client.ImpersonateChrome()
+	case ImpersonationFirefox:
+		client.ImpersonateFirefox()
+	case ImpersonationSafari:
+		client.ImpersonateSafari()
+	default:
+		return nil, fmt.Errorf("invalid impersonation: %s", impersonation.Value)
+	}
+
+	if o.transport != nil {
+		client.GetTransport().WrapRoundTrip(func(http.RoundTripper) http.RoundTripper { return o.transport })
+	}
+
+	if o.userAgent != "" {
+		client.SetUserAgent(o.userAgent)
+	}
+
+	if o.timeout > 0 {
+		client.SetTimeout(o.timeout)
+	}
+
+	switch {
+	case proxy != nil:
+		client.SetProxy(http.ProxyURL(proxy.URL))
+	case o.proxyUrl != "":
+		proxyUrl, err := url.Parse(o.proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+		client.SetProxy(http.ProxyURL(proxyUrl))
+	}
+
+	if o.retryPolicy.MaxRetries > 0 {
+		// Blocked requests are not retried using the same session, as they are blocked again
+		retryPolicy := o.retryPolicy
+		retryPolicy.Retryable = func(response *http.Response, err error) bool {
+			return (response == nil || !isBlockedStatus(response.StatusCode)) && utils.ShouldRetry(response, err)
+		}
+		client.GetTransport().WrapRoundTrip(func(transport http.RoundTripper) http.RoundTripper {
+			return utils.NewRetryTransport(transport, retryPolicy)
+		})
+	}
+
+	return client, nil
+}
@@ -1,9 +1,16 @@
 package kindle_test
 
 import (
+	"context"
+	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/ahobsonsayers/abs-tract/fixture"
 	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,4 +25,61 @@ func TestNewWithParameters(t *testing.T) {
 	client, err := kindle.NewClient(&countryCode)
 	require.NoError(t, err)
 	require.Equal(t, "https://www.amazon.es", client.URL().String())
+
+	amazonUrl := "http://example.com"
+	client, err = kindle.NewClient(
+		&countryCode,
+		kindle.WithURL(amazonUrl),
+		kindle.WithImpersonation(kindle.ImpersonationFirefox),
+		kindle.WithUserAgent("test"),
+		kindle.WithTimeout(time.Second),
+		kindle.WithProxy("http://proxy:3128"),
+		kindle.WithRetryPolicy(utils.DefaultRetryPolicy),
+	)
+	require.NoError(t, err)
+	require.Equal(t, amazonUrl, client.URL().String())
+}
+
+func TestNewWithTransport(t *testing.T) {
+	server := fixture.NewServer(t, "../testdata/kindle", kindle.DefaultAmazonURL)
+
+	// Transport should be used to make requests
+	var requests atomic.Int32
+	transport := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		return http.DefaultTransport.RoundTrip(request)
+	})
+
+	client, err := kindle.NewClient(nil, kindle.WithURL(server.URL), kindle.WithTransport(transport))
+	require.NoError(t, err)
+
+	books, err := client.Search(context.Background(), TheHobbitTitle, lo.ToPtr(TheHobbitAuthor))
+	require.NoError(t, err)
+	require.NotEmpty(t, books)
+	require.EqualValues(t, 1, requests.Load())
 }
+
+func TestNewProxyWithCustomTransport(t *testing.T) {
+	_, err := kindle.NewClient(nil, kindle.WithTransport(http.DefaultTransport), kindle.WithProxy("http://proxy:3128"))
+	require.Error(t, err)
+
+	proxyPool, err := kindle.NewProxyPool([]string{"http://proxy:3128"})
+	require.NoError(t, err)
+	_, err = kindle.NewClient(nil, kindle.WithTransport(http.DefaultTransport), kindle.WithProxyPool(proxyPool))
+	require.Error(t, err)
+}
+
+func TestNewInvalidCountryCode(t *testing.T) {
+	countryCode := "invalid"
+	_, err := kindle.NewClient(&countryCode)
+	require.Error(t, err)
+}
+
+func TestNewInvalidImpersonation(t *testing.T) {
+	_, err := kindle.NewClient(nil, kindle.WithImpersonation(kindle.Impersonation{Value: "invalid"}))
+	require.Error(t, err)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) { return f(request) }
@@ -0,0 +1,135 @@
+package kindle
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/orsinium-labs/enum"
+)
+
+// DefaultUnhealthyTimeout is the default time an unhealthy proxy is removed from rotation for.
+const DefaultUnhealthyTimeout = 10 * time.Minute
+
+var (
+	ErrNoHealthyProxies = errors.New("no healthy proxies")
+
+	proxySchemes = []string{"http", "https", "socks5"}
+)
+
+// ProxyRotation is when requests are rotated to the next proxy in a pool.
+type ProxyRotation enum.Member[string]
+
+var (
+	proxyRotationEnum = enum.NewBuilder[string, ProxyRotation]()
+
+	// ProxyRotationRequest rotates proxies every request.
+	ProxyRotationRequest = proxyRotationEnum.Add(ProxyRotation{"request"})
+	// ProxyRotationBlock rotates proxies when the current proxy is blocked.
+	ProxyRotationBlock = proxyRotationEnum.Add(ProxyRotation{"block"})
+
+	ProxyRotations = proxyRotationEnum.Enum()
+)
+
+// Proxy is a proxy in a pool.
+type Proxy struct {
+	URL *url.URL
+
+	unhealthyUntil time.Time
+}
+
+func (p *Proxy) healthy(now time.Time) bool { return !now.Before(p.unhealthyUntil) }
+
+// ProxyPool is a pool of proxies that requests are rotated between.
+// Proxies that are blocked are marked unhealthy, removing them from rotation for a time.
+// A pool can be shared between clients.
+type ProxyPool struct {
+	// Rotation is when requests are rotated to the next proxy.
+	Rotation ProxyRotation
+
+	// UnhealthyTimeout is the time an unhealthy proxy is removed from rotation for.
+	UnhealthyTimeout time.Duration
+
+	mutex   sync.Mutex
+	proxies []*Proxy
+	current int
+}
+
+// NewProxyPool creates a new pool of proxies from their urls.
+// Proxies can be http, https or socks5 proxies.
+// Will return an error if there are no proxies, or a proxy url is invalid.
+func NewProxyPool(proxyUrls []string) (*ProxyPool, error) {
+	if len(proxyUrls) == 0 {
+		return nil, errors.New("no proxies")
+	}
+
+	proxies := make([]*Proxy, 0, len(proxyUrls))
+	for _, proxyUrl := range proxyUrls {
+		parsedProxyUrl, err := url.Parse(strings.TrimSpace(proxyUrl))
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+		if !slices.Contains(proxySchemes, parsedProxyUrl.Scheme) || parsedProxyUrl.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url %s: must be a http, https or socks5 url", parsedProxyUrl.Redacted())
+		}
+		proxies = append(proxies, &Proxy{URL: parsedProxyUrl})
+	}
+
+	return &ProxyPool{
+		Rotation:         ProxyRotationRequest,
+		UnhealthyTimeout: DefaultUnhealthyTimeout,
+		proxies:          proxies,
+	}, nil
+}
+
+// Get gets the proxy to make a request through, rotating proxies according to the pool rotation.
+// Unhealthy proxies are skipped. If there are no healthy proxies, ErrNoHealthyProxies is returned.
+func (p *ProxyPool) Get() (*Proxy, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	for idx := range len(p.proxies) {
+		proxyIdx := (p.current + idx) % len(p.proxies)
+		proxy := p.proxies[proxyIdx]
+		if !proxy.healthy(now) {
+			continue
+		}
+
+		p.current = proxyIdx
+		if p.Rotation == ProxyRotationRequest {
+			p.current = (proxyIdx + 1) % len(p.proxies)
+		}
+
+		return proxy, nil
+	}
+
+	return nil, ErrNoHealthyProxies
+}
+
+// MarkUnhealthy removes a proxy from rotation until the unhealthy timeout has passed.
+func (p *ProxyPool) MarkUnhealthy(proxy *Proxy) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	proxy.unhealthyUntil = time.Now().Add(p.UnhealthyTimeout)
+}
+
+// Healthy returns the number of healthy proxies in the pool.
+func (p *ProxyPool) Healthy() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	healthy := 0
+	for _, proxy := range p.proxies {
+		if proxy.healthy(now) {
+			healthy++
+		}
+	}
+
+	return healthy
+}
@@ -0,0 +1,93 @@
+package kindle
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+
+	"github.com/imroc/req/v3"
+)
+
+// session is an identity that requests are made with. Each session has
+// its own proxy (if using a proxy pool), browser impersonation and cookies.
+type session struct {
+	client *req.Client
+	proxy  *Proxy
+}
+
+// sessions creates and stores the sessions of a client.
+// Sessions are replaced when they are blocked, rotating their impersonation.
+type sessions struct {
+	options clientOptions
+
+	mutex             sync.Mutex
+	clients           map[*Proxy]*req.Client
+	nextImpersonation int
+}
+
+func newSessions(options clientOptions) *sessions {
+	return &sessions{
+		options: options,
+		clients: make(map[*Proxy]*req.Client),
+	}
+}
+
+// get gets the session to make a request with.
+// If using a proxy pool, the session of the next proxy is returned.
+func (s *sessions) get() (session, error) {
+	var proxy *Proxy
+	if s.options.proxyPool != nil {
+		var err error
+		proxy, err = s.options.proxyPool.Get()
+		if err != nil {
+			return session{}, err
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	client, ok := s.clients[proxy]
+	if !ok {
+		impersonations := s.options.impersonations
+		impersonation := impersonations[s.nextImpersonation%len(impersonations)]
+		s.nextImpersonation++
+
+		var err error
+		client, err = s.options.newReqClient(proxy, impersonation)
+		if err != nil {
+			return session{}, err
+		}
+		s.clients[proxy] = client
+	}
+
+	return session{client: client, proxy: proxy}, nil
+}
+
+// blocked discards a session that has been blocked, so its cookies are cleared and the next
+// session uses a different impersonation. If the session uses a proxy, the proxy is marked unhealthy.
+func (s *sessions) blocked(blockedSession session) {
+	s.mutex.Lock()
+	if s.clients[blockedSession.proxy] == blockedSession.client {
+		delete(s.clients, blockedSession.proxy)
+	}
+	s.mutex.Unlock()
+
+	if blockedSession.proxy != nil {
+		s.options.proxyPool.MarkUnhealthy(blockedSession.proxy)
+	}
+}
+
+// isBlocked returns whether a response shows that amazon has blocked requests,
+// either with an error or by asking for a captcha to be solved.
+func isBlocked(response *req.Response) bool {
+	if isBlockedStatus(response.StatusCode) {
+		return true
+	}
+	return bytes.Contains(response.Bytes(), []byte("/errors/validateCaptcha"))
+}
+
+// isBlockedStatus returns whether a response status code shows that amazon has blocked requests.
+func isBlockedStatus(statusCode int) bool {
+	return statusCode == http.StatusForbidden || statusCode == http.StatusServiceUnavailable
+}
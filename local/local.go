@@ -0,0 +1,179 @@
+package local
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/samber/lo"
+)
+
+// Confidence books must match a search with to be found
+const minSearchConfidence = 0.6
+
+// Book is a book in a local library, with metadata read from the tags of its audio files
+// and its OPF file (e.g. metadata.opf). Metadata of the OPF file is preferred.
+type Book struct {
+	// Dir is the directory of the book
+	Dir string
+	// Files are the paths of the audio files of the book relative to its directory, in order
+	Files         []string
+	Title         string
+	Subtitle      string
+	Authors       []string
+	Narrators     []string
+	Series        []Series
+	Genres        []string
+	PublishedYear string
+	Publisher     string
+	Description   string
+	// Language is a language code (e.g. "en") or english name (e.g. "English")
+	Language string
+	ISBN     string
+	ASIN     string
+	// Duration is the total duration of the audio files of the book
+	Duration time.Duration
+}
+
+type Series struct {
+	Name     string
+	Sequence string
+}
+
+// Match is a book found by searching a library.
+type Match struct {
+	Book Book
+	// Confidence is how confident it is that the book is the book searched for, between 0 and 1
+	Confidence float64
+}
+
+// LibraryOption configures a library created using NewLibrary.
+type LibraryOption func(*Library)
+
+// WithScanInterval sets how often the library is scanned for changes. Libraries are rescanned in the background
+// when they are searched, if they have not been scanned for the interval.
+// 0 to only scan the library the first time it is searched.
+func WithScanInterval(interval time.Duration) LibraryOption {
+	return func(l *Library) { l.scanInterval = interval }
+}
+
+// Library is an index of the books in local directories.
+type Library struct {
+	dirs         []string
+	scanInterval time.Duration
+
+	mutex     sync.Mutex
+	books     []Book
+	scannedAt time.Time
+	scanning  bool
+}
+
+// NewLibrary creates a library of the books in directories. Directories are not scanned until the library is searched.
+// Will return an error if a directory does not exist.
+func NewLibrary(dirs []string, options ...LibraryOption) (*Library, error) {
+	if len(dirs) == 0 {
+		return nil, errors.New("library must have a directory")
+	}
+
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid library directory: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("invalid library directory: %s is not a directory", dir)
+		}
+	}
+
+	library := &Library{dirs: dirs}
+	for _, option := range options {
+		option(library)
+	}
+
+	return library, nil
+}
+
+// Books gets the books in the library. The library is scanned the first time, then if it has not been scanned
+// for the scan interval it is rescanned in the background, and its books are swapped in once the rescan is done.
+func (l *Library) Books(ctx context.Context) ([]Book, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// Without books to return, searches wait for the first scan
+	if l.scannedAt.IsZero() {
+		books, err := scanLibrary(ctx, l.dirs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan library: %w", err)
+		}
+		l.books = books
+		l.scannedAt = time.Now()
+	} else if l.scanInterval > 0 && !l.scanning && time.Since(l.scannedAt) >= l.scanInterval {
+		l.scanning = true
+		go l.rescan()
+	}
+
+	return l.books, nil
+}
+
+// rescan scans the library, swapping in its books. Failed scans are retried the next time the library is searched.
+func (l *Library) rescan() {
+	books, err := scanLibrary(context.Background(), l.dirs)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.scanning = false
+	if err != nil {
+		log.Printf("Failed to rescan local library: %s", err)
+		return
+	}
+	l.books = books
+	l.scannedAt = time.Now()
+}
+
+// Search searches for books in the library by a title and optionally an author, using fuzzy matching.
+// Folder style queries (e.g. "Author - Series 01 - Title") are parsed, and an isbn or asin matches a book exactly.
+// Books are returned from the most to the least similar.
+func (l *Library) Search(ctx context.Context, title string, author *string) ([]Match, error) {
+	books, err := l.Books(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	searchQuery := query.Parse(title, lo.FromPtr(author))
+	identifier := strings.TrimSpace(title)
+
+	var matches []Match
+	for _, book := range books {
+		confidence := searchQuery.Confidence(bookMatch(book))
+		if identifier != "" && (strings.EqualFold(identifier, book.ISBN) || strings.EqualFold(identifier, book.ASIN)) {
+			confidence = 1
+		}
+		if confidence >= minSearchConfidence {
+			matches = append(matches, Match{Book: book, Confidence: confidence})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b Match) int { return cmp.Compare(b.Confidence, a.Confidence) })
+
+	return matches, nil
+}
+
+// bookMatch gets a book as a match, to compute the confidence it is the book searched for by a query.
+func bookMatch(book Book) query.Match {
+	return query.Match{
+		Title:    book.Title,
+		Subtitle: book.Subtitle,
+		Author:   strings.Join(book.Authors, ", "),
+		Series:   lo.Map(book.Series, func(series Series, _ int) string { return series.Name }),
+		Year:     book.PublishedYear,
+	}
+}
@@ -0,0 +1,172 @@
+package local_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/local"
+	"github.com/ahobsonsayers/abs-tract/opf"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryBooks(t *testing.T) {
+	library := newTestLibrary(t)
+
+	books, err := library.Books(context.Background())
+	require.NoError(t, err)
+	require.Len(t, books, 4)
+
+	// Books are ordered by directory
+	finalEmpire := books[0]
+	require.Equal(t, "The Final Empire", finalEmpire.Title)
+	require.Equal(t, []string{"Brandon Sanderson"}, finalEmpire.Authors)
+	require.Equal(t, []string{"Michael Kramer"}, finalEmpire.Narrators)
+	require.Equal(t, []local.Series{{Name: "Mistborn", Sequence: "1"}}, finalEmpire.Series)
+	require.Equal(t, []string{"Chapter 2.mp3", "Chapter 10.mp3"}, finalEmpire.Files)
+	require.Equal(t, 2*2606250*time.Microsecond, finalEmpire.Duration)
+
+	// Metadata is parsed from the directory of books without tags
+	philosophersStone := books[1]
+	require.Equal(t, "Philosopher's Stone", philosophersStone.Title)
+	require.Equal(t, []string{"J.K. Rowling"}, philosophersStone.Authors)
+	require.Equal(t, []local.Series{{Name: "Harry Potter"}}, philosophersStone.Series)
+	require.Equal(t, "1997", philosophersStone.PublishedYear)
+
+	// Files of disc directories belong to the book of their parent directory
+	discs := books[2]
+	require.Equal(t, "Lord of the Flies", discs.Title)
+	require.Equal(t, []string{"William Golding"}, discs.Authors)
+	require.Equal(t, []string{filepath.Join("CD1", "01.mp3"), filepath.Join("CD2", "01.mp3")}, discs.Files)
+
+	// Metadata of OPF files is preferred to tags
+	hobbit := books[3]
+	require.Equal(t, "The Hobbit, or There and Back Again", hobbit.Title)
+	require.Equal(t, []string{"J.R.R. Tolkien"}, hobbit.Authors)
+	require.Equal(t, []string{"Andy Serkis"}, hobbit.Narrators)
+	require.Equal(t, "1937", hobbit.PublishedYear)
+	require.Equal(t, "en", hobbit.Language)
+	require.Equal(t, "9780618260300", hobbit.ISBN)
+	require.Equal(t, time.Hour, hobbit.Duration)
+}
+
+func TestLibrarySearch(t *testing.T) {
+	library := newTestLibrary(t)
+
+	matches, err := library.Search(context.Background(), "the final empire", lo.ToPtr("sanderson"))
+	require.NoError(t, err)
+	require.NotEmpty(t, matches)
+	require.Equal(t, "The Final Empire", matches[0].Book.Title)
+
+	matches, err = library.Search(context.Background(), "J.R.R. Tolkien - The Hobbit", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, matches)
+	require.Equal(t, "The Hobbit, or There and Back Again", matches[0].Book.Title)
+
+	matches, err = library.Search(context.Background(), "9780618260300", nil)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, "The Hobbit, or There and Back Again", matches[0].Book.Title)
+	require.InDelta(t, 1, matches[0].Confidence, 0)
+
+	matches, err = library.Search(context.Background(), "Dune", lo.ToPtr("Frank Herbert"))
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
+
+func TestLibraryRescan(t *testing.T) {
+	dir := t.TempDir()
+	library, err := local.NewLibrary([]string{dir}, local.WithScanInterval(time.Nanosecond))
+	require.NoError(t, err)
+
+	books, err := library.Books(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, books)
+
+	writeFile(t, dir, filepath.Join("Frank Herbert - Dune", "Dune.mp3"), mp3File(4, 0, nil))
+
+	// Books are swapped in once the background rescan is done
+	require.Eventually(t, func() bool {
+		books, err = library.Books(context.Background())
+		require.NoError(t, err)
+		return len(books) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "Dune", books[0].Title)
+}
+
+func TestNewLibraryInvalid(t *testing.T) {
+	_, err := local.NewLibrary(nil)
+	require.Error(t, err)
+
+	_, err = local.NewLibrary([]string{filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err)
+
+	file := writeFile(t, t.TempDir(), "book.mp3", nil)
+	_, err = local.NewLibrary([]string{file})
+	require.Error(t, err)
+}
+
+// newTestLibrary creates a library in a temporary directory containing books with tags, an OPF file and disc
+// directories, a book named by its directory, and hidden files.
+func newTestLibrary(t *testing.T) *local.Library {
+	dir := t.TempDir()
+
+	// Book with a file per chapter
+	finalEmpireDir := filepath.Join("Brandon Sanderson", "Mistborn 01")
+	for _, chapter := range []string{"Chapter 10", "Chapter 2"} {
+		writeFile(t, dir, filepath.Join(finalEmpireDir, chapter+".mp3"), mp3File(4, 0, []id3Frame{
+			{"TIT2", chapter},
+			{"TALB", "The Final Empire"},
+			{"TPE1", "Brandon Sanderson"},
+			{"TCOM", "Michael Kramer"},
+			{"TXXX", "SERIES\x00Mistborn"},
+			{"TXXX", "SERIES-PART\x001"},
+		}))
+	}
+
+	// Book with disc directories
+	for _, disc := range []string{"CD1", "CD2"} {
+		writeFile(t, dir, filepath.Join("Lord of the Flies", disc, "01.mp3"), mp3File(3, 0, []id3Frame{
+			{"TALB", "Lord of the Flies"},
+			{"TPE1", "William Golding"},
+		}))
+	}
+
+	// Book with an OPF file
+	hobbitDir := filepath.Join("Tolkien", "The Hobbit")
+	writeFile(t, dir, filepath.Join(hobbitDir, "The Hobbit.m4b"), mp4File([]mp4Item{
+		{"\xa9nam", "The Hobbit"},
+		{"\xa9ART", "Tolkien"},
+		{"----:NARRATOR", "Andy Serkis"},
+	}, 3600))
+	opfXML, err := opf.Marshal(opf.Book{
+		Title:         "The Hobbit, or There and Back Again",
+		Creators:      []opf.Creator{{Name: "J.R.R. Tolkien", Role: opf.RoleAuthor}},
+		PublishedDate: "1937-09-21",
+		Language:      "en",
+		ISBN:          "9780618260300",
+	}, opf.Version3)
+	require.NoError(t, err)
+	writeFile(t, dir, filepath.Join(hobbitDir, "metadata.opf"), opfXML)
+
+	// Book without tags
+	philosophersStoneDir := "J.K. Rowling - Harry Potter 01 - Philosopher's Stone (1997)"
+	writeFile(t, dir, filepath.Join(philosophersStoneDir, "book.mp3"), mp3File(4, 0, nil))
+
+	// Hidden files and directories
+	writeFile(t, dir, filepath.Join(".trash", "Dune", "Dune.mp3"), nil)
+	writeFile(t, dir, filepath.Join(hobbitDir, ".The Hobbit.mp3"), nil)
+
+	// Files that are not books
+	err = os.MkdirAll(filepath.Join(dir, "Empty"), 0o755)
+	require.NoError(t, err)
+	writeFile(t, dir, filepath.Join("Covers", "cover.jpg"), nil)
+
+	library, err := local.NewLibrary([]string{dir})
+	require.NoError(t, err)
+
+	return library
+}
@@ -0,0 +1,144 @@
+package local
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// Maximum number of bytes after the ID3 tag searched for the first mpeg frame.
+const maxMPEGFrameSearch = 64 << 10
+
+// Layer III bitrates in kbps by bitrate index, for mpeg 1 and mpeg 2 (and 2.5).
+var (
+	mpeg1Bitrates = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mpeg2Bitrates = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+)
+
+// Sample rates by mpeg version bits and sample rate index.
+var mpegSampleRates = map[byte][3]int{
+	0b11: {44100, 48000, 32000}, // MPEG 1
+	0b10: {22050, 24000, 16000}, // MPEG 2
+	0b00: {11025, 12000, 8000},  // MPEG 2.5
+}
+
+// mp3Duration computes the duration of an mp3 file from the mpeg frames after its ID3v2 tag (if any).
+// See: https://id3.org/id3v2.4.0-structure
+func mp3Duration(r io.ReadSeeker, fileSize int64) (time.Duration, error) {
+	_, err := r.Seek(0, io.SeekStart)
+	if err != nil {
+		return 0, err
+	}
+
+	header := make([]byte, 10)
+	_, err = io.ReadFull(r, header)
+	if err != nil {
+		return 0, err
+	}
+
+	audioStart := int64(0)
+	if string(header[:3]) == "ID3" {
+		audioStart = 10 + int64(syncsafe(header[6:10]))
+		if header[5]&0x10 != 0 {
+			audioStart += 10 // Footer
+		}
+	}
+
+	return mpegDuration(r, audioStart, fileSize)
+}
+
+// mpegDuration computes the duration of mpeg audio from its first frame. The number of frames is read
+// from the Xing (or Info) header of variable bitrate files, otherwise the bitrate is assumed to be constant.
+// If no frame is found, the duration is 0.
+func mpegDuration(r io.ReadSeeker, audioStart, fileSize int64) (time.Duration, error) {
+	_, err := r.Seek(audioStart, io.SeekStart)
+	if err != nil {
+		return 0, err
+	}
+
+	data := make([]byte, min(maxMPEGFrameSearch, max(fileSize-audioStart, 0)))
+	n, err := io.ReadFull(r, data)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, err
+	}
+	data = data[:n]
+
+	for idx := 0; idx+4 <= len(data); idx++ {
+		frame, ok := parseMPEGFrameHeader(data[idx : idx+4])
+		if !ok {
+			continue
+		}
+
+		// The Xing header is after the side information of the first frame
+		xingOffset := idx + 4 + frame.sideInfoSize
+		if xingOffset+12 <= len(data) {
+			xingId := string(data[xingOffset : xingOffset+4])
+			xingFlags := binary.BigEndian.Uint32(data[xingOffset+4:])
+			if (xingId == "Xing" || xingId == "Info") && xingFlags&0x1 != 0 {
+				frames := int64(binary.BigEndian.Uint32(data[xingOffset+8:]))
+				seconds := float64(frames*int64(frame.samples)) / float64(frame.sampleRate)
+				return time.Duration(seconds * float64(time.Second)), nil
+			}
+		}
+
+		audioSize := fileSize - audioStart - int64(idx)
+		seconds := float64(audioSize*8) / float64(frame.bitrate*1000)
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	return 0, nil
+}
+
+type mpegFrame struct {
+	bitrate      int // kbps
+	sampleRate   int
+	samples      int // Samples per frame
+	sideInfoSize int
+}
+
+// parseMPEGFrameHeader parses the header of an mpeg layer III frame.
+// Returns false if the bytes are not a valid header.
+func parseMPEGFrameHeader(header []byte) (mpegFrame, bool) {
+	if header[0] != 0xFF || header[1]&0xE0 != 0xE0 {
+		return mpegFrame{}, false
+	}
+
+	version := (header[1] >> 3) & 0b11
+	layer := (header[1] >> 1) & 0b11
+	bitrateIndex := header[2] >> 4
+	sampleRateIndex := (header[2] >> 2) & 0b11
+	mono := header[3]>>6 == 0b11
+
+	sampleRates, ok := mpegSampleRates[version]
+	if !ok || layer != 0b01 || sampleRateIndex == 0b11 {
+		return mpegFrame{}, false
+	}
+
+	frame := mpegFrame{sampleRate: sampleRates[sampleRateIndex]}
+	if version == 0b11 {
+		frame.bitrate = mpeg1Bitrates[bitrateIndex]
+		frame.samples = 1152
+		frame.sideInfoSize = lo.Ternary(mono, 17, 32)
+	} else {
+		frame.bitrate = mpeg2Bitrates[bitrateIndex]
+		frame.samples = 576
+		frame.sideInfoSize = lo.Ternary(mono, 9, 17)
+	}
+	if frame.bitrate == 0 {
+		return mpegFrame{}, false
+	}
+
+	return frame, true
+}
+
+// syncsafe decodes a syncsafe integer, whose bytes only use their lower 7 bits.
+func syncsafe(data []byte) int {
+	var value int
+	for _, b := range data {
+		value = value<<7 | int(b&0x7F)
+	}
+	return value
+}
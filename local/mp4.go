@@ -0,0 +1,139 @@
+package local
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Maximum size of the moov atom that is read. It contains the tags, chapters and sample tables of the file.
+const maxMoovSize = 64 << 20
+
+// atom is an atom of an mp4 file, which can contain other atoms.
+type atom struct {
+	kind string
+	data []byte
+}
+
+// mp4Duration reads the duration of an mp4 file (e.g. an m4b audiobook) from its movie header.
+// See: https://developer.apple.com/documentation/quicktime-file-format
+func mp4Duration(r io.ReadSeeker, fileSize int64) (time.Duration, error) {
+	moov, err := findMoovAtom(r, fileSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, child := range parseAtoms(moov) {
+		if child.kind == "mvhd" {
+			return mvhdDuration(child.data), nil
+		}
+	}
+
+	return 0, nil
+}
+
+// findMoovAtom finds the top level moov atom of an mp4 file, returning its data.
+func findMoovAtom(r io.ReadSeeker, fileSize int64) ([]byte, error) {
+	position := int64(0)
+	header := make([]byte, 8)
+	for position+8 <= fileSize {
+		_, err := r.Seek(position, io.SeekStart)
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.ReadFull(r, header)
+		if err != nil {
+			return nil, err
+		}
+
+		headerSize := int64(8)
+		atomSize := int64(binary.BigEndian.Uint32(header))
+		switch atomSize {
+		case 0: // Atom extends to the end of the file
+			atomSize = fileSize - position
+		case 1: // Atom has a 64 bit size
+			extendedSize := make([]byte, 8)
+			_, err = io.ReadFull(r, extendedSize)
+			if err != nil {
+				return nil, err
+			}
+			atomSize = int64(binary.BigEndian.Uint64(extendedSize))
+			headerSize = 16
+		}
+		if atomSize < headerSize {
+			return nil, errors.New("invalid mp4 atom size")
+		}
+
+		if string(header[4:8]) == "moov" {
+			if atomSize > maxMoovSize {
+				return nil, fmt.Errorf("mp4 moov atom is too large: %d bytes", atomSize)
+			}
+			moov := make([]byte, atomSize-headerSize)
+			_, err = io.ReadFull(r, moov)
+			if err != nil {
+				return nil, err
+			}
+			return moov, nil
+		}
+
+		position += atomSize
+	}
+
+	return nil, errors.New("mp4 file has no moov atom")
+}
+
+// parseAtoms parses the atoms contained in the data of an atom. Invalid atoms are ignored.
+func parseAtoms(data []byte) []atom {
+	var atoms []atom
+	for len(data) >= 8 {
+		headerSize := uint64(8)
+		atomSize := uint64(binary.BigEndian.Uint32(data))
+		switch atomSize {
+		case 0:
+			atomSize = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return atoms
+			}
+			atomSize = binary.BigEndian.Uint64(data[8:])
+			headerSize = 16
+		}
+		if atomSize < headerSize || atomSize > uint64(len(data)) {
+			return atoms
+		}
+
+		atoms = append(atoms, atom{kind: string(data[4:8]), data: data[headerSize:atomSize]})
+		data = data[atomSize:]
+	}
+	return atoms
+}
+
+// mvhdDuration gets the duration of a movie from its header atom.
+func mvhdDuration(data []byte) time.Duration {
+	if len(data) < 1 {
+		return 0
+	}
+
+	var timescale, duration uint64
+	switch data[0] {
+	case 0:
+		if len(data) < 20 {
+			return 0
+		}
+		timescale = uint64(binary.BigEndian.Uint32(data[12:]))
+		duration = uint64(binary.BigEndian.Uint32(data[16:]))
+	case 1:
+		if len(data) < 32 {
+			return 0
+		}
+		timescale = uint64(binary.BigEndian.Uint32(data[20:]))
+		duration = binary.BigEndian.Uint64(data[24:])
+	}
+	if timescale == 0 {
+		return 0
+	}
+
+	return time.Duration(float64(duration) / float64(timescale) * float64(time.Second))
+}
@@ -0,0 +1,255 @@
+package local
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ahobsonsayers/abs-tract/opf"
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/samber/lo"
+)
+
+// Extensions of the audio files of books
+var audioExtensions = []string{".m4b", ".m4a", ".mp4", ".mp3"}
+
+// Regex to match the name of a directory containing a disc of a book. e.g. "CD1" or "Disc 02".
+// The audio files of disc directories belong to the book of the parent directory.
+var discDirRegex = regexp.MustCompile(`(?i)^(cd|disc|disk)[\s._-]*\d+$`)
+
+// bookFiles are the files of a book found when scanning a library.
+type bookFiles struct {
+	audioFiles []string
+	opfFile    string
+}
+
+// scanLibrary scans directories for books. Each directory containing audio files or an OPF file is a book.
+// Hidden files and directories are ignored. Books that can not be read are logged and skipped.
+func scanLibrary(ctx context.Context, dirs []string) ([]Book, error) {
+	filesByDir := make(map[string]*bookFiles)
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if strings.HasPrefix(entry.Name(), ".") && path != dir {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if entry.IsDir() {
+				return nil
+			}
+
+			bookDir := filepath.Dir(path)
+			if discDirRegex.MatchString(filepath.Base(bookDir)) && bookDir != dir {
+				bookDir = filepath.Dir(bookDir)
+			}
+
+			extension := strings.ToLower(filepath.Ext(path))
+			switch {
+			case slices.Contains(audioExtensions, extension):
+				files := lo.ValueOr(filesByDir, bookDir, &bookFiles{})
+				files.audioFiles = append(files.audioFiles, path)
+				filesByDir[bookDir] = files
+			case extension == ".opf":
+				files := lo.ValueOr(filesByDir, bookDir, &bookFiles{})
+				// Prefer metadata.opf, as written by calibre and audiobookshelf
+				if files.opfFile == "" || strings.EqualFold(entry.Name(), "metadata.opf") {
+					files.opfFile = path
+				}
+				filesByDir[bookDir] = files
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bookDirs := lo.Keys(filesByDir)
+	slices.Sort(bookDirs)
+
+	books := make([]Book, 0, len(bookDirs))
+	for _, bookDir := range bookDirs {
+		book, err := readBook(bookDir, *filesByDir[bookDir])
+		if err != nil {
+			log.Printf("Failed to read local book %s: %s", bookDir, err)
+			continue
+		}
+		books = append(books, book)
+	}
+
+	return books, nil
+}
+
+// readBook reads a book from the tags of its audio files and its OPF file. Metadata of the OPF file is preferred,
+// and the title, author, series and year are parsed from the name of the directory of the book if unknown.
+func readBook(dir string, files bookFiles) (Book, error) {
+	slices.SortFunc(files.audioFiles, compareNatural)
+
+	book := Book{Dir: dir}
+	var tags Tags
+	for _, audioFile := range files.audioFiles {
+		relativePath, err := filepath.Rel(dir, audioFile)
+		if err != nil {
+			return Book{}, err
+		}
+		book.Files = append(book.Files, relativePath)
+
+		fileTags, err := ReadTags(audioFile)
+		if err != nil {
+			log.Printf("Failed to read tags of %s: %s", audioFile, err)
+			continue
+		}
+		mergeTags(&tags, fileTags)
+		book.Duration += fileTags.Duration
+	}
+	setTagMetadata(&book, tags)
+
+	if files.opfFile != "" {
+		opfXML, err := os.ReadFile(files.opfFile)
+		if err != nil {
+			return Book{}, err
+		}
+		opfBook, err := opf.Unmarshal(opfXML)
+		if err != nil {
+			return Book{}, err
+		}
+		setOPFMetadata(&book, opfBook)
+	}
+
+	// Directories are often named after their book e.g. "Author - Series 01 - Title (Year)"
+	dirQuery := query.Parse(filepath.Base(dir), "")
+	setIfEmpty(&book.Title, dirQuery.Title)
+	setIfEmpty(&book.PublishedYear, dirQuery.Year)
+	if len(book.Authors) == 0 && dirQuery.Author != "" {
+		book.Authors = []string{dirQuery.Author}
+	}
+	if len(book.Series) == 0 && dirQuery.Series != "" {
+		book.Series = []Series{{Name: dirQuery.Series}}
+	}
+
+	return book, nil
+}
+
+// mergeTags sets the tags that are not set from the tags of another file of a book. Durations are not merged.
+func mergeTags(tags *Tags, fileTags Tags) {
+	fields := []struct{ tag, fileTag *string }{
+		{&tags.Title, &fileTags.Title}, {&tags.Subtitle, &fileTags.Subtitle}, {&tags.Artist, &fileTags.Artist},
+		{&tags.AlbumArtist, &fileTags.AlbumArtist}, {&tags.Album, &fileTags.Album},
+		{&tags.Composer, &fileTags.Composer}, {&tags.Narrator, &fileTags.Narrator}, {&tags.Series, &fileTags.Series},
+		{&tags.SeriesPart, &fileTags.SeriesPart}, {&tags.Genre, &fileTags.Genre}, {&tags.Year, &fileTags.Year},
+		{&tags.Publisher, &fileTags.Publisher}, {&tags.Description, &fileTags.Description},
+		{&tags.ISBN, &fileTags.ISBN}, {&tags.ASIN, &fileTags.ASIN},
+	}
+	for _, field := range fields {
+		setIfEmpty(field.tag, *field.fileTag)
+	}
+}
+
+// setTagMetadata sets the metadata of a book from the tags of its audio files. The album is the title of books
+// with multiple files (as files are often chapters), and as with audiobookshelf, the composer is the narrator.
+func setTagMetadata(book *Book, tags Tags) {
+	book.Title = lo.CoalesceOrEmpty(tags.Title, tags.Album)
+	if len(book.Files) > 1 {
+		book.Title = lo.CoalesceOrEmpty(tags.Album, tags.Title)
+	}
+	book.Subtitle = tags.Subtitle
+
+	if author := lo.CoalesceOrEmpty(tags.AlbumArtist, tags.Artist); author != "" {
+		book.Authors = []string{author}
+	}
+	if narrator := lo.CoalesceOrEmpty(tags.Narrator, tags.Composer); narrator != "" {
+		book.Narrators = []string{narrator}
+	}
+	if tags.Series != "" {
+		book.Series = []Series{{Name: tags.Series, Sequence: tags.SeriesPart}}
+	}
+	if tags.Genre != "" {
+		book.Genres = []string{tags.Genre}
+	}
+
+	book.PublishedYear = tags.Year
+	book.Publisher = tags.Publisher
+	book.Description = tags.Description
+	book.ISBN = tags.ISBN
+	book.ASIN = tags.ASIN
+}
+
+// setOPFMetadata sets the metadata of a book from its OPF file, replacing metadata from tags.
+func setOPFMetadata(book *Book, opfBook opf.Book) {
+	book.Title = lo.CoalesceOrEmpty(opfBook.Title, book.Title)
+	book.Subtitle = lo.CoalesceOrEmpty(opfBook.Subtitle, book.Subtitle)
+
+	authors := creatorNames(opfBook.Creators, opf.RoleAuthor)
+	if len(authors) != 0 {
+		book.Authors = authors
+	}
+	narrators := creatorNames(opfBook.Creators, opf.RoleNarrator)
+	if len(narrators) != 0 {
+		book.Narrators = narrators
+	}
+
+	if len(opfBook.Series) != 0 {
+		book.Series = lo.Map(opfBook.Series, func(series opf.Series, _ int) Series {
+			return Series{Name: series.Name, Sequence: series.Index}
+		})
+	}
+	if len(opfBook.Subjects) != 0 {
+		book.Genres = opfBook.Subjects
+	}
+
+	book.PublishedYear = lo.CoalesceOrEmpty(tagYear(opfBook.PublishedDate), book.PublishedYear)
+	book.Publisher = lo.CoalesceOrEmpty(opfBook.Publisher, book.Publisher)
+	book.Description = lo.CoalesceOrEmpty(opfBook.Description, book.Description)
+	book.Language = lo.CoalesceOrEmpty(opfBook.Language, book.Language)
+	book.ISBN = lo.CoalesceOrEmpty(opfBook.ISBN, book.ISBN)
+	book.ASIN = lo.CoalesceOrEmpty(opfBook.ASIN, book.ASIN)
+}
+
+// creatorNames gets the names of the creators of a book with a role.
+func creatorNames(creators []opf.Creator, role opf.Role) []string {
+	return lo.FilterMap(creators, func(creator opf.Creator, _ int) (string, bool) {
+		return creator.Name, creator.Role == role
+	})
+}
+
+// compareNatural compares paths so numbers are ordered by their value. e.g. "Chapter 2" is before "Chapter 10".
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		aDigits := len(a) - len(strings.TrimLeft(a, "0123456789"))
+		bDigits := len(b) - len(strings.TrimLeft(b, "0123456789"))
+
+		if aDigits > 0 && bDigits > 0 {
+			aNumber := strings.TrimLeft(a[:aDigits], "0")
+			bNumber := strings.TrimLeft(b[:bDigits], "0")
+			if len(aNumber) != len(bNumber) {
+				return len(aNumber) - len(bNumber)
+			}
+			if aNumber != bNumber {
+				return strings.Compare(aNumber, bNumber)
+			}
+			a, b = a[aDigits:], b[bDigits:]
+			continue
+		}
+
+		if a[0] != b[0] {
+			return int(a[0]) - int(b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+
+	return len(a) - len(b)
+}
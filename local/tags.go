@@ -0,0 +1,185 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dhowden/tag"
+)
+
+// Tags are the metadata tags embedded in an audio file.
+type Tags struct {
+	Title       string
+	Subtitle    string
+	Artist      string
+	AlbumArtist string
+	Album       string
+	Composer    string
+	Narrator    string
+	Series      string
+	SeriesPart  string
+	Genre       string
+	Year        string
+	Publisher   string
+	Description string
+	ISBN        string
+	ASIN        string
+	Duration    time.Duration
+}
+
+// ReadTags reads the tags of an mp3 (ID3v2) or mp4 (e.g. m4b or m4a) audio file, and its duration.
+// If an mp3 file has no length tag, its duration is computed from its mpeg frames.
+func ReadTags(path string) (Tags, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Tags{}, fmt.Errorf("failed to open audio file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return Tags{}, fmt.Errorf("failed to stat audio file: %w", err)
+	}
+
+	extension := strings.ToLower(filepath.Ext(path))
+	if extension != ".mp3" && extension != ".m4b" && extension != ".m4a" && extension != ".mp4" {
+		return Tags{}, fmt.Errorf("unsupported audio file: %s", filepath.Base(path))
+	}
+
+	// Files without tags still have a duration
+	var tags Tags
+	metadata, err := tag.ReadFrom(file)
+	switch {
+	case err == nil:
+		tags = metadataTags(metadata)
+	case !errors.Is(err, tag.ErrNoTagsFound):
+		return Tags{}, fmt.Errorf("failed to read tags of %s: %w", filepath.Base(path), err)
+	}
+
+	if tags.Duration == 0 {
+		if extension == ".mp3" {
+			tags.Duration, err = mp3Duration(file, info.Size())
+		} else {
+			tags.Duration, err = mp4Duration(file, info.Size())
+		}
+		if err != nil {
+			return Tags{}, fmt.Errorf("failed to read duration of %s: %w", filepath.Base(path), err)
+		}
+	}
+
+	return tags, nil
+}
+
+// metadataTags gets the tags of the metadata of an audio file. Tags that are not standard are read from
+// the raw frames (ID3) or freeform atoms (mp4) of the metadata.
+func metadataTags(metadata tag.Metadata) Tags {
+	tags := Tags{
+		Title:       strings.TrimSpace(metadata.Title()),
+		Artist:      strings.TrimSpace(metadata.Artist()),
+		AlbumArtist: strings.TrimSpace(metadata.AlbumArtist()),
+		Album:       strings.TrimSpace(metadata.Album()),
+		Composer:    strings.TrimSpace(metadata.Composer()),
+		Genre:       strings.TrimSpace(metadata.Genre()),
+		Description: strings.TrimSpace(metadata.Comment()),
+	}
+	if metadata.Year() > 0 {
+		tags.Year = strconv.Itoa(metadata.Year())
+	}
+
+	// Raw tags are sorted, so the first of repeated frames (e.g. "TXXX", "TXXX_0") is kept
+	raw := metadata.Raw()
+	for _, name := range slices.Sorted(maps.Keys(raw)) {
+		switch value := raw[name].(type) {
+		case *tag.Comm:
+			if frameId(name) == "TXXX" || frameId(name) == "TXX" {
+				tags.setCustom(value.Description, value.Text)
+			}
+		case string:
+			// Values of mp4 freeform atoms keep the null locale of their data
+			tags.setRaw(name, strings.TrimLeft(value, "\x00"))
+		}
+	}
+
+	return tags
+}
+
+// setRaw sets a tag from a raw ID3 text frame by its id, or from an mp4 freeform atom by its name.
+func (t *Tags) setRaw(name, value string) {
+	switch frameId(name) {
+	case "TIT3", "TT3":
+		setIfEmpty(&t.Subtitle, value)
+	case "TPUB", "TPB":
+		setIfEmpty(&t.Publisher, value)
+	case "MVNM":
+		setIfEmpty(&t.Series, value)
+	case "MVIN":
+		// Movement numbers may include the number of movements e.g. "1/3"
+		number, _, _ := strings.Cut(value, "/")
+		setIfEmpty(&t.SeriesPart, number)
+	case "TLEN", "TLE":
+		milliseconds, err := strconv.Atoi(strings.TrimSpace(value))
+		if err == nil && milliseconds > 0 && t.Duration == 0 {
+			t.Duration = time.Duration(milliseconds) * time.Millisecond
+		}
+	default:
+		t.setCustom(name, value)
+	}
+}
+
+// frameId gets the id of a raw ID3 frame, whose name has a number appended if the frame is repeated e.g. "TXXX_0".
+func frameId(name string) string {
+	id, _, _ := strings.Cut(name, "_")
+	return id
+}
+
+// setCustom sets a tag from a custom tag (e.g. an ID3 TXXX frame or an mp4 freeform atom) by its name.
+// Names are those written by common audiobook taggers. Unknown names are ignored.
+func (t *Tags) setCustom(name, value string) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "SUBTITLE":
+		setIfEmpty(&t.Subtitle, value)
+	case "NARRATOR", "NARRATEDBY", "NARRATED BY":
+		setIfEmpty(&t.Narrator, value)
+	case "SERIES", "MOVEMENTNAME":
+		setIfEmpty(&t.Series, value)
+	case "SERIES-PART", "SERIES_PART", "SERIESPART", "MOVEMENT":
+		setIfEmpty(&t.SeriesPart, value)
+	case "PUBLISHER", "LABEL":
+		setIfEmpty(&t.Publisher, value)
+	case "DESCRIPTION", "SUMMARY":
+		setIfEmpty(&t.Description, value)
+	case "ISBN":
+		setIfEmpty(&t.ISBN, value)
+	case "ASIN", "AUDIBLE_ASIN", "AUDIBLEASIN":
+		setIfEmpty(&t.ASIN, value)
+	}
+}
+
+// setIfEmpty sets a tag to a value if it is not already set, so the first value of a tag is kept.
+func setIfEmpty(tag *string, value string) {
+	value = strings.TrimSpace(value)
+	if *tag == "" && value != "" {
+		*tag = value
+	}
+}
+
+// tagYear gets the year of a date tag e.g. "2006" from "2006-07-17".
+func tagYear(date string) string {
+	date = strings.TrimSpace(date)
+	if len(date) < 4 {
+		return ""
+	}
+	for _, r := range date[:4] {
+		if r < '0' || r > '9' {
+			return ""
+		}
+	}
+	return date[:4]
+}
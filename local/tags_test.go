@@ -0,0 +1,232 @@
+package local_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/ahobsonsayers/abs-tract/local"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTagsMP3(t *testing.T) {
+	// ID3v2.3 with utf-16 text and a constant bitrate
+	path := writeFile(t, t.TempDir(), "book.mp3", mp3File(3, 0, []id3Frame{
+		{"TIT2", "The Final Empire"},
+		{"TPE1", "Brandon Sanderson"},
+		{"TALB", "Mistborn"},
+		{"TCOM", "Michael Kramer"},
+		{"TYER", "2006"},
+		{"TXXX", "SERIES\x00Mistborn"},
+		{"TXXX", "SERIES-PART\x001"},
+		{"TXXX", "ASIN\x00B002UZMLXM"},
+	}))
+
+	tags, err := local.ReadTags(path)
+	require.NoError(t, err)
+	require.Equal(t, local.Tags{
+		Title:      "The Final Empire",
+		Artist:     "Brandon Sanderson",
+		Album:      "Mistborn",
+		Composer:   "Michael Kramer",
+		Series:     "Mistborn",
+		SeriesPart: "1",
+		Year:       "2006",
+		ASIN:       "B002UZMLXM",
+		// 100 frames of 417 bytes at 128kbps
+		Duration: 2606250 * time.Microsecond,
+	}, tags)
+}
+
+func TestReadTagsMP3Xing(t *testing.T) {
+	// ID3v2.4 with utf-8 text and a variable bitrate
+	path := writeFile(t, t.TempDir(), "book.mp3", mp3File(4, 1000, []id3Frame{
+		{"TIT2", "The Hobbit"},
+		{"TPE2", "J.R.R. Tolkien"},
+		{"TDRC", "1937-09-21"},
+		{"COMM", "eng\x00In a hole in the ground there lived a hobbit."},
+	}))
+
+	tags, err := local.ReadTags(path)
+	require.NoError(t, err)
+	require.Equal(t, "The Hobbit", tags.Title)
+	require.Equal(t, "J.R.R. Tolkien", tags.AlbumArtist)
+	require.Equal(t, "1937", tags.Year)
+	require.Equal(t, "In a hole in the ground there lived a hobbit.", tags.Description)
+	// 1000 frames of 1152 samples at 44100hz
+	require.Equal(t, 26122448*time.Microsecond, tags.Duration.Truncate(time.Microsecond))
+
+	// Length tags are preferred
+	path = writeFile(t, t.TempDir(), "book.mp3", mp3File(4, 1000, []id3Frame{{"TLEN", "60000"}}))
+	tags, err = local.ReadTags(path)
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, tags.Duration)
+}
+
+func TestReadTagsMP4(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "book.m4b", mp4File([]mp4Item{
+		{"\xa9nam", "The Hobbit"},
+		{"\xa9ART", "J.R.R. Tolkien"},
+		{"\xa9alb", "The Hobbit"},
+		{"----:NARRATOR", "Andy Serkis"},
+		{"\xa9day", "2020-09-17"},
+		{"\xa9gen", "Fantasy"},
+		{"\xa9cmt", "In a hole in the ground there lived a hobbit."},
+		{"----:SERIES", "Middle Earth"},
+		{"----:SERIES-PART", "0"},
+		{"----:ISBN", "9780618260300"},
+	}, 3600))
+
+	tags, err := local.ReadTags(path)
+	require.NoError(t, err)
+	require.Equal(t, local.Tags{
+		Title:       "The Hobbit",
+		Artist:      "J.R.R. Tolkien",
+		Album:       "The Hobbit",
+		Narrator:    "Andy Serkis",
+		Series:      "Middle Earth",
+		SeriesPart:  "0",
+		Genre:       "Fantasy",
+		Year:        "2020",
+		Description: "In a hole in the ground there lived a hobbit.",
+		ISBN:        "9780618260300",
+		Duration:    time.Hour,
+	}, tags)
+}
+
+func TestReadTagsInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := local.ReadTags(writeFile(t, dir, "book.flac", []byte("fLaC")))
+	require.Error(t, err)
+
+	_, err = local.ReadTags(writeFile(t, dir, "book.m4b", []byte("not an mp4 file")))
+	require.Error(t, err)
+
+	_, err = local.ReadTags(filepath.Join(dir, "missing.mp3"))
+	require.Error(t, err)
+}
+
+type id3Frame struct {
+	id    string
+	value string
+}
+
+type mp4Item struct {
+	kind  string // Freeform items are "----:<name>"
+	value string
+}
+
+// mp3File creates an mp3 file with an ID3v2 tag and 100 frames of silence at 128kbps.
+// If xingFrames is set, the first frame has a Xing header with the number of frames.
+func mp3File(version byte, xingFrames uint32, frames []id3Frame) []byte {
+	var tagBody bytes.Buffer
+	for _, frame := range frames {
+		var data []byte
+		if version == 3 {
+			data = append([]byte{1}, utf16Text(frame.value)...)
+		} else {
+			data = append([]byte{3}, frame.value...)
+		}
+
+		tagBody.WriteString(frame.id)
+		if version == 4 {
+			tagBody.Write(syncsafe(len(data)))
+		} else {
+			tagBody.Write(binary.BigEndian.AppendUint32(nil, uint32(len(data))))
+		}
+		tagBody.Write([]byte{0, 0})
+		tagBody.Write(data)
+	}
+	tagBody.Write(make([]byte, 16)) // Padding
+
+	var file bytes.Buffer
+	file.WriteString("ID3")
+	file.Write([]byte{version, 0, 0})
+	file.Write(syncsafe(tagBody.Len()))
+	file.Write(tagBody.Bytes())
+
+	for idx := range 100 {
+		// MPEG 1 layer III, 128kbps, 44100hz, stereo
+		frame := make([]byte, 417)
+		copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+		if idx == 0 && xingFrames != 0 {
+			copy(frame[36:], "Xing")
+			binary.BigEndian.PutUint32(frame[40:], 1)
+			binary.BigEndian.PutUint32(frame[44:], xingFrames)
+		}
+		file.Write(frame)
+	}
+
+	return file.Bytes()
+}
+
+// utf16Text encodes text as null terminated utf-16 with a byte order mark.
+// Text is split into separately encoded values at null characters.
+func utf16Text(text string) []byte {
+	var data []byte
+	for idx, value := range bytes.Split([]byte(text), []byte{0}) {
+		if idx != 0 {
+			data = append(data, 0, 0)
+		}
+		data = append(data, 0xFF, 0xFE)
+		for _, unit := range utf16.Encode([]rune(string(value))) {
+			data = binary.LittleEndian.AppendUint16(data, unit)
+		}
+	}
+	return data
+}
+
+func syncsafe(value int) []byte {
+	return []byte{byte(value >> 21 & 0x7F), byte(value >> 14 & 0x7F), byte(value >> 7 & 0x7F), byte(value & 0x7F)}
+}
+
+// mp4File creates an mp4 file with tags and a duration in seconds.
+func mp4File(items []mp4Item, seconds uint32) []byte {
+	var ilst []byte
+	for _, item := range items {
+		data := mp4Atom("data", binary.BigEndian.AppendUint32(make([]byte, 4, 8+len(item.value)), 0), []byte(item.value))
+		binary.BigEndian.PutUint32(data[8:], 1) // UTF-8
+
+		kind, name, freeform := bytes.Cut([]byte(item.kind), []byte(":"))
+		if freeform {
+			mean := mp4Atom("mean", []byte{0, 0, 0, 0}, []byte("com.apple.iTunes"))
+			nameAtom := mp4Atom("name", []byte{0, 0, 0, 0}, name)
+			ilst = append(ilst, mp4Atom(string(kind), mean, nameAtom, data)...)
+		} else {
+			ilst = append(ilst, mp4Atom(item.kind, data)...)
+		}
+	}
+
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:], 1000)
+	binary.BigEndian.PutUint32(mvhd[16:], seconds*1000)
+
+	meta := mp4Atom("meta", []byte{0, 0, 0, 0}, mp4Atom("ilst", ilst))
+	moov := mp4Atom("moov", mp4Atom("mvhd", mvhd), mp4Atom("udta", meta))
+
+	return append(append(mp4Atom("ftyp", []byte("M4B \x00\x00\x00\x00")), mp4Atom("mdat", make([]byte, 64))...), moov...)
+}
+
+func mp4Atom(kind string, children ...[]byte) []byte {
+	atom := binary.BigEndian.AppendUint32(nil, 0)
+	atom = append(atom, kind...)
+	for _, child := range children {
+		atom = append(atom, child...)
+	}
+	binary.BigEndian.PutUint32(atom, uint32(len(atom)))
+	return atom
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	require.NoError(t, err)
+	err = os.WriteFile(path, data, 0o600)
+	require.NoError(t, err)
+	return path
+}
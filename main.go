@@ -1,26 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
-	"net/http"
+	"os"
 
-	"github.com/ahobsonsayers/abs-tract/server"
+	"github.com/ahobsonsayers/abs-tract/cli"
 )
 
 //go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config .oapigen.yaml schema/openapi.yaml
 
-const serverAddress = "0.0.0.0:5555"
-
 func main() {
-	router, err := server.NewRouter()
-	if err != nil {
-		log.Fatalf("Failed to create router: %s", err)
+	err := cli.Run(context.Background(), os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, cli.ErrUsage) {
+		log.Print(err)
+		os.Exit(2)
 	}
-
-	// Start the Server
-	log.Printf("Server listening on %s\n", serverAddress)
-	err = http.ListenAndServe(serverAddress, router)
 	if err != nil {
-		log.Fatalf("Server exited with error: %s", err)
+		log.Fatal(err)
 	}
 }
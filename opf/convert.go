@@ -0,0 +1,76 @@
+package opf
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/description"
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/samber/lo"
+)
+
+// goodreadsRoles are the roles of goodreads authors, by their lowercase goodreads role.
+// Authors without a goodreads role are authors, and authors with an unknown role are contributors.
+var goodreadsRoles = map[string]Role{
+	"":            RoleAuthor,
+	"author":      RoleAuthor,
+	"narrator":    RoleNarrator,
+	"reader":      RoleNarrator,
+	"illustrator": RoleIllustrator,
+	"illustrated": RoleIllustrator,
+	"translator":  RoleTranslator,
+	"editor":      RoleEditor,
+}
+
+// FromGoodreadsBook converts a goodreads book to the metadata of an OPF file, using its best edition.
+func FromGoodreadsBook(goodreadsBook goodreads.Book) Book {
+	creators := lo.Map(goodreadsBook.Authors, func(author goodreads.AuthorDetails, _ int) Creator {
+		role, ok := goodreadsRoles[strings.ToLower(strings.TrimSpace(author.Role))]
+		return Creator{Name: author.Name, Role: lo.Ternary(ok, role, RoleContributor)}
+	})
+
+	publishedDate := goodreadsBook.Work.PublicationDate()
+	if publishedDate == "" {
+		publishedDate = goodreadsBook.BestEdition.PublicationDate()
+	}
+
+	series := lo.Map(goodreadsBook.Series, func(series goodreads.SeriesBook, _ int) Series {
+		return Series{Name: series.Series.Title, Index: lo.FromPtr(series.BookPosition)}
+	})
+
+	return Book{
+		Title:         goodreadsBook.BestEdition.Title(),
+		Subtitle:      goodreadsBook.BestEdition.Subtitle(),
+		Creators:      creators,
+		PublishedDate: publishedDate,
+		Publisher:     goodreadsBook.BestEdition.Publisher,
+		Description:   description.Convert(goodreadsBook.BestEdition.DescriptionHTML, description.FormatHTML),
+		Language:      goodreadsBook.BestEdition.LanguageCode,
+		ISBN:          lo.FromPtr(goodreadsBook.BestEdition.ISBN),
+		GoodreadsId:   goodreadsBook.BestEdition.Id,
+		Series:        series,
+		Subjects:      slices.Concat([]string(goodreadsBook.Genres), goodreadsBook.Tags),
+	}
+}
+
+// FromKindleBook converts a kindle book to the metadata of an OPF file.
+func FromKindleBook(kindleBook kindle.Book) Book {
+	var creators []Creator
+	if kindleBook.Author != "" {
+		creators = []Creator{{Name: kindleBook.Author, Role: RoleAuthor}}
+	}
+
+	var publishedDate string
+	if kindleBook.PublishDate != nil {
+		publishedDate = kindleBook.PublishDate.Format(time.DateOnly)
+	}
+
+	return Book{
+		Title:         kindleBook.Title,
+		Creators:      creators,
+		PublishedDate: publishedDate,
+		ASIN:          kindleBook.ASIN,
+	}
+}
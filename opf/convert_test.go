@@ -0,0 +1,70 @@
+package opf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/ahobsonsayers/abs-tract/opf"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromGoodreadsBook(t *testing.T) {
+	var tolkien, serkis goodreads.AuthorDetails
+	tolkien.Name = "J.R.R. Tolkien"
+	serkis.Name = "Andy Serkis"
+	serkis.Role = "Narrator"
+
+	goodreadsBook := goodreads.Book{
+		Work: goodreads.Work{PublicationYear: 1937, PublicationMonth: 9, PublicationDay: 21},
+		BestEdition: goodreads.Edition{
+			Id:              "5907",
+			ISBN:            lo.ToPtr("9780618260300"),
+			FullTitle:       "The Hobbit: There and Back Again (Middle Earth, #0)",
+			DescriptionHTML: "In a hole in the ground there lived a hobbit.",
+			PublicationYear: 2002,
+			Publisher:       "Houghton Mifflin",
+			Language:        "English",
+			LanguageCode:    "eng",
+		},
+		Authors: []goodreads.AuthorDetails{tolkien, serkis},
+		Series:  []goodreads.SeriesBook{{Series: goodreads.Series{Title: "Middle Earth"}, BookPosition: lo.ToPtr("0")}},
+		Genres:  goodreads.Genres{"Fantasy"},
+		Tags:    goodreads.Tags{"Dragons"},
+	}
+
+	book := opf.FromGoodreadsBook(goodreadsBook)
+	require.Equal(t, opf.Book{
+		Title:    "The Hobbit",
+		Subtitle: "There and Back Again",
+		Creators: []opf.Creator{
+			{Name: "J.R.R. Tolkien", Role: opf.RoleAuthor},
+			{Name: "Andy Serkis", Role: opf.RoleNarrator},
+		},
+		PublishedDate: "1937-09-21",
+		Publisher:     "Houghton Mifflin",
+		Description:   "<p>In a hole in the ground there lived a hobbit.</p>",
+		Language:      "eng",
+		ISBN:          "9780618260300",
+		GoodreadsId:   "5907",
+		Series:        []opf.Series{{Name: "Middle Earth", Index: "0"}},
+		Subjects:      []string{"Fantasy", "Dragons"},
+	}, book)
+}
+
+func TestFromKindleBook(t *testing.T) {
+	book := opf.FromKindleBook(kindle.Book{
+		ASIN:        "B007978NU6",
+		Title:       "The Hobbit",
+		Author:      "J.R.R. Tolkien",
+		PublishDate: lo.ToPtr(time.Date(2012, 2, 15, 0, 0, 0, 0, time.UTC)),
+	})
+	require.Equal(t, opf.Book{
+		Title:         "The Hobbit",
+		Creators:      []opf.Creator{{Name: "J.R.R. Tolkien", Role: opf.RoleAuthor}},
+		PublishedDate: "2012-02-15",
+		ASIN:          "B007978NU6",
+	}, book)
+}
@@ -0,0 +1,302 @@
+package opf
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/orsinium-labs/enum"
+	"github.com/samber/lo"
+)
+
+const (
+	opfNamespace = "http://www.idpf.org/2007/opf"
+	dcNamespace  = "http://purl.org/dc/elements/1.1/"
+)
+
+// Version is a version of the open packaging format.
+type Version enum.Member[string]
+
+var (
+	versionEnum = enum.NewBuilder[string, Version]()
+
+	// Version2 is OPF 2.0, with roles and identifier schemes given as opf attributes.
+	Version2 = versionEnum.Add(Version{"2.0"})
+	// Version3 is OPF 3.0, with roles and series given as refining meta elements.
+	Version3 = versionEnum.Add(Version{"3.0"})
+
+	Versions = versionEnum.Enum()
+
+	DefaultVersion = Version3
+)
+
+// Role is the MARC relator code of the role of a creator of a book.
+// See: https://www.loc.gov/marc/relators/relaterm.html
+type Role enum.Member[string]
+
+var (
+	roleEnum = enum.NewBuilder[string, Role]()
+
+	RoleAuthor      = roleEnum.Add(Role{"aut"})
+	RoleNarrator    = roleEnum.Add(Role{"nrt"})
+	RoleIllustrator = roleEnum.Add(Role{"ill"})
+	RoleTranslator  = roleEnum.Add(Role{"trl"})
+	RoleEditor      = roleEnum.Add(Role{"edt"})
+	RoleContributor = roleEnum.Add(Role{"ctb"})
+
+	Roles = roleEnum.Enum()
+)
+
+// Book is the metadata of a book written to an OPF file. Empty fields are not written.
+type Book struct {
+	Title    string
+	Subtitle string
+	Creators []Creator
+	// PublishedDate is an ISO 8601 date. Only known parts of the date need be included.
+	// e.g. "2006-01-02", "2006-01" or "2006"
+	PublishedDate string
+	Publisher     string
+	// Description is the html description of the book
+	Description string
+	// Language is a language code (e.g. "en") or english name (e.g. "English")
+	Language    string
+	ISBN        string
+	ASIN        string
+	GoodreadsId string
+	Series      []Series
+	Subjects    []string
+}
+
+type Creator struct {
+	Name string
+	Role Role
+}
+
+type Series struct {
+	Name string
+	// Index is the position of the book in the series e.g. "1" or "2.5"
+	Index string
+}
+
+// Marshal renders a book as the xml of an OPF file (e.g. a metadata.opf sidecar),
+// readable by calibre and audiobookshelf.
+func Marshal(book Book, version Version) ([]byte, error) {
+	if strings.TrimSpace(book.Title) == "" {
+		return nil, errors.New("book must have a title")
+	}
+
+	var metadata metadataWriter
+	switch version {
+	case Version2:
+		metadata = newMetadataV2(book)
+	case Version3:
+		metadata = newMetadataV3(book)
+	default:
+		return nil, fmt.Errorf("unsupported opf version: %s", version.Value)
+	}
+
+	opfPackage := packageElement{
+		Xmlns:            opfNamespace,
+		Version:          version.Value,
+		UniqueIdentifier: metadata.uniqueIdentifier,
+		Metadata: metadataElement{
+			XmlnsDC:  dcNamespace,
+			XmlnsOPF: opfNamespace,
+			Elements: metadata.elements,
+		},
+	}
+
+	opfXML, err := xml.MarshalIndent(opfPackage, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal opf: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(opfXML, '\n')...), nil
+}
+
+// packageElement is the root element of an OPF file. Namespaces are written as
+// literal prefixes, as the xml encoder does not support namespace prefixes.
+type packageElement struct {
+	XMLName          xml.Name        `xml:"package"`
+	Xmlns            string          `xml:"xmlns,attr"`
+	Version          string          `xml:"version,attr"`
+	UniqueIdentifier string          `xml:"unique-identifier,attr,omitempty"`
+	Metadata         metadataElement `xml:"metadata"`
+}
+
+type metadataElement struct {
+	XmlnsDC  string    `xml:"xmlns:dc,attr"`
+	XmlnsOPF string    `xml:"xmlns:opf,attr"`
+	Elements []element // Named by their XMLName
+}
+
+type element struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Value   string     `xml:",chardata"`
+}
+
+// metadataWriter writes the elements of the metadata of an OPF file, in order.
+type metadataWriter struct {
+	elements         []element
+	uniqueIdentifier string
+}
+
+// add adds an element with attributes given as name value pairs. Elements without a value are not added.
+func (w *metadataWriter) add(name, value string, attrs ...string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+
+	xmlAttrs := make([]xml.Attr, 0, len(attrs)/2)
+	for idx := 0; idx+1 < len(attrs); idx += 2 {
+		xmlAttrs = append(xmlAttrs, xml.Attr{Name: xml.Name{Local: attrs[idx]}, Value: attrs[idx+1]})
+	}
+
+	w.elements = append(w.elements, element{XMLName: xml.Name{Local: name}, Attrs: xmlAttrs, Value: value})
+}
+
+// meta adds a calibre style meta element, with a name and content attribute. Elements without content are not added.
+func (w *metadataWriter) meta(name, content string) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return
+	}
+
+	w.elements = append(w.elements, element{
+		XMLName: xml.Name{Local: "meta"},
+		Attrs:   []xml.Attr{{Name: xml.Name{Local: "name"}, Value: name}, {Name: xml.Name{Local: "content"}, Value: content}},
+	})
+}
+
+// identifier adds an identifier of the book. The first identifier added is the unique identifier of the package.
+func (w *metadataWriter) identifier(id, value string, attrs ...string) {
+	if value == "" {
+		return
+	}
+	if w.uniqueIdentifier == "" {
+		w.uniqueIdentifier = id
+	}
+	w.add("dc:identifier", value, append([]string{"id", id}, attrs...)...)
+}
+
+// calibreSeries adds the series of the book as calibre meta elements, read by both calibre and audiobookshelf.
+func (w *metadataWriter) calibreSeries(series []Series) {
+	for _, bookSeries := range series {
+		w.meta("calibre:series", bookSeries.Name)
+		w.meta("calibre:series_index", bookSeries.Index)
+	}
+}
+
+func newMetadataV2(book Book) metadataWriter {
+	var w metadataWriter
+
+	title := book.Title
+	if book.Subtitle != "" {
+		title = fmt.Sprintf("%s: %s", book.Title, book.Subtitle)
+	}
+	w.add("dc:title", title)
+
+	for _, creator := range creators(book) {
+		w.add("dc:creator", creator.Name, "opf:role", creatorRole(creator).Value)
+	}
+
+	w.add("dc:publisher", book.Publisher)
+	w.add("dc:date", book.PublishedDate)
+	w.add("dc:description", book.Description)
+	w.add("dc:language", languageCode(book.Language))
+
+	w.identifier("isbn", book.ISBN, "opf:scheme", "ISBN")
+	w.identifier("asin", book.ASIN, "opf:scheme", "ASIN")
+	w.identifier("goodreads", book.GoodreadsId, "opf:scheme", "GOODREADS")
+
+	for _, subject := range subjects(book) {
+		w.add("dc:subject", subject)
+	}
+
+	w.calibreSeries(series(book))
+
+	return w
+}
+
+func newMetadataV3(book Book) metadataWriter {
+	var w metadataWriter
+
+	w.add("dc:title", book.Title, "id", "title")
+	if book.Subtitle != "" {
+		w.add("meta", "main", "refines", "#title", "property", "title-type")
+		w.add("dc:title", book.Subtitle, "id", "subtitle")
+		w.add("meta", "subtitle", "refines", "#subtitle", "property", "title-type")
+	}
+
+	for idx, creator := range creators(book) {
+		id := fmt.Sprintf("creator%02d", idx+1)
+		w.add("dc:creator", creator.Name, "id", id)
+		w.add("meta", creatorRole(creator).Value, "refines", "#"+id, "property", "role", "scheme", "marc:relators")
+	}
+
+	w.add("dc:publisher", book.Publisher)
+	w.add("dc:date", book.PublishedDate)
+	w.add("dc:description", book.Description)
+	w.add("dc:language", languageCode(book.Language))
+
+	// Identifiers are prefixed with their scheme, as written by calibre
+	w.identifier("isbn", lo.Ternary(book.ISBN != "", "isbn:"+book.ISBN, ""))
+	w.identifier("asin", lo.Ternary(book.ASIN != "", "amazon:"+book.ASIN, ""))
+	w.identifier("goodreads", lo.Ternary(book.GoodreadsId != "", "goodreads:"+book.GoodreadsId, ""))
+
+	for _, subject := range subjects(book) {
+		w.add("dc:subject", subject)
+	}
+
+	bookSeries := series(book)
+	for idx, series := range bookSeries {
+		id := fmt.Sprintf("series%02d", idx+1)
+		w.add("meta", series.Name, "property", "belongs-to-collection", "id", id)
+		w.add("meta", "series", "refines", "#"+id, "property", "collection-type")
+		w.add("meta", series.Index, "refines", "#"+id, "property", "group-position")
+	}
+	w.calibreSeries(bookSeries)
+
+	return w
+}
+
+// creatorRole gets the role of a creator, defaulting to author if unset.
+func creatorRole(creator Creator) Role {
+	if creator.Role == (Role{}) {
+		return RoleAuthor
+	}
+	return creator.Role
+}
+
+// languageCode converts a language to its code. An empty string is returned if the language is unknown.
+func languageCode(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+
+	tag, err := utils.ParseLanguage(value)
+	if err != nil {
+		return ""
+	}
+	return tag.String()
+}
+
+// creators gets the creators of a book with a name.
+func creators(book Book) []Creator {
+	return lo.Filter(book.Creators, func(creator Creator, _ int) bool { return strings.TrimSpace(creator.Name) != "" })
+}
+
+// subjects gets the unique, non-empty subjects of a book.
+func subjects(book Book) []string {
+	subjects := lo.Map(book.Subjects, func(subject string, _ int) string { return strings.TrimSpace(subject) })
+	return lo.Uniq(lo.Compact(subjects))
+}
+
+// series gets the series of a book with a name.
+func series(book Book) []Series {
+	return lo.Filter(book.Series, func(series Series, _ int) bool { return strings.TrimSpace(series.Name) != "" })
+}
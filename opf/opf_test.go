@@ -0,0 +1,179 @@
+package opf_test
+
+import (
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/opf"
+	"github.com/stretchr/testify/require"
+)
+
+var testBook = opf.Book{
+	Title:    "The Hobbit",
+	Subtitle: "There and Back Again",
+	Creators: []opf.Creator{
+		{Name: "J.R.R. Tolkien", Role: opf.RoleAuthor},
+		{Name: "Andy Serkis", Role: opf.RoleNarrator},
+	},
+	PublishedDate: "1937-09-21",
+	Publisher:     "Houghton Mifflin",
+	Description:   "<p>In a hole in the ground there lived a hobbit.</p>",
+	Language:      "English",
+	ISBN:          "9780618260300",
+	ASIN:          "B007978NU6",
+	GoodreadsId:   "5907",
+	Series:        []opf.Series{{Name: "Middle Earth", Index: "0"}},
+	Subjects:      []string{"Fantasy", "Classic", "Fantasy", " "},
+}
+
+func TestMarshalVersion2(t *testing.T) {
+	opfXML, err := opf.Marshal(testBook, opf.Version2)
+	require.NoError(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0" unique-identifier="isbn">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+    <dc:title>The Hobbit: There and Back Again</dc:title>
+    <dc:creator opf:role="aut">J.R.R. Tolkien</dc:creator>
+    <dc:creator opf:role="nrt">Andy Serkis</dc:creator>
+    <dc:publisher>Houghton Mifflin</dc:publisher>
+    <dc:date>1937-09-21</dc:date>
+    <dc:description>&lt;p&gt;In a hole in the ground there lived a hobbit.&lt;/p&gt;</dc:description>
+    <dc:language>en</dc:language>
+    <dc:identifier id="isbn" opf:scheme="ISBN">9780618260300</dc:identifier>
+    <dc:identifier id="asin" opf:scheme="ASIN">B007978NU6</dc:identifier>
+    <dc:identifier id="goodreads" opf:scheme="GOODREADS">5907</dc:identifier>
+    <dc:subject>Fantasy</dc:subject>
+    <dc:subject>Classic</dc:subject>
+    <meta name="calibre:series" content="Middle Earth"></meta>
+    <meta name="calibre:series_index" content="0"></meta>
+  </metadata>
+</package>
+`
+	require.Equal(t, expected, string(opfXML))
+}
+
+func TestMarshalVersion3(t *testing.T) {
+	opfXML, err := opf.Marshal(testBook, opf.Version3)
+	require.NoError(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="isbn">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+    <dc:title id="title">The Hobbit</dc:title>
+    <meta refines="#title" property="title-type">main</meta>
+    <dc:title id="subtitle">There and Back Again</dc:title>
+    <meta refines="#subtitle" property="title-type">subtitle</meta>
+    <dc:creator id="creator01">J.R.R. Tolkien</dc:creator>
+    <meta refines="#creator01" property="role" scheme="marc:relators">aut</meta>
+    <dc:creator id="creator02">Andy Serkis</dc:creator>
+    <meta refines="#creator02" property="role" scheme="marc:relators">nrt</meta>
+    <dc:publisher>Houghton Mifflin</dc:publisher>
+    <dc:date>1937-09-21</dc:date>
+    <dc:description>&lt;p&gt;In a hole in the ground there lived a hobbit.&lt;/p&gt;</dc:description>
+    <dc:language>en</dc:language>
+    <dc:identifier id="isbn">isbn:9780618260300</dc:identifier>
+    <dc:identifier id="asin">amazon:B007978NU6</dc:identifier>
+    <dc:identifier id="goodreads">goodreads:5907</dc:identifier>
+    <dc:subject>Fantasy</dc:subject>
+    <dc:subject>Classic</dc:subject>
+    <meta property="belongs-to-collection" id="series01">Middle Earth</meta>
+    <meta refines="#series01" property="collection-type">series</meta>
+    <meta refines="#series01" property="group-position">0</meta>
+    <meta name="calibre:series" content="Middle Earth"></meta>
+    <meta name="calibre:series_index" content="0"></meta>
+  </metadata>
+</package>
+`
+	require.Equal(t, expected, string(opfXML))
+}
+
+func TestMarshalMinimal(t *testing.T) {
+	opfXML, err := opf.Marshal(opf.Book{
+		Title:    "The Hobbit",
+		Creators: []opf.Creator{{Name: "J.R.R. Tolkien"}, {Name: ""}},
+		Language: "elvish",
+	}, opf.Version2)
+	require.NoError(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+    <dc:title>The Hobbit</dc:title>
+    <dc:creator opf:role="aut">J.R.R. Tolkien</dc:creator>
+  </metadata>
+</package>
+`
+	require.Equal(t, expected, string(opfXML))
+}
+
+func TestMarshalInvalid(t *testing.T) {
+	_, err := opf.Marshal(opf.Book{Title: " "}, opf.Version3)
+	require.Error(t, err)
+
+	_, err = opf.Marshal(testBook, opf.Version{})
+	require.Error(t, err)
+}
+
+func TestUnmarshal(t *testing.T) {
+	for _, version := range opf.Versions.Members() {
+		t.Run(version.Value, func(t *testing.T) {
+			opfXML, err := opf.Marshal(testBook, version)
+			require.NoError(t, err)
+
+			book, err := opf.Unmarshal(opfXML)
+			require.NoError(t, err)
+
+			expected := testBook
+			expected.Language = "en"
+			expected.Subjects = []string{"Fantasy", "Classic"}
+			if version == opf.Version2 {
+				// Subtitles are part of the title in OPF 2.0
+				expected.Title = "The Hobbit: There and Back Again"
+				expected.Subtitle = ""
+			}
+			require.Equal(t, expected, book)
+		})
+	}
+}
+
+func TestUnmarshalCalibre(t *testing.T) {
+	opfXML := `<?xml version='1.0' encoding='utf-8'?>
+<package xmlns="http://www.idpf.org/2007/opf" unique-identifier="uuid_id" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+    <dc:identifier opf:scheme="calibre" id="calibre_id">42</dc:identifier>
+    <dc:identifier opf:scheme="uuid" id="uuid_id">0b5b4a8e-1d3c-4a6f-9a55-1f1d7a3b6c1e</dc:identifier>
+    <dc:title>The Final Empire</dc:title>
+    <dc:creator opf:file-as="Sanderson, Brandon" opf:role="aut">Brandon Sanderson</dc:creator>
+    <dc:creator opf:role="nrt">Michael Kramer</dc:creator>
+    <dc:date>2006-07-17T00:00:00+00:00</dc:date>
+    <dc:identifier opf:scheme="ISBN">9780765311788</dc:identifier>
+    <dc:identifier opf:scheme="MOBI-ASIN">B000SEGIU0</dc:identifier>
+    <dc:language>eng</dc:language>
+    <dc:subject>Fantasy</dc:subject>
+    <meta name="calibre:series" content="Mistborn"/>
+    <meta name="calibre:series_index" content="1.0"/>
+  </metadata>
+  <guide>
+    <reference type="cover" title="Cover" href="cover.jpg"/>
+  </guide>
+</package>`
+
+	book, err := opf.Unmarshal([]byte(opfXML))
+	require.NoError(t, err)
+	require.Equal(t, opf.Book{
+		Title: "The Final Empire",
+		Creators: []opf.Creator{
+			{Name: "Brandon Sanderson", Role: opf.RoleAuthor},
+			{Name: "Michael Kramer", Role: opf.RoleNarrator},
+		},
+		PublishedDate: "2006-07-17T00:00:00+00:00",
+		Language:      "eng",
+		ISBN:          "9780765311788",
+		ASIN:          "B000SEGIU0",
+		Series:        []opf.Series{{Name: "Mistborn", Index: "1.0"}},
+		Subjects:      []string{"Fantasy"},
+	}, book)
+
+	_, err = opf.Unmarshal([]byte("not xml"))
+	require.Error(t, err)
+}
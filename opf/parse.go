@@ -0,0 +1,157 @@
+package opf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Prefixes of identifier values, by the field of the book they identify. e.g. "isbn:9780618260300"
+var identifierPrefixes = map[string][]string{
+	"isbn":      {"urn:isbn:", "isbn:"},
+	"asin":      {"urn:asin:", "asin:", "amazon:", "mobi-asin:"},
+	"goodreads": {"urn:goodreads:", "goodreads:"},
+}
+
+// Identifier schemes, by the field of the book they identify.
+var identifierSchemes = map[string][]string{
+	"isbn":      {"isbn"},
+	"asin":      {"asin", "amazon", "mobi-asin"},
+	"goodreads": {"goodreads"},
+}
+
+type parsedPackage struct {
+	Metadata struct {
+		Elements []parsedElement `xml:",any"`
+	} `xml:"metadata"`
+}
+
+type parsedElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Value   string     `xml:",chardata"`
+}
+
+// attr gets the value of an attribute by its local name, ignoring its namespace.
+func (e parsedElement) attr(name string) string {
+	for _, attr := range e.Attrs {
+		if strings.EqualFold(attr.Name.Local, name) {
+			return strings.TrimSpace(attr.Value)
+		}
+	}
+	return ""
+}
+
+func (e parsedElement) isDC(name string) bool {
+	return e.XMLName.Space == dcNamespace && e.XMLName.Local == name
+}
+
+// Unmarshal parses the metadata of a book from the xml of an OPF file (e.g. a metadata.opf sidecar).
+// Both OPF 2.0 and 3.0 files are supported, including the series written by calibre.
+func Unmarshal(data []byte) (Book, error) {
+	var opfPackage parsedPackage
+	err := xml.NewDecoder(bytes.NewReader(data)).Decode(&opfPackage)
+	if err != nil {
+		return Book{}, fmt.Errorf("failed to parse opf: %w", err)
+	}
+	elements := opfPackage.Metadata.Elements
+
+	// Properties of elements given by OPF 3.0 meta elements refining them, by the id of the element
+	refinements := make(map[string]map[string]string)
+	for _, element := range elements {
+		refines := strings.TrimPrefix(element.attr("refines"), "#")
+		if element.XMLName.Local != "meta" || refines == "" {
+			continue
+		}
+		if refinements[refines] == nil {
+			refinements[refines] = make(map[string]string)
+		}
+		refinements[refines][element.attr("property")] = strings.TrimSpace(element.Value)
+	}
+
+	var book Book
+	var calibreSeries *Series
+	for _, element := range elements {
+		value := strings.TrimSpace(element.Value)
+		properties := refinements[element.attr("id")]
+
+		switch {
+		case element.isDC("title"):
+			if properties["title-type"] == "subtitle" {
+				book.Subtitle = value
+			} else if book.Title == "" {
+				book.Title = value
+			}
+
+		case element.isDC("creator"):
+			role := Roles.Parse(strings.ToLower(lo.CoalesceOrEmpty(element.attr("role"), properties["role"])))
+			book.Creators = append(book.Creators, Creator{Name: value, Role: lo.FromPtrOr(role, RoleAuthor)})
+
+		case element.isDC("publisher"):
+			book.Publisher = value
+
+		case element.isDC("date"):
+			book.PublishedDate = value
+
+		case element.isDC("description"):
+			book.Description = value
+
+		case element.isDC("language"):
+			book.Language = value
+
+		case element.isDC("subject"):
+			book.Subjects = append(book.Subjects, value)
+
+		case element.isDC("identifier"):
+			setIdentifier(&book, element.attr("scheme"), value)
+
+		case element.XMLName.Local == "meta" && element.attr("name") == "calibre:series":
+			book.Series = append(book.Series, Series{Name: element.attr("content")})
+			calibreSeries = &book.Series[len(book.Series)-1]
+
+		case element.XMLName.Local == "meta" && element.attr("name") == "calibre:series_index":
+			if calibreSeries != nil {
+				calibreSeries.Index = element.attr("content")
+			}
+
+		case element.XMLName.Local == "meta" && element.attr("property") == "belongs-to-collection":
+			collectionType := properties["collection-type"]
+			if collectionType != "" && collectionType != "series" {
+				continue
+			}
+			book.Series = append(book.Series, Series{Name: value, Index: properties["group-position"]})
+		}
+	}
+
+	// Series may be given both as calibre meta elements and collections
+	book.Series = lo.UniqBy(series(book), func(series Series) string { return strings.ToLower(series.Name) })
+	book.Creators = creators(book)
+	book.Subjects = subjects(book)
+
+	return book, nil
+}
+
+// setIdentifier sets the isbn, asin or goodreads id of a book from an identifier,
+// using its scheme or the prefix of its value.
+func setIdentifier(book *Book, scheme, value string) {
+	fields := map[string]*string{"isbn": &book.ISBN, "asin": &book.ASIN, "goodreads": &book.GoodreadsId}
+
+	for field, schemes := range identifierSchemes {
+		if lo.Contains(schemes, strings.ToLower(scheme)) {
+			*fields[field] = value
+			return
+		}
+	}
+
+	for field, prefixes := range identifierPrefixes {
+		for _, prefix := range prefixes {
+			if len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+				*fields[field] = value[len(prefix):]
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package query
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/adrg/strutil"
+	"github.com/adrg/strutil/metrics"
+)
+
+// Weights of the similarity of each part of a book to the query, in the confidence of a match
+const (
+	titleWeight  = 0.5
+	authorWeight = 0.35
+	seriesWeight = 0.1
+	yearWeight   = 0.05
+)
+
+// Regex to match the separators between the names of multiple authors. e.g. "J.R.R. Tolkien and Christopher Tolkien"
+var authorSeparatorRegex = regexp.MustCompile(`(?i)\s*(,|&|\band\b)\s*`)
+
+// Match is a book found by a search, to compare to the query searched for.
+type Match struct {
+	Title    string
+	Subtitle string
+	// Author is the author of the book, which may be multiple authors e.g. "Terry Pratchett and Neil Gaiman"
+	Author string
+	Series []string
+	Year   string
+}
+
+// Confidence is how confident it is that a book is the book searched for by the query, between 0 and 1.
+// It is the weighted similarity of the title, author, series and year of the book to those of the query.
+// Only the parts known by both the book and the query are compared.
+func (q Query) Confidence(match Match) float64 {
+	var score, weights float64
+
+	if q.Title != "" {
+		score += titleWeight * q.titleSimilarity(match)
+		weights += titleWeight
+	}
+
+	if q.Author != "" && match.Author != "" {
+		bestAuthorSimilarity := 0.0
+		for _, author := range authorSeparatorRegex.Split(match.Author, -1) {
+			bestAuthorSimilarity = max(bestAuthorSimilarity, similarity(author, q.Author))
+		}
+		score += authorWeight * bestAuthorSimilarity
+		weights += authorWeight
+	}
+
+	if q.Series != "" && len(match.Series) != 0 {
+		bestSeriesSimilarity := 0.0
+		for _, series := range match.Series {
+			bestSeriesSimilarity = max(bestSeriesSimilarity, similarity(series, q.Series))
+		}
+		score += seriesWeight * bestSeriesSimilarity
+		weights += seriesWeight
+	}
+
+	if q.Year != "" && match.Year != "" {
+		score += yearWeight * yearSimilarity(match.Year, q.Year)
+		weights += yearWeight
+	}
+
+	if weights == 0 {
+		return 0
+	}
+
+	return math.Round(score/weights*100) / 100
+}
+
+// titleSimilarity is the best similarity of the title of a book, with and without its subtitle,
+// to the title of the query, or all the parts of the query in case the title is wrong.
+func (q Query) titleSimilarity(match Match) float64 {
+	matchTitles := []string{match.Title}
+	if match.Subtitle != "" {
+		matchTitles = append(matchTitles, match.Title+" "+match.Subtitle)
+	}
+	if title, _, found := strings.Cut(match.Title, ":"); found {
+		// Some providers include the subtitle in the title e.g. "The Hobbit: 75th Anniversary Edition"
+		matchTitles = append(matchTitles, title)
+	}
+
+	queryTitles := []string{q.Title}
+	if len(q.Parts) > 1 {
+		queryTitles = append(queryTitles, strings.Join(q.Parts, " "))
+	}
+
+	bestSimilarity := 0.0
+	for _, matchTitle := range matchTitles {
+		for _, queryTitle := range queryTitles {
+			bestSimilarity = max(bestSimilarity, similarity(matchTitle, queryTitle))
+		}
+	}
+
+	return bestSimilarity
+}
+
+// similarity is the similarity of two strings (e.g. titles or authors), between 0 and 1.
+// Strings are normalised before they are compared, so case and punctuation are ignored.
+// The similarity is squared, as unrelated strings are still fairly similar by jaro-winkler.
+func similarity(a, b string) float64 {
+	a = normaliseText(a)
+	b = normaliseText(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	return math.Pow(strutil.Similarity(a, b, metrics.NewJaroWinkler()), 2)
+}
+
+// yearSimilarity is 1 if two years are the same, 0.5 if they are a year apart, otherwise 0.
+// Editions are often published the year after a book.
+func yearSimilarity(a, b string) float64 {
+	yearA, errA := strconv.Atoi(strings.TrimSpace(a))
+	yearB, errB := strconv.Atoi(strings.TrimSpace(b))
+	if errA != nil || errB != nil {
+		return 0
+	}
+
+	switch yearA - yearB {
+	case 0:
+		return 1
+	case -1, 1:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// normaliseText normalises text for comparing by converting it to lowercase, removing any
+// characters that are not letters, numbers or spaces, and removing the "the " prefix.
+func normaliseText(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		return -1
+	}, text)
+	text = normaliseSpace(text)
+	return strings.TrimPrefix(text, "the ")
+}
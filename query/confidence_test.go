@@ -0,0 +1,40 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfidence(t *testing.T) {
+	parsedQuery := query.Parse("Brandon Sanderson - Mistborn 01 - The Final Empire (2006)", "")
+
+	exactMatch := query.Match{
+		Title:  "The Final Empire",
+		Author: "Brandon Sanderson",
+		Series: []string{"Mistborn"},
+		Year:   "2006",
+	}
+	require.InDelta(t, 1, parsedQuery.Confidence(exactMatch), 0)
+
+	// Subtitles, multiple authors and editions published the next year are strong matches
+	editionMatch := query.Match{
+		Title:    "The Final Empire",
+		Subtitle: "Tenth Anniversary Edition",
+		Author:   "Brandon Sanderson and Isaac Stewart",
+		Year:     "2007",
+	}
+	require.Greater(t, parsedQuery.Confidence(editionMatch), 0.9)
+
+	// Books by other authors are weaker matches
+	otherAuthorMatch := query.Match{Title: "The Final Empire", Author: "Someone Else"}
+	require.Less(t, parsedQuery.Confidence(otherAuthorMatch), 0.8)
+
+	// Other books are weak matches
+	otherBookMatch := query.Match{Title: "Warbreaker", Author: "Brandon Sanderson", Year: "2009"}
+	require.Less(t, parsedQuery.Confidence(otherBookMatch), 0.6)
+
+	// Queries without parts to compare have no confidence
+	require.Zero(t, query.Parse("", "").Confidence(exactMatch))
+}
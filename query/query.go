@@ -0,0 +1,253 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
+)
+
+var (
+	// Regex to match bracketed tags. e.g. "(2006)", "[Unabridged]" or "{Narrator}"
+	bracketRegex = regexp.MustCompile(`\([^)]*\)|\[[^\]]*\]|\{[^}]*\}`)
+
+	// Regex to match the separators between parts of a query. Dashes must have spaces
+	// around them, so hyphenated words are not split. e.g. "Author - Series - Title"
+	separatorRegex = regexp.MustCompile(`\s+[-–—]+\s+|\s*/\s*`)
+
+	// Regex to match narrators, up to the end of a part. e.g. "read by Michael Kramer"
+	narratorRegex = regexp.MustCompile(`(?i)[\s,]*\b(read|narrated|performed)\s+by\b.*$`)
+
+	// Regex to match a year that is a whole part. e.g. "2006"
+	yearRegex = regexp.MustCompile(`^(1[5-9]|20)\d{2}$`)
+
+	// Regex to match a year in a bracketed tag. e.g. "(2006)" or "[2006, Unabridged]"
+	bracketYearRegex = regexp.MustCompile(`[(\[{][^)\]}]*\b((1[5-9]|20)\d{2})\b[^)\]}]*[)\]}]`)
+
+	// Regex to match an explicit series marker at the end of a part. e.g. "Book 1", "Vol. 2" or "#3"
+	seriesMarkerRegex = regexp.MustCompile(`(?i)[\s,]*(\b(book|bk|volume|vol|part|pt|no)\.?\s*|#)\d+(\.\d+)?$`)
+
+	// Regex to match a series number at the end of a part. e.g. "01"
+	seriesNumberRegex = regexp.MustCompile(`[\s,]*\b\d{1,3}(\.\d+)?$`)
+
+	// Regex to match a series number at the start of a part. e.g. "01 " or "1. "
+	leadingSeriesNumberRegex = regexp.MustCompile(`^(#?\d{1,3}(\.\d+)?)(\s*[.:)-]\s*|\s+)`)
+
+	// Words in names that are not capitalised. e.g. "Ursula K. Le Guin" or "Ludwig van Beethoven"
+	nameParticles = []string{"da", "de", "del", "der", "di", "du", "la", "le", "van", "von"}
+
+	// Words that names do not start with, but titles often do
+	articles = []string{"a", "an", "the"}
+)
+
+// Query is a search query parsed from a query of AudiobookShelf, which is often derived from
+// the name of a folder. e.g. "Brandon Sanderson - Mistborn 01 - The Final Empire (2006) [Unabridged]"
+type Query struct {
+	// Original is the query before it was parsed
+	Original string
+	Title    string
+	// Author is the given author, or the author detected in the query if no author was given
+	Author string
+	// GivenAuthor is the author given with the query, which may be empty
+	GivenAuthor string
+	Series      string
+	// Year is the year in the query, usually the year the book was published
+	Year string
+	// Parts are the cleaned parts of the query, excluding the author
+	Parts []string
+}
+
+// Variant is a title and author to search for.
+type Variant struct {
+	Title  string
+	Author string
+}
+
+// part is a cleaned part of a query.
+type part struct {
+	text string
+	// series is whether the part is a series with its number removed
+	series bool
+}
+
+// Parse parses a query and optionally an author. Bracketed tags, years, series markers and narrators
+// are removed. If no author is given, an author at the start of the query is detected.
+func Parse(query string, author string) Query {
+	author = normaliseSpace(author)
+
+	parts, year := parseParts(query)
+
+	// Remove the given author from the query, or detect the author
+	detectedAuthor := author
+	if author != "" {
+		parts = lo.Reject(parts, func(p part, _ int) bool { return strings.EqualFold(p.text, author) })
+	} else if len(parts) > 1 && !parts[0].series && looksLikeName(parts[0].text) {
+		detectedAuthor = parts[0].text
+		parts = parts[1:]
+	}
+
+	parsedQuery := Query{
+		Original:    strings.TrimSpace(query),
+		Author:      detectedAuthor,
+		GivenAuthor: author,
+		Year:        year,
+		Parts:       lo.Map(parts, func(p part, _ int) string { return p.text }),
+	}
+
+	// The title is the last part that is not a series, and the series is the first series
+	for _, p := range parts {
+		if p.series && parsedQuery.Series == "" {
+			parsedQuery.Series = p.text
+		}
+		if !p.series {
+			parsedQuery.Title = p.text
+		}
+	}
+	if parsedQuery.Title == "" {
+		parsedQuery.Title = parsedQuery.Series
+	}
+
+	return parsedQuery
+}
+
+// Variants are the titles and authors to search for, from the most to the least specific.
+// Searches should use each variant in turn until results are found.
+func (q Query) Variants() []Variant {
+	variants := []Variant{{Title: q.Title, Author: q.Author}}
+
+	// Without the author, in case it is wrong
+	if q.Author != "" {
+		variants = append(variants, Variant{Title: q.Title})
+	}
+
+	// All the parts, in case the title is wrong
+	if len(q.Parts) > 1 {
+		variants = append(variants, Variant{Title: strings.Join(q.Parts, " "), Author: q.Author})
+	}
+
+	// The series, in case the title is not known
+	if q.Series != "" && q.Series != q.Title {
+		variants = append(variants, Variant{Title: q.Series, Author: q.Author})
+	}
+
+	// The query as it was given
+	variants = append(variants, Variant{Title: q.Original, Author: q.GivenAuthor})
+
+	// Remove empty and duplicate variants
+	variants = lo.Filter(variants, func(v Variant, _ int) bool { return v.Title != "" || v.Author != "" })
+	return lo.UniqBy(variants, func(v Variant) Variant {
+		return Variant{Title: strings.ToLower(v.Title), Author: strings.ToLower(v.Author)}
+	})
+}
+
+// Search searches using each variant of a query and optionally an author in turn, until a search finds
+// results. If a search fails, its error is returned without searching the remaining variants.
+// Unless the context is already limited, searches are given a context limited to utils.SearchRequestLimit
+// requests. Once the limit is reached, searching stops and any results of the last search are returned.
+func Search[T any](
+	ctx context.Context,
+	query string,
+	author *string,
+	search func(ctx context.Context, title string, author *string) ([]T, error),
+) ([]T, error) {
+	if !utils.HasRequestLimit(ctx) {
+		ctx = utils.WithRequestLimit(ctx, utils.SearchRequestLimit)
+	}
+	for _, variant := range Parse(query, lo.FromPtr(author)).Variants() {
+		results, err := search(ctx, variant.Title, lo.EmptyableToPtr(variant.Author))
+		if errors.Is(err, utils.ErrRequestLimit) {
+			log.Printf("Search for %q reached its request limit, returning %d results found", query, len(results))
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(results) != 0 {
+			return results, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// parseParts splits a query into its parts, cleaning each part, and gets the year in the query.
+// Parts that only contain a year or a series number are removed, unless they are the only part.
+func parseParts(query string) ([]part, string) {
+	query = strings.ReplaceAll(query, "_", " ")
+
+	var year string
+	if match := bracketYearRegex.FindStringSubmatch(query); match != nil {
+		year = match[1]
+	}
+	query = bracketRegex.ReplaceAllString(query, " ")
+
+	rawParts := separatorRegex.Split(query, -1)
+	multipleParts := len(rawParts) > 1
+
+	parts := make([]part, 0, len(rawParts))
+	for _, rawPart := range rawParts {
+		text := narratorRegex.ReplaceAllString(rawPart, "")
+		text = normaliseSpace(text)
+		if text == "" {
+			continue
+		}
+		if multipleParts && yearRegex.MatchString(text) {
+			if year == "" {
+				year = text
+			}
+			continue
+		}
+
+		// Explicit series markers (e.g. "Book 1") are always removed, but series numbers (e.g. "01")
+		// are only removed from queries with multiple parts, as titles often end with numbers. e.g. "Catch 22"
+		series := false
+		if seriesMarkerRegex.MatchString(text) {
+			text = normaliseSpace(seriesMarkerRegex.ReplaceAllString(text, ""))
+			series = true
+		} else if multipleParts && seriesNumberRegex.MatchString(text) {
+			text = normaliseSpace(seriesNumberRegex.ReplaceAllString(text, ""))
+			series = true
+		} else if multipleParts {
+			text = normaliseSpace(leadingSeriesNumberRegex.ReplaceAllString(text, ""))
+		}
+
+		// Parts that are only a series marker or number are removed
+		text = strings.Trim(text, " ,.:-")
+		if text == "" {
+			continue
+		}
+
+		parts = append(parts, part{text: text, series: series})
+	}
+
+	return parts, year
+}
+
+// looksLikeName returns whether text looks like the name of a person. e.g. "Brandon Sanderson" or "J.R.R. Tolkien"
+func looksLikeName(text string) bool {
+	words := strings.Fields(text)
+	if len(words) < 2 || len(words) > 4 || lo.Contains(articles, strings.ToLower(words[0])) {
+		return false
+	}
+
+	for _, word := range words {
+		if lo.Contains(nameParticles, word) {
+			continue
+		}
+		if !unicode.IsUpper([]rune(word)[0]) || strings.ContainsFunc(word, unicode.IsDigit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normaliseSpace replaces all whitespace with a single space, removing any leading or trailing whitespace.
+func normaliseSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
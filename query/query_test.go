@@ -0,0 +1,155 @@
+package query_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query          string
+		author         string
+		expectedTitle  string
+		expectedAuthor string
+		expectedSeries string
+		expectedYear   string
+	}{
+		{
+			query:          "Brandon Sanderson - Mistborn 01 - The Final Empire (2006) [Unabridged]",
+			expectedTitle:  "The Final Empire",
+			expectedAuthor: "Brandon Sanderson",
+			expectedSeries: "Mistborn",
+			expectedYear:   "2006",
+		},
+		{
+			query:          "J.R.R. Tolkien - The Hobbit - read by Andy Serkis",
+			expectedTitle:  "The Hobbit",
+			expectedAuthor: "J.R.R. Tolkien",
+		},
+		{
+			query:          "The Way of Kings (The Stormlight Archive, #1)",
+			author:         "Brandon Sanderson",
+			expectedTitle:  "The Way of Kings",
+			expectedAuthor: "Brandon Sanderson",
+		},
+		{
+			query:          "Discworld Book 1 - The Colour of Magic",
+			expectedTitle:  "The Colour of Magic",
+			expectedSeries: "Discworld",
+		},
+		{
+			query:          "Brandon Sanderson/01 The Final Empire",
+			expectedTitle:  "The Final Empire",
+			expectedAuthor: "Brandon Sanderson",
+		},
+		{
+			query:          "Brandon Sanderson - The Final Empire",
+			author:         "brandon sanderson",
+			expectedTitle:  "The Final Empire",
+			expectedAuthor: "brandon sanderson",
+		},
+		{
+			// Titles ending with numbers are kept
+			query:         "Catch 22",
+			expectedTitle: "Catch 22",
+		},
+		{
+			// Titles that are years are kept
+			query:         "1984",
+			expectedTitle: "1984",
+		},
+		{
+			// Titles are not detected as authors
+			query:         "The Final Empire - 2006",
+			expectedTitle: "The Final Empire",
+			expectedYear:  "2006",
+		},
+	}
+	for _, test := range tests {
+		parsedQuery := query.Parse(test.query, test.author)
+		require.Equal(t, test.expectedTitle, parsedQuery.Title, test.query)
+		require.Equal(t, test.expectedAuthor, parsedQuery.Author, test.query)
+		require.Equal(t, test.expectedSeries, parsedQuery.Series, test.query)
+		require.Equal(t, test.expectedYear, parsedQuery.Year, test.query)
+	}
+}
+
+func TestVariants(t *testing.T) {
+	variants := query.Parse("Brandon Sanderson - Mistborn 01 - The Final Empire (2006)", "").Variants()
+	expectedVariants := []query.Variant{
+		{Title: "The Final Empire", Author: "Brandon Sanderson"},
+		{Title: "The Final Empire"},
+		{Title: "Mistborn The Final Empire", Author: "Brandon Sanderson"},
+		{Title: "Mistborn", Author: "Brandon Sanderson"},
+		{Title: "Brandon Sanderson - Mistborn 01 - The Final Empire (2006)"},
+	}
+	require.Equal(t, expectedVariants, variants)
+
+	// Clean queries only have a single variant
+	variants = query.Parse("The Hobbit", "J.R.R. Tolkien").Variants()
+	expectedVariants = []query.Variant{
+		{Title: "The Hobbit", Author: "J.R.R. Tolkien"},
+		{Title: "The Hobbit"},
+	}
+	require.Equal(t, expectedVariants, variants)
+}
+
+func TestSearch(t *testing.T) {
+	var searches []query.Variant
+	search := func(_ context.Context, title string, author *string) ([]string, error) {
+		searches = append(searches, query.Variant{Title: title, Author: lo.FromPtr(author)})
+		if title == "The Final Empire" && author == nil {
+			return []string{"The Final Empire"}, nil
+		}
+		return nil, nil
+	}
+
+	// Searches stop when results are found
+	results, err := query.Search(context.Background(), "Brandon Sanderson - The Final Empire [Unabridged]", nil, search)
+	require.NoError(t, err)
+	require.Equal(t, []string{"The Final Empire"}, results)
+	require.Equal(t, []query.Variant{
+		{Title: "The Final Empire", Author: "Brandon Sanderson"},
+		{Title: "The Final Empire"},
+	}, searches)
+
+	// Searches stop when a search fails
+	searches = nil
+	search = func(context.Context, string, *string) ([]string, error) {
+		searches = append(searches, query.Variant{})
+		return nil, errors.New("search failed")
+	}
+	_, err = query.Search(context.Background(), "Brandon Sanderson - The Final Empire", nil, search)
+	require.Error(t, err)
+	require.Len(t, searches, 1)
+
+	// Searches stop when the request limit is reached, sharing the limit between variants,
+	// and the results found before the limit was reached are returned
+	requests := 0
+	search = func(ctx context.Context, title string, _ *string) ([]string, error) {
+		for {
+			err := utils.TakeRequest(ctx)
+			if err != nil {
+				return []string{title}, err
+			}
+			requests++
+		}
+	}
+	results, err = query.Search(context.Background(), "Brandon Sanderson - The Final Empire", nil, search)
+	require.NoError(t, err)
+	require.Equal(t, []string{"The Final Empire"}, results)
+	require.Equal(t, utils.SearchRequestLimit, requests)
+
+	// Contexts that are already limited keep their limit
+	requests = 0
+	ctx := utils.WithRequestLimit(context.Background(), 5)
+	_, err = query.Search(ctx, "Brandon Sanderson - The Final Empire", nil, search)
+	require.NoError(t, err)
+	require.Equal(t, 5, requests)
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ahobsonsayers/abs-tract/abs"
+	"github.com/ahobsonsayers/abs-tract/batch"
+	"github.com/samber/lo"
+)
+
+var errAbsNotConfigured = errors.New("audiobookshelf is not configured: ABS_URL and ABS_TOKEN must be set")
+
+// absMatchFunc creates a function matching audiobookshelf library items to books using a provider.
+func (s *server) absMatchFunc(provider BatchProvider, region string, minConfidence float64) abs.MatchFunc {
+	return func(ctx context.Context, item abs.LibraryItem) (*abs.Book, error) {
+		metadata := item.Media.Metadata
+
+		var author string
+		if len(metadata.Authors) != 0 {
+			author = metadata.Authors[0].Name
+		}
+
+		book, err := s.matchBook(ctx, provider, region, minConfidence, batch.Item{
+			Title:  metadata.Title,
+			Author: author,
+			ISBN:   metadata.ISBN,
+			ASIN:   metadata.ASIN,
+		})
+		if err != nil || book == nil {
+			return nil, err
+		}
+
+		return lo.ToPtr(bookMetadataToAbsBook(*book)), nil
+	}
+}
+
+func (s *server) ListAbsLibraries(
+	ctx context.Context,
+	_ ListAbsLibrariesRequestObject,
+) (ListAbsLibrariesResponseObject, error) {
+	if s.absClient == nil {
+		return ListAbsLibraries400JSONResponse{N400JSONResponse{Error: lo.ToPtr(errAbsNotConfigured.Error())}}, nil
+	}
+
+	libraries, err := s.absClient.Libraries(ctx)
+	if err != nil {
+		return ListAbsLibraries500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return ListAbsLibraries200JSONResponse{
+		Libraries: lo.Map(libraries, func(library abs.Library, _ int) AbsLibrary {
+			return AbsLibrary{Id: library.Id, Name: library.Name, MediaType: library.MediaType}
+		}),
+	}, nil
+}
+
+func (s *server) EnrichAbsLibrary(
+	ctx context.Context,
+	request EnrichAbsLibraryRequestObject,
+) (EnrichAbsLibraryResponseObject, error) {
+	if s.absClient == nil {
+		return EnrichAbsLibrary400JSONResponse{N400JSONResponse{Error: lo.ToPtr(errAbsNotConfigured.Error())}}, nil
+	}
+
+	region, err := s.matchRegion(request.Body.Provider, request.Body.Region)
+	if err != nil {
+		return EnrichAbsLibrary400JSONResponse{N400JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	// Request fields are validated against the schema, so should always parse
+	var fields []abs.Field
+	for _, field := range lo.FromPtr(request.Body.Fields) {
+		absField := abs.Fields.Parse(string(field))
+		if absField != nil {
+			fields = append(fields, *absField)
+		}
+	}
+
+	report, err := s.absClient.EnrichLibrary(
+		ctx,
+		request.Id,
+		s.absMatchFunc(request.Body.Provider, region, lo.FromPtr(request.Body.MinConfidence)),
+		abs.EnrichOptions{
+			Fields:    fields,
+			Overwrite: lo.FromPtr(request.Body.Overwrite),
+			DryRun:    lo.FromPtrOr(request.Body.DryRun, true),
+		},
+	)
+	if err != nil {
+		return EnrichAbsLibrary500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return EnrichAbsLibrary200JSONResponse(absReportToAbsEnrichReport(report)), nil
+}
+
+func bookMetadataToAbsBook(book BookMetadata) abs.Book {
+	var authors []string
+	if lo.FromPtr(book.Author) != "" {
+		authors = []string{*book.Author}
+	}
+
+	series := lo.Map(lo.FromPtr(book.Series), func(series SeriesMetadata, _ int) abs.Series {
+		return abs.Series{Name: series.Series, Sequence: lo.FromPtr(series.Sequence)}
+	})
+
+	return abs.Book{
+		Title:         book.Title,
+		Subtitle:      lo.FromPtr(book.Subtitle),
+		Authors:       authors,
+		Series:        series,
+		Genres:        lo.FromPtr(book.Genres),
+		Tags:          lo.FromPtr(book.Tags),
+		PublishedYear: lo.FromPtr(book.PublishedYear),
+		Publisher:     lo.FromPtr(book.Publisher),
+		Description:   lo.FromPtr(book.Description),
+		ISBN:          lo.FromPtr(book.Isbn),
+		ASIN:          lo.FromPtr(book.Asin),
+		Language:      lo.FromPtr(book.Language),
+		Cover:         lo.FromPtr(book.Cover),
+	}
+}
+
+func absReportToAbsEnrichReport(report abs.Report) AbsEnrichReport {
+	items := make([]AbsEnrichItem, 0, len(report.Items))
+	for _, itemReport := range report.Items {
+		changes := lo.Map(itemReport.Changes, func(change abs.Change, _ int) AbsChange {
+			return AbsChange{Field: AbsField(change.Field.Value), Old: change.Old, New: change.New}
+		})
+
+		items = append(items, AbsEnrichItem{
+			Id:      itemReport.ItemId,
+			Title:   itemReport.Title,
+			Matched: itemReport.Matched,
+			Updated: itemReport.Updated,
+			Changes: changes,
+			Error:   lo.EmptyableToPtr(itemReport.Error),
+		})
+	}
+
+	return AbsEnrichReport{
+		LibraryId: report.LibraryId,
+		DryRun:    report.DryRun,
+		Total:     len(report.Items),
+		Matched:   lo.CountBy(report.Items, func(item abs.ItemReport) bool { return item.Matched }),
+		Updated:   lo.CountBy(report.Items, func(item abs.ItemReport) bool { return item.Updated }),
+		Failed:    lo.CountBy(report.Items, func(item abs.ItemReport) bool { return item.Error != "" }),
+		Items:     items,
+	}
+}
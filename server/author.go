@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/ahobsonsayers/abs-tract/description"
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+func (s *server) getGoodreadsAuthor(
+	ctx context.Context,
+	authorId string,
+	descriptionFormat description.Format,
+) (AuthorDetails, error) {
+	goodreadsAuthor, err := s.goodreadsClient.GetAuthor(ctx, authorId)
+	if err != nil {
+		return AuthorDetails{}, err
+	}
+
+	return goodreadsAuthorToAuthorDetails(goodreadsAuthor, descriptionFormat), nil
+}
+
+func (s *server) listGoodreadsAuthorBooks(
+	ctx context.Context,
+	authorId string,
+	page int,
+	descriptionFormat description.Format,
+) (AuthorBooks, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	goodreadsAuthorBooks, err := s.goodreadsClient.ListAuthorBooks(ctx, authorId, page)
+	if err != nil {
+		return AuthorBooks{}, err
+	}
+
+	books := make([]BookMetadata, 0, len(goodreadsAuthorBooks.Books))
+	for _, goodreadsEdition := range goodreadsAuthorBooks.Books {
+		book := goodreadsEditionToBookMetadata(
+			goodreadsEdition,
+			goodreadsAuthorBooks.Author.Name,
+			descriptionFormat,
+		)
+		books = append(books, book)
+	}
+	s.processBookCovers(ctx, cover.ProviderGoodreads, books)
+
+	return AuthorBooks{
+		Page:  page,
+		Total: goodreadsAuthorBooks.Total,
+		Books: books,
+	}, nil
+}
+
+func goodreadsAuthorToAuthorDetails(
+	goodreadsAuthor goodreads.Author,
+	descriptionFormat description.Format,
+) AuthorDetails {
+	var biography *string
+	if goodreadsAuthor.Biography != "" {
+		biography = lo.ToPtr(description.Convert(goodreadsAuthor.BiographyHTML, descriptionFormat))
+	}
+
+	var photoUrl *string
+	if goodreadsAuthor.PhotoURL() != "" {
+		photoUrl = lo.ToPtr(goodreadsAuthor.PhotoURL())
+	}
+
+	var link *string
+	if goodreadsAuthor.Link != "" {
+		link = lo.ToPtr(goodreadsAuthor.Link)
+	}
+
+	var bornDate *openapi_types.Date
+	if goodreadsAuthor.BirthDate() != nil {
+		bornDate = &openapi_types.Date{Time: *goodreadsAuthor.BirthDate()}
+	}
+
+	var diedDate *openapi_types.Date
+	if goodreadsAuthor.DeathDate() != nil {
+		diedDate = &openapi_types.Date{Time: *goodreadsAuthor.DeathDate()}
+	}
+
+	var hometown *string
+	if goodreadsAuthor.Hometown != "" {
+		hometown = lo.ToPtr(goodreadsAuthor.Hometown)
+	}
+
+	return AuthorDetails{
+		Id:         goodreadsAuthor.Id,
+		Name:       goodreadsAuthor.Name,
+		Biography:  biography,
+		Photo:      photoUrl,
+		Link:       link,
+		BornDate:   bornDate,
+		DiedDate:   diedDate,
+		Hometown:   hometown,
+		WorksCount: lo.ToPtr(goodreadsAuthor.WorksCount),
+	}
+}
+
+func goodreadsEditionToBookMetadata(
+	goodreadsEdition goodreads.Edition,
+	author string,
+	descriptionFormat description.Format,
+) BookMetadata {
+	var subtitle *string
+	if goodreadsEdition.Subtitle() != "" {
+		subtitle = lo.ToPtr(goodreadsEdition.Subtitle())
+	}
+
+	var publicationYear *string
+	if goodreadsEdition.PublicationYear != 0 {
+		publicationYear = lo.ToPtr(strconv.Itoa(goodreadsEdition.PublicationYear))
+	}
+
+	var publicationDate *string
+	if goodreadsEdition.PublicationDate() != "" {
+		publicationDate = lo.ToPtr(goodreadsEdition.PublicationDate())
+	}
+
+	var imageUrl *string
+	if goodreadsEdition.ImageURL != "" {
+		imageUrl = lo.ToPtr(goodreadsEdition.ImageURL)
+	}
+
+	var editionDescription *string
+	if goodreadsEdition.Description != "" {
+		editionDescription = lo.ToPtr(description.Convert(goodreadsEdition.DescriptionHTML, descriptionFormat))
+	}
+
+	var publisher *string
+	if goodreadsEdition.Publisher != "" {
+		publisher = lo.ToPtr(goodreadsEdition.Publisher)
+	}
+
+	var language *string
+	if goodreadsEdition.Language != "" {
+		language = lo.ToPtr(goodreadsEdition.Language)
+	}
+
+	return BookMetadata{
+		Title:         goodreadsEdition.Title(),
+		Subtitle:      subtitle,
+		Author:        lo.EmptyableToPtr(author),
+		PublishedYear: publicationYear,
+		PublishedDate: publicationDate,
+		Isbn:          goodreadsEdition.ISBN,
+		Cover:         imageUrl,
+		Description:   editionDescription,
+		Publisher:     publisher,
+		Language:      language,
+		// Only the publication date of the edition is known
+		EditionPublishedDate: publicationDate,
+	}
+}
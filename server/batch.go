@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ahobsonsayers/abs-tract/batch"
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/samber/lo"
+)
+
+// matchBatchItem matches an item of a batch job to a book using the provider of the job.
+func (s *server) matchBatchItem(ctx context.Context, job batch.Job, item batch.Item) (json.RawMessage, error) {
+	book, err := s.matchBook(ctx, BatchProvider(job.Provider), job.Region, job.MinConfidence, item)
+	if err != nil || book == nil {
+		return nil, err
+	}
+	return json.Marshal(book)
+}
+
+// matchBook matches an item to the book most likely to be it, using a provider. Items are searched for
+// by title and author, or by isbn or asin if they have no title. A book with the same isbn or asin as
+// the item is preferred, otherwise the book with the highest confidence is matched.
+// If the item has no match, nil is returned.
+func (s *server) matchBook(
+	ctx context.Context,
+	provider BatchProvider,
+	region string,
+	minConfidence float64,
+	item batch.Item,
+) (*BookMetadata, error) {
+	itemQuery := lo.CoalesceOrEmpty(item.Title, item.ISBN, item.ASIN)
+	if itemQuery == "" {
+		return nil, errors.New("item has no title, isbn or asin")
+	}
+	author := lo.EmptyableToPtr(item.Author)
+
+	options := searchOptions{
+		query:             query.Parse(itemQuery, item.Author),
+		limit:             defaultSearchLimit,
+		languages:         s.languages,
+		includeEditions:   true,
+		minConfidence:     minConfidence,
+		descriptionFormat: s.descriptionFormat,
+	}
+
+	var books []BookMetadata
+	var err error
+	switch provider {
+	case BatchProviderGoodreads:
+		books, err = s.searchGoodreadsBooks(ctx, itemQuery, author, options)
+	case BatchProviderKindle:
+		books, err = s.searchKindleBooks(ctx, SearchKindleParamsRegion(region), itemQuery, author, options)
+	default:
+		return nil, fmt.Errorf("invalid provider: %s", provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(books) == 0 {
+		return nil, nil
+	}
+
+	book, ok := lo.Find(books, func(book BookMetadata) bool {
+		return (item.ISBN != "" && lo.FromPtr(book.Isbn) == item.ISBN) ||
+			(item.ASIN != "" && lo.FromPtr(book.Asin) == item.ASIN)
+	})
+	if !ok {
+		// Books are ordered by relevance, so the most relevant book is kept if confidences are equal
+		book = lo.MaxBy(books, func(book, maxBook BookMetadata) bool {
+			return lo.FromPtr(book.Confidence) > lo.FromPtr(maxBook.Confidence)
+		})
+	}
+
+	return &book, nil
+}
+
+// matchRegion gets the region books are matched in using a provider. Only kindle has regions,
+// defaulting to the us region. Will return an error if the region is invalid.
+func (s *server) matchRegion(provider BatchProvider, region *string) (string, error) {
+	if provider != BatchProviderKindle {
+		return "", nil
+	}
+
+	kindleRegion := lo.FromPtrOr(region, string(SearchKindleParamsRegionUs))
+
+	// Create the client of the region upfront, so an invalid region fails the request
+	_, err := s.kindleClient(kindleRegion)
+	if err != nil {
+		return "", err
+	}
+
+	return kindleRegion, nil
+}
+
+func (s *server) MatchBatch(
+	_ context.Context,
+	request MatchBatchRequestObject,
+) (MatchBatchResponseObject, error) {
+	job := batch.Job{
+		Provider:      string(request.Body.Provider),
+		MinConfidence: lo.FromPtr(request.Body.MinConfidence),
+		Items: lo.Map(request.Body.Items, func(item BatchItem, _ int) batch.Item {
+			return batch.Item{
+				Title:  lo.FromPtr(item.Title),
+				Author: lo.FromPtr(item.Author),
+				ISBN:   lo.FromPtr(item.Isbn),
+				ASIN:   lo.FromPtr(item.Asin),
+			}
+		}),
+	}
+
+	region, err := s.matchRegion(request.Body.Provider, request.Body.Region)
+	if err != nil {
+		return MatchBatch400JSONResponse{N400JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+	job.Region = region
+
+	progress, err := s.batchQueue.Submit(job)
+	if err != nil {
+		return MatchBatch500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return MatchBatch202JSONResponse(batchProgressToBatchJob(progress)), nil
+}
+
+func (s *server) GetBatch(
+	_ context.Context,
+	request GetBatchRequestObject,
+) (GetBatchResponseObject, error) {
+	progress, err := s.batchQueue.Progress(request.Id)
+	if err != nil {
+		return GetBatch404JSONResponse{N404JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return GetBatch200JSONResponse(batchProgressToBatchJob(progress)), nil
+}
+
+func (s *server) GetBatchResults(
+	ctx context.Context,
+	request GetBatchResultsRequestObject,
+) (GetBatchResultsResponseObject, error) {
+	_, err := s.batchQueue.Progress(request.Id)
+	if err != nil {
+		return GetBatchResults404JSONResponse{N404JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return batchResultsResponse{
+		ctx:    ctx,
+		queue:  s.batchQueue,
+		id:     request.Id,
+		follow: lo.FromPtrOr(request.Params.Follow, true),
+	}, nil
+}
+
+// batchResultsResponse streams the results of a batch job as newline delimited json,
+// flushing each result as it is written so clients receive results as items are matched.
+type batchResultsResponse struct {
+	ctx    context.Context
+	queue  *batch.Queue
+	id     string
+	follow bool
+}
+
+func (r batchResultsResponse) VisitGetBatchResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	// Results are encoded as they are stored, which matches the BatchResult schema
+	err := r.queue.Results(r.ctx, r.id, r.follow, func(result batch.Result) error {
+		err := encoder.Encode(result)
+		if err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil && r.ctx.Err() != nil {
+		// The client disconnected
+		return nil
+	}
+
+	return err
+}
+
+func batchProgressToBatchJob(progress batch.Progress) BatchJob {
+	return BatchJob{
+		Id:          progress.Id,
+		Provider:    BatchProvider(progress.Provider),
+		Status:      BatchJobStatus(progress.Status.Value),
+		Total:       progress.Total,
+		Completed:   progress.Completed,
+		Matched:     progress.Matched,
+		Failed:      progress.Failed,
+		CreatedAt:   progress.CreatedAt,
+		CompletedAt: progress.CompletedAt,
+	}
+}
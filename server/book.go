@@ -2,64 +2,146 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"strconv"
+	"sync"
+	"time"
 
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/ahobsonsayers/abs-tract/description"
 	"github.com/ahobsonsayers/abs-tract/goodreads"
 	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/ahobsonsayers/abs-tract/query"
 	"github.com/samber/lo"
+	"golang.org/x/text/language"
 )
 
-func searchGoodreadsBooks(ctx context.Context, title string, author *string) ([]BookMetadata, error) {
-	goodreadsBooks, err := goodreads.DefaultClient.SearchBooks(ctx, title, author)
+func (s *server) searchGoodreadsBooks(
+	ctx context.Context,
+	title string,
+	author *string,
+	options searchOptions,
+) ([]BookMetadata, error) {
+	goodreadsBooks, err := query.Search(
+		ctx, title, author,
+		func(ctx context.Context, title string, author *string) ([]goodreads.Book, error) {
+			return s.goodreadsClient.SearchBooks(ctx, title, author)
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	// Limit number of books to 20
-	if len(goodreadsBooks) > 20 {
-		goodreadsBooks = goodreadsBooks[:20]
-	}
+	// Localising books requires requests to goodreads, so books are localised in batches,
+	// only localising as many books as are needed to fill the requested page of books
+	books := make([]BookMetadata, 0, min(len(goodreadsBooks), options.offset+options.limit))
+	for len(goodreadsBooks) != 0 && options.needed(books) != 0 {
+		batchSize := min(len(goodreadsBooks), options.needed(books))
+		batch := goodreadsBooks[:batchSize]
+		goodreadsBooks = goodreadsBooks[batchSize:]
 
-	books := make([]BookMetadata, 0, len(goodreadsBooks))
-	for _, goodreadsBook := range goodreadsBooks {
-		book := goodreadsBookToBookMetadata(goodreadsBook)
-		books = append(books, book)
+		for _, localisedBook := range s.localiseGoodreadsBooks(ctx, batch, options.languages) {
+			if options.filterLanguages && !localisedBook.inLanguage {
+				continue
+			}
+			if !options.matchesFormat(goodreadsBookFormat(localisedBook.book.BestEdition.Format)) {
+				continue
+			}
+
+			book := GoodreadsBookToBookMetadata(localisedBook.book, options.descriptionFormat)
+			book.Confidence = lo.ToPtr(options.confidence(book))
+			if *book.Confidence < options.minConfidence {
+				continue
+			}
+			if s.config.RatingTags {
+				addRatingTag(&book)
+			}
+			books = append(books, book)
+		}
 	}
+	books = options.apply(books)
+	s.processBookCovers(ctx, cover.ProviderGoodreads, books)
 
 	return books, nil
 }
 
-func searchKindleBooks(
+type localisedGoodreadsBook struct {
+	book       goodreads.Book
+	inLanguage bool
+}
+
+// localiseGoodreadsBooks gets books in their edition in the most preferred of the languages.
+// Books that can not be localised are kept in their best edition.
+func (s *server) localiseGoodreadsBooks(
+	ctx context.Context,
+	goodreadsBooks []goodreads.Book,
+	languages []language.Tag,
+) []localisedGoodreadsBook {
+	localisedBooks := make([]localisedGoodreadsBook, len(goodreadsBooks))
+
+	var wg sync.WaitGroup
+	for idx, goodreadsBook := range goodreadsBooks {
+		wg.Add(1)
+
+		go func(goodreadsBook goodreads.Book, idx int) {
+			defer wg.Done()
+
+			localisedBook, inLanguage, err := s.goodreadsClient.LocaliseBook(ctx, goodreadsBook, languages)
+			if err != nil {
+				log.Printf("Failed to localise goodreads book %s: %s", goodreadsBook.BestEdition.Id, err)
+			}
+			localisedBooks[idx] = localisedGoodreadsBook{book: localisedBook, inLanguage: inLanguage}
+		}(goodreadsBook, idx)
+	}
+	wg.Wait()
+
+	return localisedBooks
+}
+
+func (s *server) searchKindleBooks(
 	ctx context.Context,
 	countryCode SearchKindleParamsRegion,
 	title string,
 	author *string,
+	options searchOptions,
 ) ([]BookMetadata, error) {
-	kindleClient, err := kindle.NewClient(lo.ToPtr(string(countryCode)))
+	kindleClient, err := s.kindleClient(string(countryCode))
 	if err != nil {
 		return nil, err
 	}
 
-	kindleBooks, err := kindleClient.Search(ctx, title, author)
+	kindleBooks, err := query.Search(
+		ctx, title, author,
+		func(ctx context.Context, title string, author *string) ([]kindle.Book, error) {
+			return kindleClient.SearchDepartment(ctx, options.kindleDepartment(), title, author)
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	// Limit number of books to 20
-	if len(kindleBooks) > 20 {
-		kindleBooks = kindleBooks[:20]
-	}
-
 	books := make([]BookMetadata, 0, len(kindleBooks))
 	for _, kindleBook := range kindleBooks {
-		book := kindleBookToBookMetadata(kindleBook)
+		if !options.matchesFormat(kindleBookFormat(kindleBook.Format)) {
+			continue
+		}
+
+		book := KindleBookToBookMetadata(kindleBook)
+		book.Confidence = lo.ToPtr(options.confidence(book))
+		if *book.Confidence < options.minConfidence {
+			continue
+		}
 		books = append(books, book)
 	}
+	books = options.apply(books)
+	s.processBookCovers(ctx, cover.ProviderKindle, books)
 
 	return books, nil
 }
 
-func goodreadsBookToBookMetadata(goodreadsBook goodreads.Book) BookMetadata {
+// GoodreadsBookToBookMetadata converts a goodreads book to the metadata of the book returned by the server.
+func GoodreadsBookToBookMetadata(goodreadsBook goodreads.Book, descriptionFormat description.Format) BookMetadata {
 	var subtitle *string
 	if goodreadsBook.BestEdition.Subtitle() != "" {
 		subtitle = lo.ToPtr(goodreadsBook.BestEdition.Subtitle())
@@ -77,6 +159,18 @@ func goodreadsBookToBookMetadata(goodreadsBook goodreads.Book) BookMetadata {
 		publicationYear = lo.ToPtr(strconv.Itoa(goodreadsBook.BestEdition.PublicationYear))
 	}
 
+	var publicationDate *string
+	if goodreadsBook.Work.PublicationDate() != "" {
+		publicationDate = lo.ToPtr(goodreadsBook.Work.PublicationDate())
+	} else if goodreadsBook.BestEdition.PublicationDate() != "" {
+		publicationDate = lo.ToPtr(goodreadsBook.BestEdition.PublicationDate())
+	}
+
+	var editionPublicationDate *string
+	if goodreadsBook.BestEdition.PublicationDate() != "" {
+		editionPublicationDate = lo.ToPtr(goodreadsBook.BestEdition.PublicationDate())
+	}
+
 	var imageUrl *string
 	if goodreadsBook.BestEdition.ImageURL != "" {
 		imageUrl = lo.ToPtr(goodreadsBook.BestEdition.ImageURL)
@@ -97,29 +191,66 @@ func goodreadsBookToBookMetadata(goodreadsBook goodreads.Book) BookMetadata {
 		Subtitle:      subtitle,
 		Author:        author,
 		PublishedYear: publicationYear,
+		PublishedDate: publicationDate,
+		Rating:        lo.ToPtr(goodreadsWorkToRating(goodreadsBook.Work)),
 		// Edition Fields
-		Isbn:        goodreadsBook.BestEdition.ISBN,
-		Cover:       imageUrl,
-		Description: &goodreadsBook.BestEdition.Description,
-		Publisher:   &goodreadsBook.BestEdition.Publisher,
-		Language:    &goodreadsBook.BestEdition.Language,
+		Isbn:                 goodreadsBook.BestEdition.ISBN,
+		Cover:                imageUrl,
+		Description:          lo.ToPtr(description.Convert(goodreadsBook.BestEdition.DescriptionHTML, descriptionFormat)),
+		Publisher:            &goodreadsBook.BestEdition.Publisher,
+		Language:             &goodreadsBook.BestEdition.Language,
+		EditionPublishedDate: editionPublicationDate,
 		// Other fields
 		Series: &series,
 		Genres: lo.ToPtr([]string(goodreadsBook.Genres)),
+		Tags:   lo.ToPtr([]string(goodreadsBook.Tags)),
+	}
+}
+
+func goodreadsWorkToRating(goodreadsWork goodreads.Work) Rating {
+	starRatings := goodreadsWork.StarRatings()
+	return Rating{
+		Average:      goodreadsWork.AverageRating(),
+		Count:        goodreadsWork.RatingsCount,
+		ReviewsCount: lo.ToPtr(goodreadsWork.ReviewsCount),
+		Distribution: &RatingDistribution{
+			OneStar:   starRatings.Stars(1),
+			TwoStar:   starRatings.Stars(2),
+			ThreeStar: starRatings.Stars(3),
+			FourStar:  starRatings.Stars(4),
+			FiveStar:  starRatings.Stars(5),
+		},
 	}
 }
 
-func kindleBookToBookMetadata(kindleBook kindle.Book) BookMetadata {
+// addRatingTag adds the average rating of a book to its tags e.g. "Rating 4.3".
+// No tag is added if the book has no ratings.
+func addRatingTag(book *BookMetadata) {
+	if book.Rating == nil || book.Rating.Count == 0 {
+		return
+	}
+
+	ratingTag := fmt.Sprintf("Rating %.1f", book.Rating.Average)
+	book.Tags = lo.ToPtr(append(lo.FromPtr(book.Tags), ratingTag))
+}
+
+// KindleBookToBookMetadata converts a kindle book to the metadata of the book returned by the server.
+func KindleBookToBookMetadata(kindleBook kindle.Book) BookMetadata {
+	// Kindle only knows the publish date of the edition
 	var publishedYear *string
+	var publishedDate *string
 	if kindleBook.PublishDate != nil {
 		publishedYear = lo.ToPtr(strconv.Itoa(kindleBook.PublishDate.Year()))
+		publishedDate = lo.ToPtr(kindleBook.PublishDate.Format(time.DateOnly))
 	}
 
 	return BookMetadata{
-		Asin:          &kindleBook.ASIN,
-		Title:         kindleBook.Title,
-		Author:        &kindleBook.Author,
-		Cover:         &kindleBook.Cover,
-		PublishedYear: publishedYear,
+		Asin:                 &kindleBook.ASIN,
+		Title:                kindleBook.Title,
+		Author:               &kindleBook.Author,
+		Cover:                &kindleBook.Cover,
+		PublishedYear:        publishedYear,
+		PublishedDate:        publishedDate,
+		EditionPublishedDate: publishedDate,
 	}
 }
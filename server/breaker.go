@@ -0,0 +1,44 @@
+package server
+
+import (
+	"errors"
+	"log"
+
+	"github.com/ahobsonsayers/abs-tract/config"
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
+)
+
+// newCircuitBreaker creates a circuit breaker for requests to a provider, logging its state changes.
+// If circuit breakers are disabled, nil is returned.
+func newCircuitBreaker(cfg config.Config, name string) *utils.CircuitBreaker {
+	if cfg.CircuitBreakerThreshold == 0 {
+		return nil
+	}
+
+	breaker := utils.NewCircuitBreaker(name, cfg.CircuitBreakerThreshold, cfg.CircuitBreakerTimeout)
+	breaker.OnStateChange = func(name string, from, to utils.CircuitState) {
+		log.Printf("Circuit breaker for %s changed from %s to %s", name, from.Value, to.Value)
+	}
+
+	return breaker
+}
+
+// unavailableResponse creates a response for an error caused by an open circuit breaker.
+// Returns false if the error was not caused by an open circuit breaker.
+func unavailableResponse(err error) (N503JSONResponse, bool) {
+	var circuitOpenErr *utils.CircuitOpenError
+	if !errors.As(err, &circuitOpenErr) {
+		return N503JSONResponse{}, false
+	}
+
+	return N503JSONResponse{
+		Error: lo.ToPtr(circuitOpenErr.Error()),
+		CircuitBreaker: &CircuitBreaker{
+			Name:     circuitOpenErr.Name,
+			State:    CircuitBreakerState(circuitOpenErr.State.Value),
+			Failures: circuitOpenErr.Failures,
+			RetryAt:  circuitOpenErr.RetryAt,
+		},
+	}, true
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ahobsonsayers/abs-tract/calibre"
+	"github.com/ahobsonsayers/abs-tract/description"
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
+)
+
+var errCalibreNotConfigured = errors.New("calibre is not configured: CALIBRE_LIBRARY_DIR must be set")
+
+func (s *server) SearchCalibre(
+	ctx context.Context,
+	request SearchCalibreRequestObject,
+) (SearchCalibreResponseObject, error) {
+	if s.calibreLibrary == nil {
+		return SearchCalibre400JSONResponse{N400JSONResponse{Error: lo.ToPtr(errCalibreNotConfigured.Error())}}, nil
+	}
+
+	matches, err := s.calibreLibrary.Search(ctx, request.Params.Query, request.Params.Author)
+	if err != nil {
+		return SearchCalibre500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	options := searchOptions{
+		limit:             lo.FromPtrOr(request.Params.Limit, defaultSearchLimit),
+		offset:            lo.FromPtr(request.Params.Offset),
+		includeEditions:   true,
+		minConfidence:     lo.FromPtr(request.Params.MinConfidence),
+		descriptionFormat: s.descriptionFormatOrDefault(request.Params.DescriptionFormat),
+	}
+
+	books := make([]BookMetadata, 0, len(matches))
+	for _, match := range matches {
+		if match.Confidence < options.minConfidence {
+			continue
+		}
+		book := calibreBookToBookMetadata(match.Book, options.descriptionFormat)
+		book.Cover = s.calibreCoverURL(match.Book)
+		book.Confidence = lo.ToPtr(match.Confidence)
+		books = append(books, book)
+	}
+	books = options.apply(books)
+
+	return SearchCalibre200JSONResponse{N200JSONResponse{Matches: &books}}, nil
+}
+
+func (s *server) GetCalibreCover(
+	ctx context.Context,
+	request GetCalibreCoverRequestObject,
+) (GetCalibreCoverResponseObject, error) {
+	if s.calibreLibrary == nil {
+		return GetCalibreCover400JSONResponse{N400JSONResponse{Error: lo.ToPtr(errCalibreNotConfigured.Error())}}, nil
+	}
+
+	coverPath, err := s.calibreLibrary.CoverPath(ctx, request.Id)
+	if err != nil {
+		if errors.Is(err, calibre.ErrBookNotFound) {
+			return GetCalibreCover404JSONResponse{N404JSONResponse{Error: lo.ToPtr("cover not found")}}, nil
+		}
+		return GetCalibreCover500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	coverFile, err := os.Open(coverPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return GetCalibreCover404JSONResponse{N404JSONResponse{Error: lo.ToPtr("cover not found")}}, nil
+		}
+		return GetCalibreCover500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	info, err := coverFile.Stat()
+	if err != nil {
+		coverFile.Close()
+		return GetCalibreCover500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	// The file is closed once the response is written
+	return GetCalibreCover200ImagejpegResponse{Body: coverFile, ContentLength: info.Size()}, nil
+}
+
+// calibreCoverURL gets the url of the cover of a calibre book, served by the server.
+// Covers are only returned if a public url is configured, as the url must be reachable by clients.
+func (s *server) calibreCoverURL(book calibre.Book) *string {
+	if s.config.PublicURL == "" || !book.HasCover {
+		return nil
+	}
+	return lo.ToPtr(fmt.Sprintf("%s/calibre/books/%d/cover", s.config.PublicURL, book.Id))
+}
+
+func calibreBookToBookMetadata(calibreBook calibre.Book, descriptionFormat description.Format) BookMetadata {
+	book := BookMetadata{
+		Title:     calibreBook.Title,
+		Author:    lo.EmptyableToPtr(strings.Join(calibreBook.Authors, ", ")),
+		Publisher: lo.EmptyableToPtr(calibreBook.Publisher),
+		Isbn:      lo.EmptyableToPtr(calibreBook.ISBN()),
+		Asin:      lo.EmptyableToPtr(calibreBook.ASIN()),
+	}
+
+	if calibreBook.PublishedDate != "" {
+		book.PublishedYear = lo.ToPtr(calibreBook.PublishedDate[:4])
+		book.PublishedDate = &calibreBook.PublishedDate
+		book.EditionPublishedDate = &calibreBook.PublishedDate
+	}
+
+	if calibreBook.Description != "" {
+		book.Description = lo.ToPtr(description.Convert(calibreBook.Description, descriptionFormat))
+	}
+
+	if calibreBook.Series != nil {
+		book.Series = &[]SeriesMetadata{{
+			Series:   calibreBook.Series.Name,
+			Sequence: lo.ToPtr(strconv.FormatFloat(calibreBook.Series.Index, 'f', -1, 64)),
+		}}
+	}
+
+	if len(calibreBook.Tags) != 0 {
+		book.Tags = &calibreBook.Tags
+	}
+
+	// Languages are returned as their name, as with other providers
+	if len(calibreBook.Languages) != 0 {
+		book.Language = &calibreBook.Languages[0]
+		tag, err := utils.ParseLanguage(calibreBook.Languages[0])
+		if err == nil && utils.LanguageName(tag) != "" {
+			book.Language = lo.ToPtr(utils.LanguageName(tag))
+		}
+	}
+
+	return book
+}
@@ -0,0 +1,386 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/ahobsonsayers/abs-tract/config"
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
+)
+
+// newCoverClient creates the client covers are fetched using.
+func newCoverClient(cfg config.Config, retryPolicy utils.RetryPolicy) (*cover.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.ProxyURL != "" {
+		proxyUrl, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	httpClient := &http.Client{
+		Transport: utils.NewRetryTransport(transport, retryPolicy),
+		Timeout:   cfg.RequestTimeout,
+	}
+
+	options := []cover.ClientOption{cover.WithHTTPClient(httpClient)}
+	if cfg.CoverCacheDir != "" {
+		options = append(
+			options,
+			cover.WithCacheDir(cfg.CoverCacheDir),
+			cover.WithMaxCacheSize(int64(cfg.CoverCacheSize)<<20),
+		)
+	}
+
+	return cover.NewClient(options...)
+}
+
+// newCoverQuery creates the query parameters added to proxied cover urls, so proxied covers
+// are transformed using the cover config.
+func newCoverQuery(cfg config.Config) (url.Values, error) {
+	coverQuery := url.Values{}
+	if cfg.CoverMaxWidth > 0 {
+		coverQuery.Set("width", strconv.Itoa(cfg.CoverMaxWidth))
+	}
+	if cfg.CoverMaxHeight > 0 {
+		coverQuery.Set("height", strconv.Itoa(cfg.CoverMaxHeight))
+	}
+	if cfg.CoverFormat != "" {
+		if cover.Formats.Parse(cfg.CoverFormat) == nil {
+			return nil, fmt.Errorf("invalid cover format: %s", cfg.CoverFormat)
+		}
+		coverQuery.Set("format", cfg.CoverFormat)
+	}
+	return coverQuery, nil
+}
+
+// coverURL gets the url of a cover to return in responses. If a public url is configured,
+// the url of the cover proxied through the server is returned, otherwise the url is unchanged.
+func (s *server) coverURL(provider cover.Provider, coverUrl *string) *string {
+	if s.config.PublicURL == "" || coverUrl == nil || *coverUrl == "" {
+		return coverUrl
+	}
+
+	coverId, err := cover.ID(provider, *coverUrl)
+	if err != nil {
+		return coverUrl
+	}
+
+	proxiedCoverUrl := fmt.Sprintf("%s/covers/%s/%s", s.config.PublicURL, provider.Value, coverId)
+	if len(s.coverQuery) != 0 {
+		proxiedCoverUrl += "?" + s.coverQuery.Encode()
+	}
+
+	return &proxiedCoverUrl
+}
+
+// processBookCovers selects the best cover of books if cover analysis is enabled, then replaces
+// the cover urls of books with proxied cover urls if a public url is configured.
+func (s *server) processBookCovers(ctx context.Context, provider cover.Provider, books []BookMetadata) {
+	coverProviders := make([]cover.Provider, len(books))
+	for idx := range books {
+		coverProviders[idx] = provider
+	}
+
+	if s.config.CoverAnalysis {
+		var wg sync.WaitGroup
+		for idx := range books {
+			wg.Add(1)
+
+			go func(idx int) {
+				defer wg.Done()
+				coverProviders[idx] = s.selectBestCover(ctx, provider, &books[idx])
+			}(idx)
+		}
+
+		wg.Wait()
+	}
+
+	for idx := range books {
+		books[idx].Cover = s.coverURL(coverProviders[idx], books[idx].Cover)
+	}
+}
+
+// selectBestCover sets the cover of a book from a provider to the best of its candidate covers,
+// returning the provider of the selected cover. Candidate covers are the cover of the book, the cover
+// of its isbn on open library, and the covers of the book on the other of goodreads and kindle.
+// If no candidate cover is valid, the cover of the book is kept unless it was rejected.
+func (s *server) selectBestCover(ctx context.Context, provider cover.Provider, book *BookMetadata) cover.Provider {
+	var candidates []cover.Candidate
+	if lo.FromPtr(book.Cover) != "" {
+		candidates = append(candidates, cover.Candidate{Provider: provider, URL: *book.Cover})
+	}
+	if lo.FromPtr(book.Isbn) != "" {
+		candidates = append(candidates, cover.Candidate{
+			Provider: cover.ProviderOpenLibrary,
+			URL:      cover.OpenLibraryURL(*book.Isbn),
+		})
+	}
+	candidates = append(candidates, s.crossSourceCandidates(ctx, provider, book)...)
+	if len(candidates) == 0 {
+		return provider
+	}
+
+	bestCover, err := s.coverClient.Best(ctx, candidates...)
+	if err != nil {
+		if errors.Is(err, cover.ErrRejected) {
+			book.Cover = nil
+		}
+		return provider
+	}
+
+	book.Cover = &bestCover.URL
+	book.CoverWidth = &bestCover.Width
+	book.CoverHeight = &bestCover.Height
+
+	return bestCover.Provider
+}
+
+// crossSourceCandidates gets the candidate covers of a book from a provider on the other of goodreads
+// and kindle, looking the book up by its isbn or asin. Failing to look up the book is not an error,
+// as the book still has its other candidate covers.
+func (s *server) crossSourceCandidates(
+	ctx context.Context,
+	provider cover.Provider,
+	book *BookMetadata,
+) []cover.Candidate {
+	switch provider {
+	case cover.ProviderGoodreads:
+		if lo.FromPtr(book.Isbn) == "" {
+			return nil
+		}
+
+		kindleClient, err := s.kindleClient(string(kindleLanguagesRegion(s.languages)))
+		if err != nil {
+			log.Printf("Failed to create kindle client: %s", err)
+			return nil
+		}
+
+		kindleBooks, err := kindleClient.Search(ctx, *book.Isbn, nil)
+		if err != nil {
+			log.Printf("Failed to search kindle for isbn %s: %s", *book.Isbn, err)
+			return nil
+		}
+		if len(kindleBooks) == 0 {
+			return nil
+		}
+
+		candidates := make([]cover.Candidate, 0, len(kindleBooks[0].Covers))
+		for _, coverUrl := range kindleBooks[0].Covers {
+			candidates = append(candidates, cover.Candidate{Provider: cover.ProviderKindle, URL: coverUrl})
+		}
+		return candidates
+
+	case cover.ProviderKindle:
+		isbn := lo.FromPtr(book.Isbn)
+		if isbn == "" {
+			isbn = lo.FromPtr(book.Asin)
+		}
+		if isbn == "" {
+			return nil
+		}
+
+		goodreadsBook, err := s.goodreadsClient.GetBookByISBN(ctx, isbn)
+		if err != nil {
+			log.Printf("Failed to get goodreads book by isbn %s: %s", isbn, err)
+			return nil
+		}
+		if goodreadsBook.BestEdition.ImageURL == "" {
+			return nil
+		}
+
+		return []cover.Candidate{{Provider: cover.ProviderGoodreads, URL: goodreadsBook.BestEdition.ImageURL}}
+	}
+
+	return nil
+}
+
+func (s *server) GetCover(
+	ctx context.Context,
+	request GetCoverRequestObject,
+) (GetCoverResponseObject, error) {
+	provider := cover.Providers.Parse(string(request.Provider))
+	if provider == nil {
+		return GetCover400JSONResponse{N400JSONResponse{Error: lo.ToPtr("invalid provider")}}, nil
+	}
+
+	options := cover.Options{
+		MaxWidth:      lo.FromPtr(request.Params.Width),
+		MaxHeight:     lo.FromPtr(request.Params.Height),
+		StripMetadata: lo.FromPtr(request.Params.Strip),
+	}
+	if request.Params.Format != nil {
+		options.Format = cover.Formats.Parse(string(*request.Params.Format))
+	}
+
+	coverImage, err := s.coverClient.Get(ctx, *provider, request.Id, options)
+	if err != nil {
+		switch {
+		case errors.Is(err, cover.ErrInvalidID):
+			return GetCover400JSONResponse{N400JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+		case errors.Is(err, cover.ErrNotFound):
+			return GetCover404JSONResponse{N404JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+		}
+		return GetCover500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return GetCover200ImageResponse{
+		Body:          bytes.NewReader(coverImage.Data),
+		ContentType:   coverImage.ContentType,
+		ContentLength: int64(len(coverImage.Data)),
+	}, nil
+}
+
+func (s *server) ListGoodreadsCovers(
+	ctx context.Context,
+	request ListGoodreadsCoversRequestObject,
+) (ListGoodreadsCoversResponseObject, error) {
+	covers, err := s.listGoodreadsCovers(ctx, request.Params.Query, request.Params.Author)
+	if err != nil {
+		if response, ok := unavailableResponse(err); ok {
+			return ListGoodreadsCovers503JSONResponse{response}, nil
+		}
+		return ListGoodreadsCovers500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return ListGoodreadsCovers200JSONResponse{Covers: covers}, nil
+}
+
+func (s *server) ListKindleCovers(
+	ctx context.Context,
+	request ListKindleCoversRequestObject,
+) (ListKindleCoversResponseObject, error) {
+	covers, err := s.listKindleCovers(ctx, string(request.Region), request.Params.Query, request.Params.Author)
+	if err != nil {
+		if response, ok := unavailableResponse(err); ok {
+			return ListKindleCovers503JSONResponse{response}, nil
+		}
+		return ListKindleCovers500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return ListKindleCovers200JSONResponse{Covers: covers}, nil
+}
+
+func (s *server) listGoodreadsCovers(ctx context.Context, title string, author *string) ([]Cover, error) {
+	goodreadsBooks, err := query.Search(
+		ctx, title, author,
+		func(ctx context.Context, title string, author *string) ([]goodreads.Book, error) {
+			return s.goodreadsClient.SearchBooks(ctx, title, author)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit number of books to the default number of search results
+	if len(goodreadsBooks) > defaultSearchLimit {
+		goodreadsBooks = goodreadsBooks[:defaultSearchLimit]
+	}
+
+	// Covers of every edition of each book are candidates, best edition first
+	bookImageURLs := make([][]string, len(goodreadsBooks))
+	var wg sync.WaitGroup
+	for idx, goodreadsBook := range goodreadsBooks {
+		wg.Add(1)
+
+		go func(goodreadsBook goodreads.Book, idx int) {
+			defer wg.Done()
+			bookImageURLs[idx] = s.goodreadsEditionImageURLs(ctx, goodreadsBook)
+		}(goodreadsBook, idx)
+	}
+	wg.Wait()
+
+	imageURLs := lo.Uniq(lo.Flatten(bookImageURLs))
+	candidates := make([]cover.Candidate, 0, len(imageURLs))
+	for _, imageURL := range imageURLs {
+		candidates = append(candidates, cover.Candidate{Provider: cover.ProviderGoodreads, URL: imageURL})
+	}
+
+	return s.distinctCovers(ctx, candidates), nil
+}
+
+// goodreadsEditionImageURLs gets the cover urls of all the editions of the work of a book, best edition first.
+// Failing to get the editions is not an error, as the cover of the best edition can still be returned.
+func (s *server) goodreadsEditionImageURLs(ctx context.Context, goodreadsBook goodreads.Book) []string {
+	var imageURLs []string
+	if goodreadsBook.BestEdition.ImageURL != "" {
+		imageURLs = append(imageURLs, goodreadsBook.BestEdition.ImageURL)
+	}
+	if goodreadsBook.Work.Id == "" {
+		return imageURLs
+	}
+
+	editions, err := s.goodreadsClient.ListWorkEditions(ctx, goodreadsBook.Work.Id)
+	if err != nil {
+		log.Printf("Failed to list editions of goodreads work %s: %s", goodreadsBook.Work.Id, err)
+		return imageURLs
+	}
+
+	for _, edition := range editions {
+		if edition.ImageURL != "" {
+			imageURLs = append(imageURLs, edition.ImageURL)
+		}
+	}
+
+	return imageURLs
+}
+
+func (s *server) listKindleCovers(ctx context.Context, region string, title string, author *string) ([]Cover, error) {
+	kindleClient, err := s.kindleClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	kindleBooks, err := query.Search(
+		ctx, title, author,
+		func(ctx context.Context, title string, author *string) ([]kindle.Book, error) {
+			return kindleClient.Search(ctx, title, author)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limit number of books to the default number of search results
+	if len(kindleBooks) > defaultSearchLimit {
+		kindleBooks = kindleBooks[:defaultSearchLimit]
+	}
+
+	var candidates []cover.Candidate
+	for _, kindleBook := range kindleBooks {
+		for _, coverUrl := range kindleBook.Covers {
+			candidates = append(candidates, cover.Candidate{Provider: cover.ProviderKindle, URL: coverUrl})
+		}
+	}
+
+	return s.distinctCovers(ctx, candidates), nil
+}
+
+// distinctCovers gets the distinct covers of candidates, rejecting placeholders and small covers.
+func (s *server) distinctCovers(ctx context.Context, candidates []cover.Candidate) []Cover {
+	analyses := s.coverClient.Distinct(ctx, candidates...)
+
+	covers := make([]Cover, 0, len(analyses))
+	for _, analysis := range analyses {
+		covers = append(covers, Cover{
+			Url:    lo.FromPtr(s.coverURL(analysis.Provider, &analysis.URL)),
+			Width:  analysis.Width,
+			Height: analysis.Height,
+		})
+	}
+
+	return covers
+}
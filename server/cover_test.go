@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/config"
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGoodreadsCoverUrl = "https://i.gr-assets.com/images/S/compressed.photo.goodreads.com/books/1.jpg"
+	testKindleCoverUrl    = "https://m.media-amazon.com/images/I/61Ng-W9EhBL.jpg"
+)
+
+func TestSelectBestCoverCrossSource(t *testing.T) {
+	kindleServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("k") != "9780261103344" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`<div class="s-result-list"><div data-index="0" data-asin="B007978NPG">
+			<h2>The Hobbit</h2><a>Kindle Edition</a><div class="a-color-secondary">by J.R.R. Tolkien</div>
+			<img srcset="https://m.media-amazon.com/images/I/61Ng-W9EhBL._AC_UY218_.jpg 1x">
+		</div></div>`))
+	}))
+	defer kindleServer.Close()
+
+	// The kindle cover is larger than the goodreads cover, so is the best cover
+	coverServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Host {
+		case "i.gr-assets.com":
+			_, _ = w.Write(testCoverData(t, 120, 180))
+		case "m.media-amazon.com":
+			_, _ = w.Write(testCoverData(t, 400, 600))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer coverServer.Close()
+
+	s := newTestServer(t, config.Config{KindleURL: kindleServer.URL, CoverAnalysis: true}, coverServer)
+
+	book := BookMetadata{Cover: lo.ToPtr(testGoodreadsCoverUrl), Isbn: lo.ToPtr("9780261103344")}
+	provider := s.selectBestCover(context.Background(), cover.ProviderGoodreads, &book)
+	require.Equal(t, cover.ProviderKindle, provider)
+	require.Equal(t, testKindleCoverUrl, lo.FromPtr(book.Cover))
+	require.Equal(t, 400, lo.FromPtr(book.CoverWidth))
+	require.Equal(t, 600, lo.FromPtr(book.CoverHeight))
+}
+
+// newTestServer creates a server using a config whose covers are all fetched from a cover server
+func newTestServer(t *testing.T, cfg config.Config, coverServer *httptest.Server) *server {
+	strictServer, err := NewServer(cfg)
+	require.NoError(t, err)
+	s := strictServer.(*server)
+
+	coverServerUrl := lo.Must(url.Parse(coverServer.URL))
+	s.coverClient, err = cover.NewClient(cover.WithHTTPClient(&http.Client{
+		Transport: roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			request = request.Clone(request.Context())
+			request.Host = request.URL.Host
+			request.URL.Scheme = coverServerUrl.Scheme
+			request.URL.Host = coverServerUrl.Host
+			return http.DefaultTransport.RoundTrip(request)
+		}),
+	}))
+	require.NoError(t, err)
+
+	return s
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) { return f(request) }
+
+func testCoverData(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buffer bytes.Buffer
+	err := png.Encode(&buffer, img)
+	require.NoError(t, err)
+
+	return buffer.Bytes()
+}
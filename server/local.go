@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ahobsonsayers/abs-tract/local"
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
+)
+
+var errLocalLibraryNotConfigured = errors.New("local library is not configured: LOCAL_LIBRARY_DIRS must be set")
+
+func (s *server) SearchLocal(
+	ctx context.Context,
+	request SearchLocalRequestObject,
+) (SearchLocalResponseObject, error) {
+	if s.localLibrary == nil {
+		return SearchLocal400JSONResponse{N400JSONResponse{Error: lo.ToPtr(errLocalLibraryNotConfigured.Error())}}, nil
+	}
+
+	matches, err := s.localLibrary.Search(ctx, request.Params.Query, request.Params.Author)
+	if err != nil {
+		return SearchLocal500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	options := searchOptions{
+		limit:           lo.FromPtrOr(request.Params.Limit, defaultSearchLimit),
+		offset:          lo.FromPtr(request.Params.Offset),
+		includeEditions: true,
+		minConfidence:   lo.FromPtr(request.Params.MinConfidence),
+	}
+
+	books := make([]BookMetadata, 0, len(matches))
+	for _, match := range matches {
+		if match.Confidence < options.minConfidence {
+			continue
+		}
+		book := localBookToBookMetadata(match.Book)
+		book.Confidence = lo.ToPtr(match.Confidence)
+		books = append(books, book)
+	}
+	books = options.apply(books)
+
+	return SearchLocal200JSONResponse{N200JSONResponse{Matches: &books}}, nil
+}
+
+func localBookToBookMetadata(localBook local.Book) BookMetadata {
+	book := BookMetadata{
+		Title:         localBook.Title,
+		Subtitle:      lo.EmptyableToPtr(localBook.Subtitle),
+		Author:        lo.EmptyableToPtr(strings.Join(localBook.Authors, ", ")),
+		Narrator:      lo.EmptyableToPtr(strings.Join(localBook.Narrators, ", ")),
+		Publisher:     lo.EmptyableToPtr(localBook.Publisher),
+		PublishedYear: lo.EmptyableToPtr(localBook.PublishedYear),
+		PublishedDate: lo.EmptyableToPtr(localBook.PublishedYear),
+		Description:   lo.EmptyableToPtr(localBook.Description),
+		Isbn:          lo.EmptyableToPtr(localBook.ISBN),
+		Asin:          lo.EmptyableToPtr(localBook.ASIN),
+	}
+
+	if len(localBook.Genres) != 0 {
+		book.Genres = &localBook.Genres
+	}
+	if len(localBook.Series) != 0 {
+		book.Series = lo.ToPtr(lo.Map(localBook.Series, func(series local.Series, _ int) SeriesMetadata {
+			return SeriesMetadata{Series: series.Name, Sequence: lo.EmptyableToPtr(series.Sequence)}
+		}))
+	}
+
+	// Languages are returned as their name, as with other providers
+	if localBook.Language != "" {
+		book.Language = &localBook.Language
+		tag, err := utils.ParseLanguage(localBook.Language)
+		if err == nil && utils.LanguageName(tag) != "" {
+			book.Language = lo.ToPtr(utils.LanguageName(tag))
+		}
+	}
+
+	if localBook.Duration > 0 {
+		book.Duration = lo.ToPtr(int(localBook.Duration.Seconds()))
+	}
+
+	return book
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ahobsonsayers/abs-tract/opf"
+	"github.com/samber/lo"
+)
+
+func (s *server) GetGoodreadsBookOpf(
+	ctx context.Context,
+	request GetGoodreadsBookOpfRequestObject,
+) (GetGoodreadsBookOpfResponseObject, error) {
+	version, err := opfVersion(request.Params.Version)
+	if err != nil {
+		return GetGoodreadsBookOpf400JSONResponse{N400JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	goodreadsBook, err := s.goodreadsClient.GetBookById(ctx, request.Id)
+	if err != nil {
+		if response, ok := unavailableResponse(err); ok {
+			return GetGoodreadsBookOpf503JSONResponse{response}, nil
+		}
+		return GetGoodreadsBookOpf500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	opfXML, err := opf.Marshal(opf.FromGoodreadsBook(goodreadsBook), version)
+	if err != nil {
+		return GetGoodreadsBookOpf500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return GetGoodreadsBookOpf200ApplicationoebpsPackageXmlResponse{opfResponse(opfXML)}, nil
+}
+
+func (s *server) RenderOpf(
+	_ context.Context,
+	request RenderOpfRequestObject,
+) (RenderOpfResponseObject, error) {
+	version, err := opfVersion(request.Params.Version)
+	if err != nil {
+		return RenderOpf400JSONResponse{N400JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	opfXML, err := opf.Marshal(bookMetadataToOpfBook(*request.Body), version)
+	if err != nil {
+		return RenderOpf400JSONResponse{N400JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return RenderOpf200ApplicationoebpsPackageXmlResponse{opfResponse(opfXML)}, nil
+}
+
+// opfVersion parses the requested version of an OPF file, defaulting to the default version if unset.
+func opfVersion(version *OpfVersion) (opf.Version, error) {
+	if version == nil {
+		return opf.DefaultVersion, nil
+	}
+
+	opfVersion := opf.Versions.Parse(string(*version))
+	if opfVersion == nil {
+		return opf.Version{}, fmt.Errorf("invalid opf version: %s", *version)
+	}
+
+	return *opfVersion, nil
+}
+
+func opfResponse(opfXML []byte) OpfApplicationoebpsPackageXmlResponse {
+	return OpfApplicationoebpsPackageXmlResponse{
+		Body:          bytes.NewReader(opfXML),
+		ContentLength: int64(len(opfXML)),
+	}
+}
+
+func bookMetadataToOpfBook(book BookMetadata) opf.Book {
+	var creators []opf.Creator
+	if lo.FromPtr(book.Author) != "" {
+		creators = append(creators, opf.Creator{Name: *book.Author, Role: opf.RoleAuthor})
+	}
+	if lo.FromPtr(book.Narrator) != "" {
+		creators = append(creators, opf.Creator{Name: *book.Narrator, Role: opf.RoleNarrator})
+	}
+
+	series := lo.Map(lo.FromPtr(book.Series), func(series SeriesMetadata, _ int) opf.Series {
+		return opf.Series{Name: series.Series, Index: lo.FromPtr(series.Sequence)}
+	})
+
+	return opf.Book{
+		Title:         book.Title,
+		Subtitle:      lo.FromPtr(book.Subtitle),
+		Creators:      creators,
+		PublishedDate: lo.CoalesceOrEmpty(lo.FromPtr(book.PublishedDate), lo.FromPtr(book.PublishedYear)),
+		Publisher:     lo.FromPtr(book.Publisher),
+		Description:   lo.FromPtr(book.Description),
+		Language:      lo.FromPtr(book.Language),
+		ISBN:          lo.FromPtr(book.Isbn),
+		ASIN:          lo.FromPtr(book.Asin),
+		Series:        series,
+		Subjects:      slices.Concat(lo.FromPtr(book.Genres), lo.FromPtr(book.Tags)),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/ahobsonsayers/abs-tract/config"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
@@ -15,7 +16,7 @@ import (
 )
 
 // NewRouter creates a new handler for server routes
-func NewRouter() (http.Handler, error) {
+func NewRouter(cfg config.Config) (http.Handler, error) {
 	// Load openapi spec
 	spec, err := GetSwagger()
 	if err != nil {
@@ -58,9 +59,15 @@ func NewRouter() (http.Handler, error) {
 		),
 	)
 
+	// Create server
+	server, err := NewServer(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+
 	// Create route handler for OpenAPI routes
-	server := NewStrictHandler(NewServer(), nil)
-	routeHandler := HandlerFromMux(server, chiRouter)
+	strictHandler := NewStrictHandler(server, nil)
+	routeHandler := HandlerFromMux(strictHandler, chiRouter)
 
 	return routeHandler, nil
 }
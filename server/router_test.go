@@ -0,0 +1,642 @@
+package server_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/config"
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/ahobsonsayers/abs-tract/fixture"
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/ahobsonsayers/abs-tract/opf"
+	"github.com/ahobsonsayers/abs-tract/server"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchGoodreads(t *testing.T) {
+	router := newTestRouter(t)
+
+	response := search(t, router, "/goodreads/search?query=The+Hobbit&author=J.R.R.+Tolkien")
+	require.NotNil(t, response.Matches)
+	require.Len(t, *response.Matches, 2)
+
+	book := (*response.Matches)[0]
+	require.Equal(t, "The Hobbit, or There and Back Again", book.Title)
+	require.Equal(t, "J.R.R. Tolkien", lo.FromPtr(book.Author))
+	require.Equal(t, "9780618260300", lo.FromPtr(book.Isbn))
+	require.Equal(t, "English", lo.FromPtr(book.Language))
+	require.Equal(t, "1937-09-21", lo.FromPtr(book.PublishedDate))
+	require.Equal(t, []string{"Fantasy", "Classic", "Fiction"}, lo.FromPtr(book.Genres))
+	require.Equal(t, []server.SeriesMetadata{{Series: "Middle Earth", Sequence: lo.ToPtr("0")}}, lo.FromPtr(book.Series))
+	require.NotNil(t, book.Rating)
+	require.Equal(t, 3747342, book.Rating.Count)
+}
+
+func TestSearchGoodreadsFolderQuery(t *testing.T) {
+	router := newTestRouter(t)
+
+	response := search(t, router, "/goodreads/search?query=J.R.R.+Tolkien+-+The+Hobbit+(1937)+%5BUnabridged%5D")
+	require.NotEmpty(t, *response.Matches)
+
+	book := (*response.Matches)[0]
+	require.Equal(t, "The Hobbit, or There and Back Again", book.Title)
+	require.Equal(t, "J.R.R. Tolkien", lo.FromPtr(book.Author))
+}
+
+func TestSearchGoodreadsParams(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := map[string]struct {
+		params         string
+		expectedTitles []string
+	}{
+		"limit": {
+			params:         "&limit=1",
+			expectedTitles: []string{"The Hobbit, or There and Back Again"},
+		},
+		"offset": {
+			params:         "&offset=1",
+			expectedTitles: []string{"The Hobbit"},
+		},
+		"offset past results": {
+			params:         "&offset=2",
+			expectedTitles: []string{},
+		},
+		"language code": {
+			params:         "&language=en",
+			expectedTitles: []string{"The Hobbit, or There and Back Again", "The Hobbit"},
+		},
+		"language name": {
+			params:         "&language=English",
+			expectedTitles: []string{"The Hobbit, or There and Back Again", "The Hobbit"},
+		},
+		"language list": {
+			params:         "&language=de,+en-GB",
+			expectedTitles: []string{"The Hobbit, or There and Back Again", "The Hobbit"},
+		},
+		"other language": {
+			params:         "&language=de",
+			expectedTitles: []string{},
+		},
+		"format": {
+			params:         "&format=print",
+			expectedTitles: []string{"The Hobbit, or There and Back Again", "The Hobbit"},
+		},
+		"other format": {
+			params:         "&format=audiobook",
+			expectedTitles: []string{},
+		},
+		"exclude editions": {
+			params:         "&includeEditions=false",
+			expectedTitles: []string{"The Hobbit, or There and Back Again", "The Hobbit"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response := search(t, router, "/goodreads/search?query=The+Hobbit&author=J.R.R.+Tolkien"+test.params)
+			require.NotNil(t, response.Matches)
+
+			titles := lo.Map(*response.Matches, func(book server.BookMetadata, _ int) string { return book.Title })
+			require.Equal(t, test.expectedTitles, titles)
+		})
+	}
+}
+
+func TestSearchParamsInvalid(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, url := range []string{
+		"/goodreads/search?query=The+Hobbit&limit=0",
+		"/goodreads/search?query=The+Hobbit&limit=101",
+		"/goodreads/search?query=The+Hobbit&offset=-1",
+		"/goodreads/search?query=The+Hobbit&format=scroll",
+		"/goodreads/search?query=The+Hobbit&includeEditions=maybe",
+		"/goodreads/search?query=The+Hobbit&minConfidence=1.5",
+		"/goodreads/search?query=The+Hobbit&language=en,elvish",
+		"/kindle/search?query=The+Hobbit&language=elvish",
+		"/kindle/uk/search?query=The+Hobbit&format=audiobook",
+	} {
+		recorder := get(router, url)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, url)
+	}
+}
+
+func TestSearchGoodreadsConfidence(t *testing.T) {
+	router := newTestRouter(t)
+
+	response := search(t, router, "/goodreads/search?query=The+Hobbit&author=J.R.R.+Tolkien")
+	require.Len(t, *response.Matches, 2)
+	for _, book := range *response.Matches {
+		require.NotNil(t, book.Confidence)
+		require.InDelta(t, 0.5, *book.Confidence, 0.5)
+	}
+
+	// Only the hobbit by tolkien is a strong match
+	response = search(t, router, "/goodreads/search?query=The+Hobbit&author=J.R.R.+Tolkien&minConfidence=0.8")
+	require.Len(t, *response.Matches, 1)
+	require.Equal(t, "J.R.R. Tolkien", lo.FromPtr((*response.Matches)[0].Author))
+}
+
+func TestSearchGoodreadsDescriptionFormat(t *testing.T) {
+	router := newTestRouter(t)
+
+	response := search(t, router, "/goodreads/search?query=The+Hobbit&author=J.R.R.+Tolkien")
+	description := lo.FromPtr((*response.Matches)[0].Description)
+	require.Contains(t, description, "children, The Hobbit met")
+	require.Contains(t, description, "comfort.\n\nWritten")
+
+	response = search(t, router, "/goodreads/search?query=The+Hobbit&author=J.R.R.+Tolkien&descriptionFormat=markdown")
+	description = lo.FromPtr((*response.Matches)[0].Description)
+	require.Contains(t, description, "children, *The Hobbit* met")
+
+	response = search(t, router, "/goodreads/search?query=The+Hobbit&author=J.R.R.+Tolkien&descriptionFormat=html")
+	description = lo.FromPtr((*response.Matches)[0].Description)
+	require.Contains(t, description, "children, <i>The Hobbit</i> met")
+	require.Contains(t, description, "comfort.</p>\n<p>Written")
+
+	// Configured format is used if the request does not set one
+	router = newTestRouterWithConfig(t, config.Config{DescriptionFormat: "markdown"})
+	response = search(t, router, "/goodreads/search?query=The+Hobbit&author=J.R.R.+Tolkien")
+	description = lo.FromPtr((*response.Matches)[0].Description)
+	require.Contains(t, description, "children, *The Hobbit* met")
+
+	recorder := get(router, "/goodreads/search?query=The+Hobbit&descriptionFormat=rtf")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestSearchKindle(t *testing.T) {
+	router := newTestRouter(t)
+
+	response := search(t, router, "/kindle/uk/search?query=The+Hobbit&author=J.R.R.+Tolkien")
+	require.NotNil(t, response.Matches)
+	require.Len(t, *response.Matches, 2)
+
+	book := (*response.Matches)[0]
+	require.Equal(t, "B007978NU6", lo.FromPtr(book.Asin))
+	require.Equal(t, "The Hobbit: 75th Anniversary Edition", book.Title)
+	require.Equal(t, "J.R.R. Tolkien and Christopher Tolkien", lo.FromPtr(book.Author))
+	require.Equal(t, "https://m.media-amazon.com/images/I/61Ng-W9EhBL.jpg", lo.FromPtr(book.Cover))
+	require.Equal(t, "2012-02-15", lo.FromPtr(book.PublishedDate))
+}
+
+func TestSearchKindleFolderQuery(t *testing.T) {
+	router := newTestRouter(t)
+
+	response := search(t, router, "/kindle/uk/search?query=J.R.R.+Tolkien+-+The+Hobbit+-+read+by+Andy+Serkis")
+	require.NotEmpty(t, *response.Matches)
+	require.Equal(t, "The Hobbit: 75th Anniversary Edition", (*response.Matches)[0].Title)
+}
+
+func TestSearchKindleParams(t *testing.T) {
+	router := newTestRouter(t)
+
+	response := search(t, router, "/kindle/uk/search?query=The+Hobbit&author=J.R.R.+Tolkien&format=ebook&offset=1&limit=1")
+	require.NotNil(t, response.Matches)
+	require.Len(t, *response.Matches, 1)
+	require.Equal(t, "The Hobbit", (*response.Matches)[0].Title)
+}
+
+func TestSearchKindleConfidence(t *testing.T) {
+	router := newTestRouter(t)
+
+	response := search(t, router, "/kindle/uk/search?query=The+Hobbit&author=J.R.R.+Tolkien&minConfidence=0.9")
+	require.Len(t, *response.Matches, 2)
+	require.InDelta(t, 1, lo.FromPtr((*response.Matches)[0].Confidence), 0)
+
+	// The year of the query does not match the year of the books
+	response = search(t, router, "/kindle/uk/search?query=J.R.R.+Tolkien+-+The+Hobbit+(1937)&minConfidence=0.95")
+	require.Empty(t, *response.Matches)
+}
+
+func TestSearchKindleByLanguage(t *testing.T) {
+	router := newTestRouter(t)
+
+	response := search(t, router, "/kindle/search?query=The+Hobbit&author=J.R.R.+Tolkien&language=en-GB")
+	require.NotNil(t, response.Matches)
+	require.Len(t, *response.Matches, 2)
+	require.Equal(t, "B007978NU6", lo.FromPtr((*response.Matches)[0].Asin))
+}
+
+func TestSearchGoodreadsUnavailable(t *testing.T) {
+	// Goodreads always fails
+	goodreadsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer goodreadsServer.Close()
+
+	router, err := server.NewRouter(config.Config{
+		GoodreadsURL:            goodreadsServer.URL,
+		CircuitBreakerThreshold: 1,
+		CircuitBreakerTimeout:   time.Minute,
+	})
+	require.NoError(t, err)
+
+	// First request fails, opening the circuit
+	recorder := get(router, "/goodreads/search?query=The+Hobbit")
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	// Following requests fail immediately with the state of the circuit breaker
+	recorder = get(router, "/goodreads/search?query=The+Hobbit")
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	var response server.N503
+	err = json.NewDecoder(recorder.Body).Decode(&response)
+	require.NoError(t, err)
+	require.NotNil(t, response.CircuitBreaker)
+	require.Equal(t, "goodreads", response.CircuitBreaker.Name)
+	require.Equal(t, server.Open, response.CircuitBreaker.State)
+	require.Positive(t, response.CircuitBreaker.Failures)
+}
+
+func TestSearchKindleProxiedCovers(t *testing.T) {
+	router := newTestRouterWithConfig(t, config.Config{
+		PublicURL:     "http://abs-tract:5555",
+		CoverMaxWidth: 300,
+		CoverFormat:   "jpeg",
+	})
+
+	response := search(t, router, "/kindle/uk/search?query=The+Hobbit&author=J.R.R.+Tolkien")
+	require.NotNil(t, response.Matches)
+
+	coverId, err := cover.ID(cover.ProviderKindle, "https://m.media-amazon.com/images/I/61Ng-W9EhBL.jpg")
+	require.NoError(t, err)
+
+	expectedCover := "http://abs-tract:5555/covers/kindle/" + coverId + "?format=jpeg&width=300"
+	require.Equal(t, expectedCover, lo.FromPtr((*response.Matches)[0].Cover))
+
+	// Kindle cover should not be a valid goodreads cover
+	recorder := get(router, "/covers/goodreads/"+coverId)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestGetCoverInvalid(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := get(router, "/covers/kindle/invalid")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = get(router, "/covers/unknown/invalid")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = get(router, "/covers/kindle/invalid?format=gif")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestMatchBatch(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := post(router, "/batch/match", `{
+		"provider": "goodreads",
+		"items": [
+			{"title": "The Hobbit", "author": "J.R.R. Tolkien"},
+			{"author": "J.R.R. Tolkien"}
+		]
+	}`)
+	require.Equal(t, http.StatusAccepted, recorder.Code, recorder.Body.String())
+
+	var job server.BatchJob
+	err := json.NewDecoder(recorder.Body).Decode(&job)
+	require.NoError(t, err)
+	require.NotEmpty(t, job.Id)
+	require.Equal(t, server.BatchProviderGoodreads, job.Provider)
+	require.Equal(t, 2, job.Total)
+
+	// Results are streamed until the job is completed
+	recorder = get(router, "/batch/"+job.Id+"/results")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+
+	results := make([]server.BatchResult, 2)
+	decoder := json.NewDecoder(recorder.Body)
+	for decoder.More() {
+		var result server.BatchResult
+		err := decoder.Decode(&result)
+		require.NoError(t, err)
+		results[result.Index] = result
+	}
+
+	require.NotNil(t, results[0].Match)
+	require.Equal(t, "The Hobbit, or There and Back Again", results[0].Match.Title)
+	require.NotNil(t, results[0].Match.Confidence)
+	require.Nil(t, results[1].Match)
+	require.Equal(t, "item has no title, isbn or asin", lo.FromPtr(results[1].Error))
+
+	recorder = get(router, "/batch/"+job.Id)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	err = json.NewDecoder(recorder.Body).Decode(&job)
+	require.NoError(t, err)
+	require.Equal(t, server.Completed, job.Status)
+	require.Equal(t, 2, job.Completed)
+	require.Equal(t, 1, job.Matched)
+	require.Equal(t, 1, job.Failed)
+	require.NotNil(t, job.CompletedAt)
+}
+
+func TestMatchBatchInvalid(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := map[string]string{
+		"unknown provider":   `{"provider": "unknown", "items": [{"title": "The Hobbit"}]}`,
+		"no items":           `{"provider": "goodreads", "items": []}`,
+		"invalid region":     `{"provider": "kindle", "region": "xx", "items": [{"title": "The Hobbit"}]}`,
+		"invalid confidence": `{"provider": "goodreads", "minConfidence": 2, "items": [{"title": "The Hobbit"}]}`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := post(router, "/batch/match", body)
+			require.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+		})
+	}
+
+	recorder := get(router, "/batch/unknown")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = get(router, "/batch/unknown/results")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestEnrichAbsLibrary(t *testing.T) {
+	// Audiobookshelf has a library with a single book, recording updates of it
+	var updates []string
+	absServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/libraries/library/items":
+			_, _ = w.Write([]byte(`{"results": [{
+				"id": "hobbit",
+				"mediaType": "book",
+				"media": {"metadata": {"title": "The Hobbit", "authors": [{"id": "tolkien", "name": "J.R.R. Tolkien"}]}}
+			}], "total": 1}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/items/hobbit/media":
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			updates = append(updates, string(body))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer absServer.Close()
+
+	router := newTestRouterWithConfig(t, config.Config{AbsURL: absServer.URL, AbsToken: "token"})
+
+	// Dry runs only report changes
+	recorder := post(router, "/abs/libraries/library/enrich", `{"provider": "goodreads"}`)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var report server.AbsEnrichReport
+	err := json.NewDecoder(recorder.Body).Decode(&report)
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 1, report.Matched)
+	require.Zero(t, report.Updated)
+	require.Contains(t, report.Items[0].Changes, server.AbsChange{
+		Field: server.AbsFieldIsbn,
+		New:   "9780618260300",
+	})
+	require.Empty(t, updates)
+
+	recorder = post(
+		router, "/abs/libraries/library/enrich",
+		`{"provider": "goodreads", "fields": ["isbn"], "dryRun": false}`,
+	)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	err = json.NewDecoder(recorder.Body).Decode(&report)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Updated)
+	require.Equal(t, []string{`{"metadata":{"isbn":"9780618260300"}}`}, updates)
+
+	// Unknown libraries fail
+	recorder = post(router, "/abs/libraries/unknown/enrich", `{"provider": "goodreads"}`)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func TestEnrichAbsLibraryInvalid(t *testing.T) {
+	router := newTestRouter(t)
+
+	// Audiobookshelf is not configured
+	recorder := post(router, "/abs/libraries/library/enrich", `{"provider": "goodreads"}`)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = get(router, "/abs/libraries")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	router = newTestRouterWithConfig(t, config.Config{AbsURL: "http://audiobookshelf:13378", AbsToken: "token"})
+
+	recorder = post(router, "/abs/libraries/library/enrich", `{"provider": "goodreads", "fields": ["unknown"]}`)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestGetGoodreadsBookOpf(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := get(router, "/goodreads/books/5907.opf")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "application/oebps-package+xml", recorder.Header().Get("Content-Type"))
+
+	body := recorder.Body.String()
+	require.Contains(t, body, `<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="isbn">`)
+	require.Contains(t, body, `<dc:title id="title">The Hobbit, or There and Back Again</dc:title>`)
+	require.Contains(t, body, `<dc:identifier id="goodreads">goodreads:5907</dc:identifier>`)
+	require.Contains(t, body, `<meta name="calibre:series" content="Middle Earth"></meta>`)
+
+	recorder = get(router, "/goodreads/books/5907.opf?version=2.0")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	body = recorder.Body.String()
+	require.Contains(t, body, `version="2.0"`)
+	require.Contains(t, body, `<dc:creator opf:role="aut">J.R.R. Tolkien</dc:creator>`)
+	require.Contains(t, body, `<dc:identifier id="isbn" opf:scheme="ISBN">9780618260300</dc:identifier>`)
+	require.Contains(t, body, `<dc:language>en</dc:language>`)
+	require.Contains(t, body, `<meta name="calibre:series_index" content="0"></meta>`)
+
+	recorder = get(router, "/goodreads/books/5907.opf?version=1.0")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestRenderOpf(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := post(router, "/opf?version=2.0", `{
+		"title": "The Hobbit",
+		"author": "J.R.R. Tolkien",
+		"narrator": "Andy Serkis",
+		"asin": "B0099SNBGM",
+		"language": "English",
+		"publishedYear": "1937",
+		"series": [{"series": "Middle Earth", "sequence": "0"}],
+		"genres": ["Fantasy"]
+	}`)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	body := recorder.Body.String()
+	require.Contains(t, body, `<dc:creator opf:role="nrt">Andy Serkis</dc:creator>`)
+	require.Contains(t, body, `<dc:date>1937</dc:date>`)
+	require.Contains(t, body, `<dc:identifier id="asin" opf:scheme="ASIN">B0099SNBGM</dc:identifier>`)
+	require.Contains(t, body, `<dc:subject>Fantasy</dc:subject>`)
+
+	recorder = post(router, "/opf", `{"author": "J.R.R. Tolkien"}`)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestSearchLocal(t *testing.T) {
+	// Library has a book with only an OPF file
+	libraryDir := t.TempDir()
+	opfXML, err := opf.Marshal(opf.Book{
+		Title:         "The Hobbit, or There and Back Again",
+		Creators:      []opf.Creator{{Name: "J.R.R. Tolkien", Role: opf.RoleAuthor}},
+		PublishedDate: "1937-09-21",
+		Language:      "en",
+		ISBN:          "9780618260300",
+		Series:        []opf.Series{{Name: "Middle Earth", Index: "0"}},
+	}, opf.Version3)
+	require.NoError(t, err)
+	err = os.MkdirAll(filepath.Join(libraryDir, "The Hobbit"), 0o755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(libraryDir, "The Hobbit", "metadata.opf"), opfXML, 0o600)
+	require.NoError(t, err)
+
+	router := newTestRouterWithConfig(t, config.Config{LocalLibraryDirs: []string{libraryDir}})
+
+	response := search(t, router, "/local/search?query=the+hobbit&author=tolkien")
+	require.Len(t, *response.Matches, 1)
+	book := (*response.Matches)[0]
+	require.Equal(t, "The Hobbit, or There and Back Again", book.Title)
+	require.Equal(t, "J.R.R. Tolkien", *book.Author)
+	require.Equal(t, "1937", *book.PublishedYear)
+	require.Equal(t, "English", *book.Language)
+	require.Equal(t, []server.SeriesMetadata{{Series: "Middle Earth", Sequence: lo.ToPtr("0")}}, *book.Series)
+	require.Greater(t, *book.Confidence, 0.6)
+
+	response = search(t, router, "/local/search?query=9780618260300")
+	require.Len(t, *response.Matches, 1)
+	require.InDelta(t, 1, *(*response.Matches)[0].Confidence, 0)
+
+	response = search(t, router, "/local/search?query=dune")
+	require.Empty(t, *response.Matches)
+
+	// Local libraries are not configured
+	recorder := get(newTestRouter(t), "/local/search?query=the+hobbit")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestSearchCalibre(t *testing.T) {
+	router := newTestRouterWithConfig(t, config.Config{
+		CalibreLibraryDir: "../calibre/testdata/library",
+		PublicURL:         "http://abs-tract:5555",
+	})
+
+	response := search(t, router, "/calibre/search?query=the+hobbit&author=tolkien&descriptionFormat=markdown")
+	require.NotEmpty(t, *response.Matches)
+	book := (*response.Matches)[0]
+	require.Equal(t, "The Hobbit", book.Title)
+	require.Equal(t, "J.R.R. Tolkien", *book.Author)
+	require.Equal(t, "Houghton Mifflin", *book.Publisher)
+	require.Equal(t, "1937", *book.PublishedYear)
+	require.Equal(t, "1937-09-21", *book.PublishedDate)
+	require.Equal(t, "English", *book.Language)
+	require.Equal(t, "9780618260300", *book.Isbn)
+	require.Equal(t, []server.SeriesMetadata{{Series: "Middle Earth", Sequence: lo.ToPtr("0")}}, *book.Series)
+	require.Equal(t, []string{"Fantasy", "Classics"}, *book.Tags)
+	require.Equal(t, "http://abs-tract:5555/calibre/books/1/cover", *book.Cover)
+	require.True(t, strings.HasPrefix(*book.Description, "In a hole in the ground there lived a hobbit.\n\n"))
+
+	response = search(t, router, "/calibre/search?query=978-0-06-085398-3")
+	require.Len(t, *response.Matches, 1)
+	book = (*response.Matches)[0]
+	require.Equal(t, "Good Omens", book.Title)
+	require.Equal(t, "Terry Pratchett, Neil Gaiman", *book.Author)
+	require.Nil(t, book.Cover)
+
+	// Calibre is not configured
+	recorder := get(newTestRouter(t), "/calibre/search?query=the+hobbit")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestGetCalibreCover(t *testing.T) {
+	router := newTestRouterWithConfig(t, config.Config{CalibreLibraryDir: "../calibre/testdata/library"})
+
+	recorder := get(router, "/calibre/books/1/cover")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Equal(t, "image/jpeg", recorder.Header().Get("Content-Type"))
+	require.Equal(t, []byte{0xFF, 0xD8}, recorder.Body.Bytes()[:2])
+
+	// Book without a cover
+	recorder = get(router, "/calibre/books/3/cover")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = get(router, "/calibre/books/1000/cover")
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = get(router, "/calibre/books/hobbit/cover")
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+// newTestRouter creates a router whose providers are servers serving recorded fixtures
+func newTestRouter(t *testing.T) http.Handler {
+	return newTestRouterWithConfig(t, config.Config{
+		MaxGenres:         goodreads.DefaultMaxGenres,
+		MaxTags:           goodreads.DefaultMaxTags,
+		MinTagShelfWeight: goodreads.DefaultMinTagShelfWeight,
+	})
+}
+
+// newTestRouterWithConfig creates a router using a config whose providers are servers serving recorded fixtures
+func newTestRouterWithConfig(t *testing.T, cfg config.Config) http.Handler {
+	goodreadsFixtureServer := fixture.NewServer(t, "../testdata/goodreads", goodreads.DefaultGoodreadsUrl)
+	goodreadsFixtureProxy := httputil.NewSingleHostReverseProxy(lo.Must(url.Parse(goodreadsFixtureServer.URL)))
+	goodreadsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Editions of works are not recorded, so books are never localised to other editions
+		if strings.HasPrefix(r.URL.Path, "/work/editions/") {
+			http.NotFound(w, r)
+			return
+		}
+		goodreadsFixtureProxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(goodreadsServer.Close)
+	kindleServer := fixture.NewServer(t, "../testdata/kindle", "https://www.amazon.co.uk")
+
+	cfg.GoodreadsURL = goodreadsServer.URL
+	cfg.KindleURL = kindleServer.URL
+
+	router, err := server.NewRouter(cfg)
+	require.NoError(t, err)
+
+	return router
+}
+
+func search(t *testing.T, router http.Handler, url string) server.N200 {
+	recorder := get(router, url)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var response server.N200
+	err := json.NewDecoder(recorder.Body).Decode(&response)
+	require.NoError(t, err)
+
+	return response
+}
+
+func get(router http.Handler, url string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, url, http.NoBody)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func post(router http.Handler, url, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
@@ -0,0 +1,245 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahobsonsayers/abs-tract/description"
+	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/ahobsonsayers/abs-tract/query"
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/samber/lo"
+	"golang.org/x/text/language"
+)
+
+// Default maximum number of books returned by searches
+const defaultSearchLimit = 20
+
+// searchOptions are the options of a book search, set by the query parameters of a request.
+// Unset parameters use the defaults, so requests only setting a query and author are unchanged.
+type searchOptions struct {
+	// query is the query searched for, which the confidence of books is computed against
+	query  query.Query
+	limit  int
+	offset int
+	// languages are the preferred languages of books, most preferred first
+	languages []language.Tag
+	// filterLanguages is whether books without an edition in one of the languages are removed
+	filterLanguages bool
+	// format is the format books must be. nil for any format
+	format          *BookFormat
+	includeEditions bool
+	// minConfidence is the confidence books must have of being the book searched for
+	minConfidence     float64
+	descriptionFormat description.Format
+}
+
+func (s *server) goodreadsSearchOptions(params SearchGoodreadsParams) (searchOptions, error) {
+	languages, filterLanguages, err := s.languagesOrDefault(params.Language)
+	if err != nil {
+		return searchOptions{}, err
+	}
+
+	return searchOptions{
+		query:             query.Parse(params.Query, lo.FromPtr(params.Author)),
+		limit:             lo.FromPtrOr(params.Limit, defaultSearchLimit),
+		offset:            lo.FromPtr(params.Offset),
+		languages:         languages,
+		filterLanguages:   filterLanguages,
+		format:            params.Format,
+		includeEditions:   lo.FromPtrOr(params.IncludeEditions, true),
+		minConfidence:     lo.FromPtr(params.MinConfidence),
+		descriptionFormat: s.descriptionFormatOrDefault(params.DescriptionFormat),
+	}, nil
+}
+
+func (s *server) kindleSearchOptions(params SearchKindleParams) searchOptions {
+	options := searchOptions{
+		query:             query.Parse(params.Query, lo.FromPtr(params.Author)),
+		limit:             lo.FromPtrOr(params.Limit, defaultSearchLimit),
+		offset:            lo.FromPtr(params.Offset),
+		includeEditions:   lo.FromPtrOr(params.IncludeEditions, true),
+		minConfidence:     lo.FromPtr(params.MinConfidence),
+		descriptionFormat: s.descriptionFormat,
+	}
+	if params.Format != nil {
+		options.format = lo.ToPtr(BookFormat(*params.Format))
+	}
+
+	return options
+}
+
+// languagesOrDefault gets the languages of a request (comma separated), and whether books should be filtered by them,
+// falling back to the configured languages if the request does not set any. Configured languages are only preferred.
+// Will return an error if a language of the request is invalid.
+func (s *server) languagesOrDefault(requestLanguages *Language) ([]language.Tag, bool, error) {
+	if requestLanguages == nil {
+		return s.languages, false, nil
+	}
+
+	var languageValues []string
+	for _, languageValue := range strings.Split(*requestLanguages, ",") {
+		languageValue = strings.TrimSpace(languageValue)
+		if languageValue != "" {
+			languageValues = append(languageValues, languageValue)
+		}
+	}
+	if len(languageValues) == 0 {
+		return s.languages, false, nil
+	}
+
+	languages, err := utils.ParseLanguages(languageValues)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid language: %w", err)
+	}
+
+	return languages, true, nil
+}
+
+// needed is the number of books still needed to fill the page of books set by the limit and offset.
+func (o searchOptions) needed(books []BookMetadata) int {
+	if !o.includeEditions {
+		books = lo.UniqBy(books, bookEditionKey)
+	}
+	return max(o.offset+o.limit-len(books), 0)
+}
+
+// kindleDepartment is the department of the amazon store books of the format are sold in.
+func (o searchOptions) kindleDepartment() kindle.Department {
+	if o.format != nil && *o.format == BookFormatPrint {
+		return kindle.DepartmentBooks
+	}
+	return kindle.DepartmentKindle
+}
+
+// matchesFormat returns whether a format of a book matches the format of the search.
+// Books of an unknown format only match if the search is for any format.
+func (o searchOptions) matchesFormat(format *BookFormat) bool {
+	return o.format == nil || (format != nil && *format == *o.format)
+}
+
+// confidence is how confident it is that a book is the book searched for, between 0 and 1.
+func (o searchOptions) confidence(book BookMetadata) float64 {
+	return BookConfidence(o.query, book)
+}
+
+// BookConfidence is how confident it is that a book is the book searched for by a query, between 0 and 1.
+func BookConfidence(searchQuery query.Query, book BookMetadata) float64 {
+	return searchQuery.Confidence(query.Match{
+		Title:    book.Title,
+		Subtitle: lo.FromPtr(book.Subtitle),
+		Author:   lo.FromPtr(book.Author),
+		Series:   lo.Map(lo.FromPtr(book.Series), func(series SeriesMetadata, _ int) string { return series.Series }),
+		Year:     lo.FromPtr(book.PublishedYear),
+	})
+}
+
+// apply removes the other editions of books if editions should not be included,
+// and returns the page of books set by the limit and offset.
+func (o searchOptions) apply(books []BookMetadata) []BookMetadata {
+	if !o.includeEditions {
+		// Books are ordered by relevance, so the first edition of each book is kept
+		books = lo.UniqBy(books, bookEditionKey)
+	}
+
+	if o.offset >= len(books) {
+		return []BookMetadata{}
+	}
+	books = books[o.offset:]
+
+	if o.limit > 0 && len(books) > o.limit {
+		books = books[:o.limit]
+	}
+
+	return books
+}
+
+// bookEditionKey is a key that is the same for all editions of a book, made from its title and author.
+func bookEditionKey(book BookMetadata) string {
+	key := strings.Join([]string{book.Title, lo.FromPtr(book.Author)}, " ")
+	return strings.ToLower(strings.Join(strings.Fields(key), " "))
+}
+
+// goodreadsBookFormat gets the format of a goodreads edition from its format e.g. "Kindle Edition" or "Hardcover".
+// If the format is unknown, nil is returned.
+func goodreadsBookFormat(format string) *BookFormat {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch {
+	case format == "":
+		return nil
+	case strings.Contains(format, "audio") || strings.Contains(format, "mp3"):
+		return lo.ToPtr(BookFormatAudiobook)
+	case strings.Contains(format, "kindle") ||
+		strings.Contains(format, "ebook") ||
+		strings.Contains(format, "e-book") ||
+		strings.Contains(format, "nook"):
+		return lo.ToPtr(BookFormatEbook)
+	default:
+		return lo.ToPtr(BookFormatPrint)
+	}
+}
+
+// kindleBookFormat gets the format of a kindle book from its format e.g. "kindle" or "paperback".
+// If the format is unknown, nil is returned.
+func kindleBookFormat(format string) *BookFormat {
+	switch {
+	case strings.Contains(format, "kindle"):
+		return lo.ToPtr(BookFormatEbook)
+	case strings.Contains(format, "hardcover") || strings.Contains(format, "paperback"):
+		return lo.ToPtr(BookFormatPrint)
+	default:
+		return nil
+	}
+}
+
+var (
+	// kindleRegions are the kindle regions of countries
+	kindleRegions = map[string]SearchKindleParamsRegion{
+		"AU": SearchKindleParamsRegionAu,
+		"CA": SearchKindleParamsRegionCa,
+		"DE": SearchKindleParamsRegionDe,
+		"ES": SearchKindleParamsRegionEs,
+		"FR": SearchKindleParamsRegionFr,
+		"GB": SearchKindleParamsRegionUk,
+		"IN": SearchKindleParamsRegionIn,
+		"IT": SearchKindleParamsRegionIt,
+		"JP": SearchKindleParamsRegionJp,
+		"US": SearchKindleParamsRegionUs,
+	}
+
+	// kindleLanguageRegions are the kindle regions whose marketplace mainly sells books in a language
+	kindleLanguageRegions = map[string]SearchKindleParamsRegion{
+		"de": SearchKindleParamsRegionDe,
+		"en": SearchKindleParamsRegionUs,
+		"es": SearchKindleParamsRegionEs,
+		"fr": SearchKindleParamsRegionFr,
+		"hi": SearchKindleParamsRegionIn,
+		"it": SearchKindleParamsRegionIt,
+		"ja": SearchKindleParamsRegionJp,
+	}
+)
+
+// kindleLanguagesRegion gets the kindle region of the most preferred of the languages that has a marketplace.
+// Languages with a country use the marketplace of the country if it has one e.g. "en-GB" uses the uk marketplace.
+// If no language has a marketplace, the us marketplace is used.
+func kindleLanguagesRegion(languages []language.Tag) SearchKindleParamsRegion {
+	for _, preferredLanguage := range languages {
+		country, confidence := preferredLanguage.Region()
+		if confidence == language.Exact {
+			region, ok := kindleRegions[country.String()]
+			if ok {
+				return region
+			}
+		}
+
+		base, confidence := preferredLanguage.Base()
+		if confidence == language.Exact {
+			region, ok := kindleLanguageRegions[base.String()]
+			if ok {
+				return region
+			}
+		}
+	}
+
+	return SearchKindleParamsRegionUs
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestKindleLanguagesRegion(t *testing.T) {
+	tests := map[string]struct {
+		languages      []language.Tag
+		expectedRegion SearchKindleParamsRegion
+	}{
+		"language": {
+			languages:      []language.Tag{language.German},
+			expectedRegion: SearchKindleParamsRegionDe,
+		},
+		"language with country": {
+			languages:      []language.Tag{language.BritishEnglish},
+			expectedRegion: SearchKindleParamsRegionUk,
+		},
+		"language with country without marketplace": {
+			languages:      []language.Tag{language.MustParse("de-AT")},
+			expectedRegion: SearchKindleParamsRegionDe,
+		},
+		"fallback language": {
+			languages:      []language.Tag{language.Dutch, language.French},
+			expectedRegion: SearchKindleParamsRegionFr,
+		},
+		"no marketplace": {
+			languages:      []language.Tag{language.Dutch},
+			expectedRegion: SearchKindleParamsRegionUs,
+		},
+		"no languages": {
+			expectedRegion: SearchKindleParamsRegionUs,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.expectedRegion, kindleLanguagesRegion(test.languages))
+		})
+	}
+}
+
+func TestGoodreadsBookFormat(t *testing.T) {
+	require.Equal(t, BookFormatEbook, lo.FromPtr(goodreadsBookFormat("Kindle Edition")))
+	require.Equal(t, BookFormatEbook, lo.FromPtr(goodreadsBookFormat("ebook")))
+	require.Equal(t, BookFormatAudiobook, lo.FromPtr(goodreadsBookFormat("Audible Audio")))
+	require.Equal(t, BookFormatAudiobook, lo.FromPtr(goodreadsBookFormat("Audio CD")))
+	require.Equal(t, BookFormatPrint, lo.FromPtr(goodreadsBookFormat("Mass Market Paperback")))
+	require.Nil(t, goodreadsBookFormat(""))
+}
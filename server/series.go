@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/samber/lo"
+)
+
+func (s *server) getGoodreadsSeries(ctx context.Context, seriesId string) (Series, error) {
+	goodreadsSeries, err := s.goodreadsClient.GetSeries(ctx, seriesId)
+	if err != nil {
+		return Series{}, err
+	}
+
+	series := goodreadsSeriesToSeries(goodreadsSeries)
+	for idx := range series.Books {
+		series.Books[idx].Cover = s.coverURL(cover.ProviderGoodreads, series.Books[idx].Cover)
+	}
+
+	return series, nil
+}
+
+func goodreadsSeriesToSeries(goodreadsSeries goodreads.SeriesDetails) Series {
+	var description *string
+	if goodreadsSeries.Description != "" {
+		description = lo.ToPtr(goodreadsSeries.Description)
+	}
+
+	books := make([]SeriesBook, 0, len(goodreadsSeries.Works))
+	for _, goodreadsWork := range goodreadsSeries.Works {
+		book := goodreadsSeriesWorkToSeriesBook(goodreadsWork)
+		books = append(books, book)
+	}
+
+	return Series{
+		Id:               goodreadsSeries.Id,
+		Title:            goodreadsSeries.Title,
+		Description:      description,
+		Numbered:         lo.ToPtr(goodreadsSeries.Numbered),
+		PrimaryBookCount: lo.ToPtr(goodreadsSeries.PrimaryBookCount),
+		TotalBookCount:   lo.ToPtr(goodreadsSeries.TotalBookCount),
+		Books:            books,
+	}
+}
+
+func goodreadsSeriesWorkToSeriesBook(goodreadsWork goodreads.SeriesWork) SeriesBook {
+	// Prefer the original title of the work, falling back to the title of the best book
+	title := goodreadsWork.Work.Title()
+	if title == "" {
+		title = goodreadsWork.BestBook.Title()
+	}
+
+	var author *string
+	if goodreadsWork.BestBook.Author != "" {
+		author = lo.ToPtr(goodreadsWork.BestBook.Author)
+	}
+
+	var imageUrl *string
+	if goodreadsWork.BestBook.ImageURL != "" {
+		imageUrl = lo.ToPtr(goodreadsWork.BestBook.ImageURL)
+	}
+
+	var sequence *string
+	position := goodreads.ParseSeriesPosition(goodreadsWork.BookPosition)
+	if position.Valid {
+		sequence = lo.ToPtr(position.String())
+	}
+
+	var publicationYear *string
+	if goodreadsWork.Work.PublicationYear != 0 {
+		publicationYear = lo.ToPtr(strconv.Itoa(goodreadsWork.Work.PublicationYear))
+	}
+
+	return SeriesBook{
+		Id:            goodreadsWork.BestBook.Id,
+		Title:         title,
+		Author:        author,
+		Cover:         imageUrl,
+		Sequence:      sequence,
+		Primary:       position.Primary(),
+		PublishedYear: publicationYear,
+	}
+}
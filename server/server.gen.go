@@ -1,6 +1,6 @@
 // Package server provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.1.0 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package server
 
 import (
@@ -10,56 +10,444 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
 	Api_keyScopes = "api_key.Scopes"
 )
 
+// Defines values for AbsField.
+const (
+	AbsFieldAsin          AbsField = "asin"
+	AbsFieldAuthors       AbsField = "authors"
+	AbsFieldCover         AbsField = "cover"
+	AbsFieldDescription   AbsField = "description"
+	AbsFieldGenres        AbsField = "genres"
+	AbsFieldIsbn          AbsField = "isbn"
+	AbsFieldLanguage      AbsField = "language"
+	AbsFieldPublishedYear AbsField = "published-year"
+	AbsFieldPublisher     AbsField = "publisher"
+	AbsFieldSeries        AbsField = "series"
+	AbsFieldSubtitle      AbsField = "subtitle"
+	AbsFieldTags          AbsField = "tags"
+	AbsFieldTitle         AbsField = "title"
+)
+
+// Defines values for BatchJobStatus.
+const (
+	Completed BatchJobStatus = "completed"
+	Queued    BatchJobStatus = "queued"
+	Running   BatchJobStatus = "running"
+)
+
+// Defines values for BatchProvider.
+const (
+	BatchProviderGoodreads BatchProvider = "goodreads"
+	BatchProviderKindle    BatchProvider = "kindle"
+)
+
+// Defines values for BookFormat.
+const (
+	BookFormatAudiobook BookFormat = "audiobook"
+	BookFormatEbook     BookFormat = "ebook"
+	BookFormatPrint     BookFormat = "print"
+)
+
+// Defines values for CircuitBreakerState.
+const (
+	Closed   CircuitBreakerState = "closed"
+	HalfOpen CircuitBreakerState = "half-open"
+	Open     CircuitBreakerState = "open"
+)
+
+// Defines values for DescriptionFormat.
+const (
+	Html     DescriptionFormat = "html"
+	Markdown DescriptionFormat = "markdown"
+	Plain    DescriptionFormat = "plain"
+)
+
+// Defines values for KindleBookFormat.
+const (
+	KindleBookFormatEbook KindleBookFormat = "ebook"
+	KindleBookFormatPrint KindleBookFormat = "print"
+)
+
+// Defines values for OpfVersion.
+const (
+	N20 OpfVersion = "2.0"
+	N30 OpfVersion = "3.0"
+)
+
+// Defines values for GetCoverParamsFormat.
+const (
+	Jpeg GetCoverParamsFormat = "jpeg"
+	Png  GetCoverParamsFormat = "png"
+	Webp GetCoverParamsFormat = "webp"
+)
+
+// Defines values for GetCoverParamsProvider.
+const (
+	GetCoverParamsProviderGoodreads   GetCoverParamsProvider = "goodreads"
+	GetCoverParamsProviderKindle      GetCoverParamsProvider = "kindle"
+	GetCoverParamsProviderOpenlibrary GetCoverParamsProvider = "openlibrary"
+)
+
+// Defines values for ListKindleCoversParamsRegion.
+const (
+	ListKindleCoversParamsRegionAu ListKindleCoversParamsRegion = "au"
+	ListKindleCoversParamsRegionCa ListKindleCoversParamsRegion = "ca"
+	ListKindleCoversParamsRegionDe ListKindleCoversParamsRegion = "de"
+	ListKindleCoversParamsRegionEs ListKindleCoversParamsRegion = "es"
+	ListKindleCoversParamsRegionFr ListKindleCoversParamsRegion = "fr"
+	ListKindleCoversParamsRegionIn ListKindleCoversParamsRegion = "in"
+	ListKindleCoversParamsRegionIt ListKindleCoversParamsRegion = "it"
+	ListKindleCoversParamsRegionJp ListKindleCoversParamsRegion = "jp"
+	ListKindleCoversParamsRegionUk ListKindleCoversParamsRegion = "uk"
+	ListKindleCoversParamsRegionUs ListKindleCoversParamsRegion = "us"
+)
+
 // Defines values for SearchKindleParamsRegion.
 const (
-	Au SearchKindleParamsRegion = "au"
-	Ca SearchKindleParamsRegion = "ca"
-	De SearchKindleParamsRegion = "de"
-	Es SearchKindleParamsRegion = "es"
-	Fr SearchKindleParamsRegion = "fr"
-	In SearchKindleParamsRegion = "in"
-	It SearchKindleParamsRegion = "it"
-	Jp SearchKindleParamsRegion = "jp"
-	Uk SearchKindleParamsRegion = "uk"
-	Us SearchKindleParamsRegion = "us"
+	SearchKindleParamsRegionAu SearchKindleParamsRegion = "au"
+	SearchKindleParamsRegionCa SearchKindleParamsRegion = "ca"
+	SearchKindleParamsRegionDe SearchKindleParamsRegion = "de"
+	SearchKindleParamsRegionEs SearchKindleParamsRegion = "es"
+	SearchKindleParamsRegionFr SearchKindleParamsRegion = "fr"
+	SearchKindleParamsRegionIn SearchKindleParamsRegion = "in"
+	SearchKindleParamsRegionIt SearchKindleParamsRegion = "it"
+	SearchKindleParamsRegionJp SearchKindleParamsRegion = "jp"
+	SearchKindleParamsRegionUk SearchKindleParamsRegion = "uk"
+	SearchKindleParamsRegionUs SearchKindleParamsRegion = "us"
 )
 
+// AbsChange Change of a field of a library item. Lists are given as comma separated text
+type AbsChange struct {
+	// Field Field of the metadata of an audiobookshelf library item
+	Field AbsField `json:"field"`
+	New   string   `json:"new"`
+	Old   string   `json:"old"`
+}
+
+// AbsEnrichItem defines model for AbsEnrichItem.
+type AbsEnrichItem struct {
+	Changes []AbsChange `json:"changes"`
+
+	// Error Error matching or changing the item
+	Error *string `json:"error,omitempty"`
+
+	// Id Id of the library item
+	Id string `json:"id"`
+
+	// Matched Whether a book was matched to the item
+	Matched bool   `json:"matched"`
+	Title   string `json:"title"`
+
+	// Updated Whether the changes were made. Always false for dry runs
+	Updated bool `json:"updated"`
+}
+
+// AbsEnrichReport defines model for AbsEnrichReport.
+type AbsEnrichReport struct {
+	DryRun bool `json:"dryRun"`
+
+	// Failed Number of books that failed to be matched or changed
+	Failed    int             `json:"failed"`
+	Items     []AbsEnrichItem `json:"items"`
+	LibraryId string          `json:"libraryId"`
+
+	// Matched Number of books a book was matched to
+	Matched int `json:"matched"`
+
+	// Total Number of books in the library
+	Total int `json:"total"`
+
+	// Updated Number of books that were changed
+	Updated int `json:"updated"`
+}
+
+// AbsEnrichRequest defines model for AbsEnrichRequest.
+type AbsEnrichRequest struct {
+	// DryRun Whether to only report changes, without making them
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// Fields Fields of items to write. If unset, all fields except the title and authors (which items are matched by)
+	Fields *[]AbsField `json:"fields,omitempty"`
+
+	// MinConfidence Confidence (between 0 and 1) a book must have of being an item for it to be matched
+	MinConfidence *float64 `json:"minConfidence,omitempty"`
+
+	// Overwrite Whether to change fields items already have. If false, only empty fields are set
+	Overwrite *bool `json:"overwrite,omitempty"`
+
+	// Provider Provider books are matched using
+	Provider BatchProvider `json:"provider"`
+
+	// Region Kindle region to match books in, e.g. "uk" or "us". Only used by the kindle provider
+	Region *string `json:"region,omitempty"`
+}
+
+// AbsField Field of the metadata of an audiobookshelf library item
+type AbsField string
+
+// AbsLibraries defines model for AbsLibraries.
+type AbsLibraries struct {
+	Libraries []AbsLibrary `json:"libraries"`
+}
+
+// AbsLibrary defines model for AbsLibrary.
+type AbsLibrary struct {
+	Id string `json:"id"`
+
+	// MediaType Type of media of the library, e.g. "book" or "podcast"
+	MediaType string `json:"mediaType"`
+	Name      string `json:"name"`
+}
+
+// AuthorBooks defines model for AuthorBooks.
+type AuthorBooks struct {
+	Books []BookMetadata `json:"books"`
+	Page  int            `json:"page"`
+
+	// Total Total number of books by the author
+	Total int `json:"total"`
+}
+
+// AuthorDetails defines model for AuthorDetails.
+type AuthorDetails struct {
+	Biography *string             `json:"biography,omitempty"`
+	BornDate  *openapi_types.Date `json:"bornDate,omitempty"`
+	DiedDate  *openapi_types.Date `json:"diedDate,omitempty"`
+	Hometown  *string             `json:"hometown,omitempty"`
+	Id        string              `json:"id"`
+
+	// Link URL to the goodreads page of the author
+	Link *string `json:"link,omitempty"`
+	Name string  `json:"name"`
+
+	// Photo URL to a photo of the author
+	Photo      *string `json:"photo,omitempty"`
+	WorksCount *int    `json:"worksCount,omitempty"`
+}
+
+// BatchItem Book to match. Books are matched by title and author if a title is set, otherwise by isbn or asin.
+// Books with an isbn or asin are matched to the book with the same isbn or asin if one is found
+type BatchItem struct {
+	Asin   *string `json:"asin,omitempty"`
+	Author *string `json:"author,omitempty"`
+	Isbn   *string `json:"isbn,omitempty"`
+	Title  *string `json:"title,omitempty"`
+}
+
+// BatchJob Progress of a batch job
+type BatchJob struct {
+	// Completed Number of items matched, or that failed to match
+	Completed   int        `json:"completed"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+
+	// Failed Number of items that failed to match
+	Failed int    `json:"failed"`
+	Id     string `json:"id"`
+
+	// Matched Number of items a book was matched to
+	Matched int `json:"matched"`
+
+	// Provider Provider books are matched using
+	Provider BatchProvider  `json:"provider"`
+	Status   BatchJobStatus `json:"status"`
+
+	// Total Number of items of the job
+	Total int `json:"total"`
+}
+
+// BatchJobStatus defines model for BatchJob.Status.
+type BatchJobStatus string
+
+// BatchMatchRequest defines model for BatchMatchRequest.
+type BatchMatchRequest struct {
+	Items []BatchItem `json:"items"`
+
+	// MinConfidence Confidence (between 0 and 1) a book must have of being an item for it to be matched
+	MinConfidence *float64 `json:"minConfidence,omitempty"`
+
+	// Provider Provider books are matched using
+	Provider BatchProvider `json:"provider"`
+
+	// Region Kindle region to match books in, e.g. "uk" or "us". Only used by the kindle provider
+	Region *string `json:"region,omitempty"`
+}
+
+// BatchProvider Provider books are matched using
+type BatchProvider string
+
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+	// Error Error matching the item. Items without a match or error have no matching book
+	Error *string `json:"error,omitempty"`
+
+	// Index Index of the item in the items of the job
+	Index int `json:"index"`
+
+	// Item Book to match. Books are matched by title and author if a title is set, otherwise by isbn or asin.
+	// Books with an isbn or asin are matched to the book with the same isbn or asin if one is found
+	Item  BatchItem     `json:"item"`
+	Match *BookMetadata `json:"match,omitempty"`
+}
+
+// BookFormat Format of a book
+type BookFormat string
+
 // BookMetadata defines model for BookMetadata.
 type BookMetadata struct {
 	Asin   *string `json:"asin,omitempty"`
 	Author *string `json:"author,omitempty"`
 
+	// Confidence How confident it is that the book is the book searched for, between 0 and 1. Computed from the similarity
+	// of the title, author, series and year of the book to the query. Only set for search results
+	Confidence *float64 `json:"confidence,omitempty"`
+
 	// Cover URL to the cover image
-	Cover       *string `json:"cover,omitempty"`
+	Cover *string `json:"cover,omitempty"`
+
+	// CoverHeight Height of the cover image. Only known if cover analysis is enabled
+	CoverHeight *int `json:"coverHeight,omitempty"`
+
+	// CoverWidth Width of the cover image. Only known if cover analysis is enabled
+	CoverWidth  *int    `json:"coverWidth,omitempty"`
 	Description *string `json:"description,omitempty"`
 
 	// Duration Duration in seconds
-	Duration      *int              `json:"duration,omitempty"`
-	Genres        *[]string         `json:"genres,omitempty"`
-	Isbn          *string           `json:"isbn,omitempty"`
-	Language      *string           `json:"language,omitempty"`
-	Narrator      *string           `json:"narrator,omitempty"`
-	PublishedYear *string           `json:"publishedYear,omitempty"`
-	Publisher     *string           `json:"publisher,omitempty"`
-	Series        *[]SeriesMetadata `json:"series,omitempty"`
-	Subtitle      *string           `json:"subtitle,omitempty"`
-	Tags          *[]string         `json:"tags,omitempty"`
-	Title         string            `json:"title"`
+	Duration *int `json:"duration,omitempty"`
+
+	// EditionPublishedDate ISO 8601 date the edition was published. Only known parts of the date are included.
+	// e.g. "2006-01-02", "2006-01" or "2006"
+	EditionPublishedDate *string   `json:"editionPublishedDate,omitempty"`
+	Genres               *[]string `json:"genres,omitempty"`
+	Isbn                 *string   `json:"isbn,omitempty"`
+	Language             *string   `json:"language,omitempty"`
+	Narrator             *string   `json:"narrator,omitempty"`
+
+	// PublishedDate ISO 8601 date the book was originally published, falling back to the date the edition was published
+	// if unknown. Only known parts of the date are included. e.g. "2006-01-02", "2006-01" or "2006"
+	PublishedDate *string `json:"publishedDate,omitempty"`
+	PublishedYear *string `json:"publishedYear,omitempty"`
+	Publisher     *string `json:"publisher,omitempty"`
+
+	// Rating Ratings given to a book by users of a provider
+	Rating   *Rating           `json:"rating,omitempty"`
+	Series   *[]SeriesMetadata `json:"series,omitempty"`
+	Subtitle *string           `json:"subtitle,omitempty"`
+	Tags     *[]string         `json:"tags,omitempty"`
+	Title    string            `json:"title"`
+}
+
+// CircuitBreaker State of the circuit breaker stopping requests being made to a failing provider
+type CircuitBreaker struct {
+	// Failures Number of consecutive failed requests to the provider
+	Failures int `json:"failures"`
+
+	// Name Name of the provider, e.g. "goodreads" or "kindle-uk"
+	Name string `json:"name"`
+
+	// RetryAt Time after which requests to the provider will be tried again
+	RetryAt time.Time           `json:"retryAt"`
+	State   CircuitBreakerState `json:"state"`
+}
+
+// CircuitBreakerState defines model for CircuitBreaker.State.
+type CircuitBreakerState string
+
+// Cover defines model for Cover.
+type Cover struct {
+	Height int `json:"height"`
+
+	// Url URL to the cover image
+	Url   string `json:"url"`
+	Width int    `json:"width"`
+}
+
+// Covers defines model for Covers.
+type Covers struct {
+	Covers []Cover `json:"covers"`
+}
+
+// DescriptionFormat Format of descriptions and biographies.
+// Either plain text, markdown, or sanitised html
+type DescriptionFormat string
+
+// KindleBookFormat Format of a book sold on kindle. Kindle does not sell audiobooks
+type KindleBookFormat string
+
+// OpfVersion Version of the open packaging format of OPF files
+type OpfVersion string
+
+// Rating Ratings given to a book by users of a provider
+type Rating struct {
+	// Average Average star rating out of 5. 0 if there are no ratings
+	Average float64 `json:"average"`
+
+	// Count Number of ratings
+	Count int `json:"count"`
+
+	// Distribution Number of ratings given for each star rating
+	Distribution *RatingDistribution `json:"distribution,omitempty"`
+
+	// ReviewsCount Number of text reviews
+	ReviewsCount *int `json:"reviewsCount,omitempty"`
+}
+
+// RatingDistribution Number of ratings given for each star rating
+type RatingDistribution struct {
+	FiveStar  int `json:"fiveStar"`
+	FourStar  int `json:"fourStar"`
+	OneStar   int `json:"oneStar"`
+	ThreeStar int `json:"threeStar"`
+	TwoStar   int `json:"twoStar"`
+}
+
+// Series defines model for Series.
+type Series struct {
+	Books       []SeriesBook `json:"books"`
+	Description *string      `json:"description,omitempty"`
+	Id          string       `json:"id"`
+	Numbered    *bool        `json:"numbered,omitempty"`
+
+	// PrimaryBookCount Number of primary books in the series (excluding novellas, omnibuses etc.)
+	PrimaryBookCount *int   `json:"primaryBookCount,omitempty"`
+	Title            string `json:"title"`
+
+	// TotalBookCount Number of books in the series
+	TotalBookCount *int `json:"totalBookCount,omitempty"`
+}
+
+// SeriesBook defines model for SeriesBook.
+type SeriesBook struct {
+	Author *string `json:"author,omitempty"`
+
+	// Cover URL to the cover image
+	Cover *string `json:"cover,omitempty"`
+	Id    string  `json:"id"`
+
+	// Primary Whether the book is a primary book of the series
+	Primary       bool    `json:"primary"`
+	PublishedYear *string `json:"publishedYear,omitempty"`
+
+	// Sequence Position of the book in the series. e.g. "1", "0.5" or "1-3"
+	Sequence *string `json:"sequence,omitempty"`
+	Title    string  `json:"title"`
 }
 
 // SeriesMetadata defines model for SeriesMetadata.
@@ -71,6 +459,33 @@ type SeriesMetadata struct {
 // Author defines model for author.
 type Author = string
 
+// Format Format of a book
+type Format = BookFormat
+
+// Id defines model for id.
+type Id = string
+
+// IncludeEditions defines model for includeEditions.
+type IncludeEditions = bool
+
+// KindleFormat Format of a book sold on kindle. Kindle does not sell audiobooks
+type KindleFormat = KindleBookFormat
+
+// Language defines model for language.
+type Language = string
+
+// Limit defines model for limit.
+type Limit = int
+
+// MinConfidence defines model for minConfidence.
+type MinConfidence = float64
+
+// Offset defines model for offset.
+type Offset = int
+
+// Page defines model for page.
+type Page = int
+
 // Query defines model for query.
 type Query = string
 
@@ -89,52 +504,395 @@ type N401 struct {
 	Error *string `json:"error,omitempty"`
 }
 
+// N404 defines model for 404.
+type N404 struct {
+	Error *string `json:"error,omitempty"`
+}
+
 // N500 defines model for 500.
 type N500 struct {
 	Error *string `json:"error,omitempty"`
 }
 
+// N503 defines model for 503.
+type N503 struct {
+	// CircuitBreaker State of the circuit breaker stopping requests being made to a failing provider
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+	Error          *string         `json:"error,omitempty"`
+}
+
+// GetBatchResultsParams defines parameters for GetBatchResults.
+type GetBatchResultsParams struct {
+	// Follow Whether to keep streaming results as items are matched until the job is completed.
+	// If false, only the results of items already matched are returned
+	Follow *bool `form:"follow,omitempty" json:"follow,omitempty"`
+}
+
+// SearchCalibreParams defines parameters for SearchCalibre.
+type SearchCalibreParams struct {
+	Query  Query   `form:"query" json:"query"`
+	Author *Author `form:"author,omitempty" json:"author,omitempty"`
+
+	// Limit Maximum number of results to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of results to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// MinConfidence Only return books with at least this confidence (between 0 and 1) of being the book searched for
+	MinConfidence *MinConfidence `form:"minConfidence,omitempty" json:"minConfidence,omitempty"`
+
+	// DescriptionFormat Format of descriptions and biographies. If unset, the configured format is used
+	DescriptionFormat *DescriptionFormat `form:"descriptionFormat,omitempty" json:"descriptionFormat,omitempty"`
+}
+
+// GetCoverParams defines parameters for GetCover.
+type GetCoverParams struct {
+	// Width Maximum width of the cover. The cover is resized to fit, keeping its aspect ratio
+	Width *int `form:"width,omitempty" json:"width,omitempty"`
+
+	// Height Maximum height of the cover. The cover is resized to fit, keeping its aspect ratio
+	Height *int `form:"height,omitempty" json:"height,omitempty"`
+
+	// Format Format to convert the cover to. WebP covers are lossless
+	Format *GetCoverParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Strip Whether to strip metadata (e.g. EXIF) from the cover
+	Strip *bool `form:"strip,omitempty" json:"strip,omitempty"`
+}
+
+// GetCoverParamsFormat defines parameters for GetCover.
+type GetCoverParamsFormat string
+
+// GetCoverParamsProvider defines parameters for GetCover.
+type GetCoverParamsProvider string
+
+// GetGoodreadsAuthorParams defines parameters for GetGoodreadsAuthor.
+type GetGoodreadsAuthorParams struct {
+	// DescriptionFormat Format of descriptions and biographies. If unset, the configured format is used
+	DescriptionFormat *DescriptionFormat `form:"descriptionFormat,omitempty" json:"descriptionFormat,omitempty"`
+}
+
+// ListGoodreadsAuthorBooksParams defines parameters for ListGoodreadsAuthorBooks.
+type ListGoodreadsAuthorBooksParams struct {
+	Page *Page `form:"page,omitempty" json:"page,omitempty"`
+
+	// DescriptionFormat Format of descriptions and biographies. If unset, the configured format is used
+	DescriptionFormat *DescriptionFormat `form:"descriptionFormat,omitempty" json:"descriptionFormat,omitempty"`
+}
+
+// GetGoodreadsBookOpfParams defines parameters for GetGoodreadsBookOpf.
+type GetGoodreadsBookOpfParams struct {
+	// Version Version of the OPF file. Defaults to 3.0
+	Version *OpfVersion `form:"version,omitempty" json:"version,omitempty"`
+}
+
+// ListGoodreadsCoversParams defines parameters for ListGoodreadsCovers.
+type ListGoodreadsCoversParams struct {
+	Query  Query   `form:"query" json:"query"`
+	Author *Author `form:"author,omitempty" json:"author,omitempty"`
+}
+
 // SearchGoodreadsParams defines parameters for SearchGoodreads.
 type SearchGoodreadsParams struct {
 	Query  Query   `form:"query" json:"query"`
 	Author *Author `form:"author,omitempty" json:"author,omitempty"`
+
+	// Limit Maximum number of results to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of results to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Language Comma separated languages, most preferred first. Each is either a language code (e.g. "de", "ger" or "en-GB")
+	// or an english language name (e.g. "German"). Only books with an edition in one of the languages are returned,
+	// in their edition in the most preferred language. If unset, the configured languages are preferred
+	Language *Language `form:"language,omitempty" json:"language,omitempty"`
+
+	// Format Only return books of a format
+	Format *Format `form:"format,omitempty" json:"format,omitempty"`
+
+	// IncludeEditions Whether to return every edition of a book (with the same title and author).
+	// If false, only the most relevant edition of each book is returned
+	IncludeEditions *IncludeEditions `form:"includeEditions,omitempty" json:"includeEditions,omitempty"`
+
+	// MinConfidence Only return books with at least this confidence (between 0 and 1) of being the book searched for
+	MinConfidence *MinConfidence `form:"minConfidence,omitempty" json:"minConfidence,omitempty"`
+
+	// DescriptionFormat Format of descriptions and biographies. If unset, the configured format is used
+	DescriptionFormat *DescriptionFormat `form:"descriptionFormat,omitempty" json:"descriptionFormat,omitempty"`
+}
+
+// SearchKindleByLanguageParams defines parameters for SearchKindleByLanguage.
+type SearchKindleByLanguageParams struct {
+	Query  Query   `form:"query" json:"query"`
+	Author *Author `form:"author,omitempty" json:"author,omitempty"`
+
+	// Limit Maximum number of results to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of results to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Language Comma separated languages, most preferred first. Each is either a language code (e.g. "de", "ger" or "en-GB")
+	// or an english language name (e.g. "German"). Only books with an edition in one of the languages are returned,
+	// in their edition in the most preferred language. If unset, the configured languages are preferred
+	Language *Language `form:"language,omitempty" json:"language,omitempty"`
+
+	// Format Only return books of a format
+	Format *KindleFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// IncludeEditions Whether to return every edition of a book (with the same title and author).
+	// If false, only the most relevant edition of each book is returned
+	IncludeEditions *IncludeEditions `form:"includeEditions,omitempty" json:"includeEditions,omitempty"`
+
+	// MinConfidence Only return books with at least this confidence (between 0 and 1) of being the book searched for
+	MinConfidence *MinConfidence `form:"minConfidence,omitempty" json:"minConfidence,omitempty"`
+}
+
+// ListKindleCoversParams defines parameters for ListKindleCovers.
+type ListKindleCoversParams struct {
+	Query  Query   `form:"query" json:"query"`
+	Author *Author `form:"author,omitempty" json:"author,omitempty"`
 }
 
+// ListKindleCoversParamsRegion defines parameters for ListKindleCovers.
+type ListKindleCoversParamsRegion string
+
 // SearchKindleParams defines parameters for SearchKindle.
 type SearchKindleParams struct {
 	Query  Query   `form:"query" json:"query"`
 	Author *Author `form:"author,omitempty" json:"author,omitempty"`
+
+	// Limit Maximum number of results to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of results to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Format Only return books of a format
+	Format *KindleFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// IncludeEditions Whether to return every edition of a book (with the same title and author).
+	// If false, only the most relevant edition of each book is returned
+	IncludeEditions *IncludeEditions `form:"includeEditions,omitempty" json:"includeEditions,omitempty"`
+
+	// MinConfidence Only return books with at least this confidence (between 0 and 1) of being the book searched for
+	MinConfidence *MinConfidence `form:"minConfidence,omitempty" json:"minConfidence,omitempty"`
 }
 
 // SearchKindleParamsRegion defines parameters for SearchKindle.
 type SearchKindleParamsRegion string
 
+// SearchLocalParams defines parameters for SearchLocal.
+type SearchLocalParams struct {
+	Query  Query   `form:"query" json:"query"`
+	Author *Author `form:"author,omitempty" json:"author,omitempty"`
+
+	// Limit Maximum number of results to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of results to skip
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// MinConfidence Only return books with at least this confidence (between 0 and 1) of being the book searched for
+	MinConfidence *MinConfidence `form:"minConfidence,omitempty" json:"minConfidence,omitempty"`
+}
+
+// RenderOpfParams defines parameters for RenderOpf.
+type RenderOpfParams struct {
+	// Version Version of the OPF file. Defaults to 3.0
+	Version *OpfVersion `form:"version,omitempty" json:"version,omitempty"`
+}
+
+// EnrichAbsLibraryJSONRequestBody defines body for EnrichAbsLibrary for application/json ContentType.
+type EnrichAbsLibraryJSONRequestBody = AbsEnrichRequest
+
+// MatchBatchJSONRequestBody defines body for MatchBatch for application/json ContentType.
+type MatchBatchJSONRequestBody = BatchMatchRequest
+
+// RenderOpfJSONRequestBody defines body for RenderOpf for application/json ContentType.
+type RenderOpfJSONRequestBody = BookMetadata
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List the libraries of audiobookshelf
+	// (GET /abs/libraries)
+	ListAbsLibraries(w http.ResponseWriter, r *http.Request)
+	// Enrich an audiobookshelf library
+	// (POST /abs/libraries/{id}/enrich)
+	EnrichAbsLibrary(w http.ResponseWriter, r *http.Request, id Id)
+	// Match a batch of books
+	// (POST /batch/match)
+	MatchBatch(w http.ResponseWriter, r *http.Request)
+	// Get the progress of a batch job
+	// (GET /batch/{id})
+	GetBatch(w http.ResponseWriter, r *http.Request, id Id)
+	// Stream the results of a batch job
+	// (GET /batch/{id}/results)
+	GetBatchResults(w http.ResponseWriter, r *http.Request, id Id, params GetBatchResultsParams)
+	// Get the cover of a book in the calibre library
+	// (GET /calibre/books/{id}/cover)
+	GetCalibreCover(w http.ResponseWriter, r *http.Request, id int)
+	// Search for books in the calibre library
+	// (GET /calibre/search)
+	SearchCalibre(w http.ResponseWriter, r *http.Request, params SearchCalibreParams)
+	// Get a book cover
+	// (GET /covers/{provider}/{id})
+	GetCover(w http.ResponseWriter, r *http.Request, provider GetCoverParamsProvider, id string, params GetCoverParams)
+	// Get an author from goodreads
+	// (GET /goodreads/authors/{id})
+	GetGoodreadsAuthor(w http.ResponseWriter, r *http.Request, id Id, params GetGoodreadsAuthorParams)
+	// List books by an author from goodreads
+	// (GET /goodreads/authors/{id}/books)
+	ListGoodreadsAuthorBooks(w http.ResponseWriter, r *http.Request, id Id, params ListGoodreadsAuthorBooksParams)
+	// Get a book from goodreads as an OPF file
+	// (GET /goodreads/books/{id}.opf)
+	GetGoodreadsBookOpf(w http.ResponseWriter, r *http.Request, id Id, params GetGoodreadsBookOpfParams)
+	// List covers of books using goodreads
+	// (GET /goodreads/covers)
+	ListGoodreadsCovers(w http.ResponseWriter, r *http.Request, params ListGoodreadsCoversParams)
 	// Search for books using goodreads
 	// (GET /goodreads/search)
 	SearchGoodreads(w http.ResponseWriter, r *http.Request, params SearchGoodreadsParams)
+	// Get a series from goodreads
+	// (GET /goodreads/series/{id})
+	GetGoodreadsSeries(w http.ResponseWriter, r *http.Request, id Id)
+	// Search for books using kindle, in the marketplace of a language
+	// (GET /kindle/search)
+	SearchKindleByLanguage(w http.ResponseWriter, r *http.Request, params SearchKindleByLanguageParams)
+	// List covers of books using kindle
+	// (GET /kindle/{region}/covers)
+	ListKindleCovers(w http.ResponseWriter, r *http.Request, region ListKindleCoversParamsRegion, params ListKindleCoversParams)
 	// Search for books using kindle
 	// (GET /kindle/{region}/search)
 	SearchKindle(w http.ResponseWriter, r *http.Request, region SearchKindleParamsRegion, params SearchKindleParams)
+	// Search for books in local libraries
+	// (GET /local/search)
+	SearchLocal(w http.ResponseWriter, r *http.Request, params SearchLocalParams)
+	// Render a book as an OPF file
+	// (POST /opf)
+	RenderOpf(w http.ResponseWriter, r *http.Request, params RenderOpfParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// List the libraries of audiobookshelf
+// (GET /abs/libraries)
+func (_ Unimplemented) ListAbsLibraries(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Enrich an audiobookshelf library
+// (POST /abs/libraries/{id}/enrich)
+func (_ Unimplemented) EnrichAbsLibrary(w http.ResponseWriter, r *http.Request, id Id) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Match a batch of books
+// (POST /batch/match)
+func (_ Unimplemented) MatchBatch(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the progress of a batch job
+// (GET /batch/{id})
+func (_ Unimplemented) GetBatch(w http.ResponseWriter, r *http.Request, id Id) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream the results of a batch job
+// (GET /batch/{id}/results)
+func (_ Unimplemented) GetBatchResults(w http.ResponseWriter, r *http.Request, id Id, params GetBatchResultsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the cover of a book in the calibre library
+// (GET /calibre/books/{id}/cover)
+func (_ Unimplemented) GetCalibreCover(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search for books in the calibre library
+// (GET /calibre/search)
+func (_ Unimplemented) SearchCalibre(w http.ResponseWriter, r *http.Request, params SearchCalibreParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a book cover
+// (GET /covers/{provider}/{id})
+func (_ Unimplemented) GetCover(w http.ResponseWriter, r *http.Request, provider GetCoverParamsProvider, id string, params GetCoverParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get an author from goodreads
+// (GET /goodreads/authors/{id})
+func (_ Unimplemented) GetGoodreadsAuthor(w http.ResponseWriter, r *http.Request, id Id, params GetGoodreadsAuthorParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List books by an author from goodreads
+// (GET /goodreads/authors/{id}/books)
+func (_ Unimplemented) ListGoodreadsAuthorBooks(w http.ResponseWriter, r *http.Request, id Id, params ListGoodreadsAuthorBooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a book from goodreads as an OPF file
+// (GET /goodreads/books/{id}.opf)
+func (_ Unimplemented) GetGoodreadsBookOpf(w http.ResponseWriter, r *http.Request, id Id, params GetGoodreadsBookOpfParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List covers of books using goodreads
+// (GET /goodreads/covers)
+func (_ Unimplemented) ListGoodreadsCovers(w http.ResponseWriter, r *http.Request, params ListGoodreadsCoversParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Search for books using goodreads
 // (GET /goodreads/search)
 func (_ Unimplemented) SearchGoodreads(w http.ResponseWriter, r *http.Request, params SearchGoodreadsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get a series from goodreads
+// (GET /goodreads/series/{id})
+func (_ Unimplemented) GetGoodreadsSeries(w http.ResponseWriter, r *http.Request, id Id) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search for books using kindle, in the marketplace of a language
+// (GET /kindle/search)
+func (_ Unimplemented) SearchKindleByLanguage(w http.ResponseWriter, r *http.Request, params SearchKindleByLanguageParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List covers of books using kindle
+// (GET /kindle/{region}/covers)
+func (_ Unimplemented) ListKindleCovers(w http.ResponseWriter, r *http.Request, region ListKindleCoversParamsRegion, params ListKindleCoversParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Search for books using kindle
 // (GET /kindle/{region}/search)
 func (_ Unimplemented) SearchKindle(w http.ResponseWriter, r *http.Request, region SearchKindleParamsRegion, params SearchKindleParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Search for books in local libraries
+// (GET /local/search)
+func (_ Unimplemented) SearchLocal(w http.ResponseWriter, r *http.Request, params SearchLocalParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Render a book as an OPF file
+// (POST /opf)
+func (_ Unimplemented) RenderOpf(w http.ResponseWriter, r *http.Request, params RenderOpfParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -144,70 +902,194 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// SearchGoodreads operation middleware
-func (siw *ServerInterfaceWrapper) SearchGoodreads(w http.ResponseWriter, r *http.Request) {
+// ListAbsLibraries operation middleware
+func (siw *ServerInterfaceWrapper) ListAbsLibraries(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAbsLibraries(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// EnrichAbsLibrary operation middleware
+func (siw *ServerInterfaceWrapper) EnrichAbsLibrary(w http.ResponseWriter, r *http.Request) {
+
 	var err error
 
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
 	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params SearchGoodreadsParams
+	r = r.WithContext(ctx)
 
-	// ------------- Required query parameter "query" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnrichAbsLibrary(w, r, id)
+	}))
 
-	if paramValue := r.URL.Query().Get("query"); paramValue != "" {
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "query"})
+	handler.ServeHTTP(w, r)
+}
+
+// MatchBatch operation middleware
+func (siw *ServerInterfaceWrapper) MatchBatch(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MatchBatch(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBatch operation middleware
+func (siw *ServerInterfaceWrapper) GetBatch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "query", r.URL.Query(), &params.Query)
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBatch(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBatchResults operation middleware
+func (siw *ServerInterfaceWrapper) GetBatchResults(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "author" -------------
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBatchResultsParams
+
+	// ------------- Optional query parameter "follow" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "follow", r.URL.Query(), &params.Follow)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "follow", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SearchGoodreads(w, r, params)
+		siw.Handler.GetBatchResults(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	handler.ServeHTTP(w, r)
 }
 
-// SearchKindle operation middleware
-func (siw *ServerInterfaceWrapper) SearchKindle(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// GetCalibreCover operation middleware
+func (siw *ServerInterfaceWrapper) GetCalibreCover(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "region" -------------
-	var region SearchKindleParamsRegion
+	// ------------- Path parameter "id" -------------
+	var id int
 
-	err = runtime.BindStyledParameterWithOptions("simple", "region", chi.URLParam(r, "region"), &region, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: false})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "region", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCalibreCover(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchCalibre operation middleware
+func (siw *ServerInterfaceWrapper) SearchCalibre(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
 	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
 
+	r = r.WithContext(ctx)
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params SearchKindleParams
+	var params SearchCalibreParams
 
 	// ------------- Required query parameter "query" -------------
 
@@ -232,303 +1114,2493 @@ func (siw *ServerInterfaceWrapper) SearchKindle(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minConfidence" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minConfidence", r.URL.Query(), &params.MinConfidence)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minConfidence", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "descriptionFormat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "descriptionFormat", r.URL.Query(), &params.DescriptionFormat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "descriptionFormat", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SearchKindle(w, r, region, params)
+		siw.Handler.SearchCalibre(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetCover operation middleware
+func (siw *ServerInterfaceWrapper) GetCover(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "provider" -------------
+	var provider GetCoverParamsProvider
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Path parameter "id" -------------
+	var id string
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-type RequiredParamError struct {
-	ParamName string
-}
+	ctx := r.Context()
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	r = r.WithContext(ctx)
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCoverParams
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Optional query parameter "width" -------------
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindQueryParameter("form", true, false, "width", r.URL.Query(), &params.Width)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "width", Err: err})
+		return
+	}
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Optional query parameter "height" -------------
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindQueryParameter("form", true, false, "height", r.URL.Query(), &params.Height)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "height", Err: err})
+		return
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	// ------------- Optional query parameter "format" -------------
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	// ------------- Optional query parameter "strip" -------------
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	err = runtime.BindQueryParameter("form", true, false, "strip", r.URL.Query(), &params.Strip)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "strip", Err: err})
+		return
+	}
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCover(w, r, provider, id, params)
+	}))
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// GetGoodreadsAuthor operation middleware
+func (siw *ServerInterfaceWrapper) GetGoodreadsAuthor(w http.ResponseWriter, r *http.Request) {
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	var err error
 
-	r.Group(func(r chi.Router) {
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGoodreadsAuthorParams
+
+	// ------------- Optional query parameter "descriptionFormat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "descriptionFormat", r.URL.Query(), &params.DescriptionFormat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "descriptionFormat", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGoodreadsAuthor(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListGoodreadsAuthorBooks operation middleware
+func (siw *ServerInterfaceWrapper) ListGoodreadsAuthorBooks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListGoodreadsAuthorBooksParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "descriptionFormat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "descriptionFormat", r.URL.Query(), &params.DescriptionFormat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "descriptionFormat", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListGoodreadsAuthorBooks(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetGoodreadsBookOpf operation middleware
+func (siw *ServerInterfaceWrapper) GetGoodreadsBookOpf(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetGoodreadsBookOpfParams
+
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "version", r.URL.Query(), &params.Version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGoodreadsBookOpf(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListGoodreadsCovers operation middleware
+func (siw *ServerInterfaceWrapper) ListGoodreadsCovers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListGoodreadsCoversParams
+
+	// ------------- Required query parameter "query" -------------
+
+	if paramValue := r.URL.Query().Get("query"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "query"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListGoodreadsCovers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchGoodreads operation middleware
+func (siw *ServerInterfaceWrapper) SearchGoodreads(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchGoodreadsParams
+
+	// ------------- Required query parameter "query" -------------
+
+	if paramValue := r.URL.Query().Get("query"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "query"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "language" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "language", r.URL.Query(), &params.Language)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "language", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "includeEditions" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeEditions", r.URL.Query(), &params.IncludeEditions)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeEditions", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minConfidence" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minConfidence", r.URL.Query(), &params.MinConfidence)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minConfidence", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "descriptionFormat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "descriptionFormat", r.URL.Query(), &params.DescriptionFormat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "descriptionFormat", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchGoodreads(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetGoodreadsSeries operation middleware
+func (siw *ServerInterfaceWrapper) GetGoodreadsSeries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetGoodreadsSeries(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchKindleByLanguage operation middleware
+func (siw *ServerInterfaceWrapper) SearchKindleByLanguage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchKindleByLanguageParams
+
+	// ------------- Required query parameter "query" -------------
+
+	if paramValue := r.URL.Query().Get("query"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "query"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "language" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "language", r.URL.Query(), &params.Language)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "language", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "includeEditions" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeEditions", r.URL.Query(), &params.IncludeEditions)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeEditions", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minConfidence" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minConfidence", r.URL.Query(), &params.MinConfidence)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minConfidence", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchKindleByLanguage(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListKindleCovers operation middleware
+func (siw *ServerInterfaceWrapper) ListKindleCovers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "region" -------------
+	var region ListKindleCoversParamsRegion
+
+	err = runtime.BindStyledParameterWithOptions("simple", "region", chi.URLParam(r, "region"), &region, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: false})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "region", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListKindleCoversParams
+
+	// ------------- Required query parameter "query" -------------
+
+	if paramValue := r.URL.Query().Get("query"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "query"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListKindleCovers(w, r, region, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchKindle operation middleware
+func (siw *ServerInterfaceWrapper) SearchKindle(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "region" -------------
+	var region SearchKindleParamsRegion
+
+	err = runtime.BindStyledParameterWithOptions("simple", "region", chi.URLParam(r, "region"), &region, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: false})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "region", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchKindleParams
+
+	// ------------- Required query parameter "query" -------------
+
+	if paramValue := r.URL.Query().Get("query"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "query"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "includeEditions" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includeEditions", r.URL.Query(), &params.IncludeEditions)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "includeEditions", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minConfidence" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minConfidence", r.URL.Query(), &params.MinConfidence)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minConfidence", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchKindle(w, r, region, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchLocal operation middleware
+func (siw *ServerInterfaceWrapper) SearchLocal(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchLocalParams
+
+	// ------------- Required query parameter "query" -------------
+
+	if paramValue := r.URL.Query().Get("query"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "query"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "query", r.URL.Query(), &params.Query)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "query", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author", r.URL.Query(), &params.Author)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "minConfidence" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minConfidence", r.URL.Query(), &params.MinConfidence)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minConfidence", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchLocal(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenderOpf operation middleware
+func (siw *ServerInterfaceWrapper) RenderOpf(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, Api_keyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RenderOpfParams
+
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "version", r.URL.Query(), &params.Version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenderOpf(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/abs/libraries", wrapper.ListAbsLibraries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/abs/libraries/{id}/enrich", wrapper.EnrichAbsLibrary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/batch/match", wrapper.MatchBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/batch/{id}", wrapper.GetBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/batch/{id}/results", wrapper.GetBatchResults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/calibre/books/{id}/cover", wrapper.GetCalibreCover)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/calibre/search", wrapper.SearchCalibre)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/covers/{provider}/{id}", wrapper.GetCover)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/goodreads/authors/{id}", wrapper.GetGoodreadsAuthor)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/goodreads/authors/{id}/books", wrapper.ListGoodreadsAuthorBooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/goodreads/books/{id}.opf", wrapper.GetGoodreadsBookOpf)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/goodreads/covers", wrapper.ListGoodreadsCovers)
+	})
+	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/goodreads/search", wrapper.SearchGoodreads)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/goodreads/series/{id}", wrapper.GetGoodreadsSeries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/kindle/search", wrapper.SearchKindleByLanguage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/kindle/{region}/covers", wrapper.ListKindleCovers)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/kindle/{region}/search", wrapper.SearchKindle)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/local/search", wrapper.SearchLocal)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/opf", wrapper.RenderOpf)
+	})
+
+	return r
+}
+
+type N200JSONResponse struct {
+	Matches *[]BookMetadata `json:"matches,omitempty"`
+}
+
+type N400JSONResponse struct {
+	Error *string `json:"error,omitempty"`
+}
+
+type N401JSONResponse struct {
+	Error *string `json:"error,omitempty"`
+}
+
+type N404JSONResponse struct {
+	Error *string `json:"error,omitempty"`
+}
+
+type N500JSONResponse struct {
+	Error *string `json:"error,omitempty"`
+}
+
+type N503JSONResponse struct {
+	// CircuitBreaker State of the circuit breaker stopping requests being made to a failing provider
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+	Error          *string         `json:"error,omitempty"`
+}
+
+type OpfApplicationoebpsPackageXmlResponse struct {
+	Body io.Reader
+
+	ContentLength int64
+}
+
+type ListAbsLibrariesRequestObject struct {
+}
+
+type ListAbsLibrariesResponseObject interface {
+	VisitListAbsLibrariesResponse(w http.ResponseWriter) error
+}
+
+type ListAbsLibraries200JSONResponse AbsLibraries
+
+func (response ListAbsLibraries200JSONResponse) VisitListAbsLibrariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAbsLibraries400JSONResponse struct{ N400JSONResponse }
+
+func (response ListAbsLibraries400JSONResponse) VisitListAbsLibrariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAbsLibraries401JSONResponse struct{ N401JSONResponse }
+
+func (response ListAbsLibraries401JSONResponse) VisitListAbsLibrariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAbsLibraries500JSONResponse struct{ N500JSONResponse }
+
+func (response ListAbsLibraries500JSONResponse) VisitListAbsLibrariesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrichAbsLibraryRequestObject struct {
+	Id   Id `json:"id"`
+	Body *EnrichAbsLibraryJSONRequestBody
+}
+
+type EnrichAbsLibraryResponseObject interface {
+	VisitEnrichAbsLibraryResponse(w http.ResponseWriter) error
+}
+
+type EnrichAbsLibrary200JSONResponse AbsEnrichReport
+
+func (response EnrichAbsLibrary200JSONResponse) VisitEnrichAbsLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrichAbsLibrary400JSONResponse struct{ N400JSONResponse }
+
+func (response EnrichAbsLibrary400JSONResponse) VisitEnrichAbsLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrichAbsLibrary401JSONResponse struct{ N401JSONResponse }
+
+func (response EnrichAbsLibrary401JSONResponse) VisitEnrichAbsLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrichAbsLibrary500JSONResponse struct{ N500JSONResponse }
+
+func (response EnrichAbsLibrary500JSONResponse) VisitEnrichAbsLibraryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MatchBatchRequestObject struct {
+	Body *MatchBatchJSONRequestBody
+}
+
+type MatchBatchResponseObject interface {
+	VisitMatchBatchResponse(w http.ResponseWriter) error
+}
+
+type MatchBatch202JSONResponse BatchJob
+
+func (response MatchBatch202JSONResponse) VisitMatchBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MatchBatch400JSONResponse struct{ N400JSONResponse }
+
+func (response MatchBatch400JSONResponse) VisitMatchBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MatchBatch401JSONResponse struct{ N401JSONResponse }
+
+func (response MatchBatch401JSONResponse) VisitMatchBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MatchBatch500JSONResponse struct{ N500JSONResponse }
+
+func (response MatchBatch500JSONResponse) VisitMatchBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBatchRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetBatchResponseObject interface {
+	VisitGetBatchResponse(w http.ResponseWriter) error
+}
+
+type GetBatch200JSONResponse BatchJob
+
+func (response GetBatch200JSONResponse) VisitGetBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBatch401JSONResponse struct{ N401JSONResponse }
+
+func (response GetBatch401JSONResponse) VisitGetBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBatch404JSONResponse struct{ N404JSONResponse }
+
+func (response GetBatch404JSONResponse) VisitGetBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBatchResultsRequestObject struct {
+	Id     Id `json:"id"`
+	Params GetBatchResultsParams
+}
+
+type GetBatchResultsResponseObject interface {
+	VisitGetBatchResultsResponse(w http.ResponseWriter) error
+}
+
+type GetBatchResults200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetBatchResults200ApplicationxNdjsonResponse) VisitGetBatchResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetBatchResults401JSONResponse struct{ N401JSONResponse }
+
+func (response GetBatchResults401JSONResponse) VisitGetBatchResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBatchResults404JSONResponse struct{ N404JSONResponse }
+
+func (response GetBatchResults404JSONResponse) VisitGetBatchResultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCalibreCoverRequestObject struct {
+	Id int `json:"id"`
+}
+
+type GetCalibreCoverResponseObject interface {
+	VisitGetCalibreCoverResponse(w http.ResponseWriter) error
+}
+
+type GetCalibreCover200ImagejpegResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetCalibreCover200ImagejpegResponse) VisitGetCalibreCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "image/jpeg")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetCalibreCover400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCalibreCover400JSONResponse) VisitGetCalibreCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCalibreCover401JSONResponse struct{ N401JSONResponse }
+
+func (response GetCalibreCover401JSONResponse) VisitGetCalibreCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCalibreCover404JSONResponse struct{ N404JSONResponse }
+
+func (response GetCalibreCover404JSONResponse) VisitGetCalibreCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCalibreCover500JSONResponse struct{ N500JSONResponse }
+
+func (response GetCalibreCover500JSONResponse) VisitGetCalibreCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchCalibreRequestObject struct {
+	Params SearchCalibreParams
+}
+
+type SearchCalibreResponseObject interface {
+	VisitSearchCalibreResponse(w http.ResponseWriter) error
+}
+
+type SearchCalibre200JSONResponse struct{ N200JSONResponse }
+
+func (response SearchCalibre200JSONResponse) VisitSearchCalibreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchCalibre400JSONResponse struct{ N400JSONResponse }
+
+func (response SearchCalibre400JSONResponse) VisitSearchCalibreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchCalibre401JSONResponse struct{ N401JSONResponse }
+
+func (response SearchCalibre401JSONResponse) VisitSearchCalibreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchCalibre500JSONResponse struct{ N500JSONResponse }
+
+func (response SearchCalibre500JSONResponse) VisitSearchCalibreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCoverRequestObject struct {
+	Provider GetCoverParamsProvider `json:"provider"`
+	Id       string                 `json:"id"`
+	Params   GetCoverParams
+}
+
+type GetCoverResponseObject interface {
+	VisitGetCoverResponse(w http.ResponseWriter) error
+}
+
+type GetCover200ImageResponse struct {
+	Body          io.Reader
+	ContentType   string
+	ContentLength int64
+}
+
+func (response GetCover200ImageResponse) VisitGetCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", response.ContentType)
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetCover400JSONResponse struct{ N400JSONResponse }
+
+func (response GetCover400JSONResponse) VisitGetCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCover401JSONResponse struct{ N401JSONResponse }
+
+func (response GetCover401JSONResponse) VisitGetCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCover404JSONResponse struct{ N404JSONResponse }
+
+func (response GetCover404JSONResponse) VisitGetCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCover500JSONResponse struct{ N500JSONResponse }
+
+func (response GetCover500JSONResponse) VisitGetCoverResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsAuthorRequestObject struct {
+	Id     Id `json:"id"`
+	Params GetGoodreadsAuthorParams
+}
+
+type GetGoodreadsAuthorResponseObject interface {
+	VisitGetGoodreadsAuthorResponse(w http.ResponseWriter) error
+}
+
+type GetGoodreadsAuthor200JSONResponse AuthorDetails
+
+func (response GetGoodreadsAuthor200JSONResponse) VisitGetGoodreadsAuthorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsAuthor400JSONResponse struct{ N400JSONResponse }
+
+func (response GetGoodreadsAuthor400JSONResponse) VisitGetGoodreadsAuthorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsAuthor401JSONResponse struct{ N401JSONResponse }
+
+func (response GetGoodreadsAuthor401JSONResponse) VisitGetGoodreadsAuthorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsAuthor500JSONResponse struct{ N500JSONResponse }
+
+func (response GetGoodreadsAuthor500JSONResponse) VisitGetGoodreadsAuthorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsAuthor503JSONResponse struct{ N503JSONResponse }
+
+func (response GetGoodreadsAuthor503JSONResponse) VisitGetGoodreadsAuthorResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsAuthorBooksRequestObject struct {
+	Id     Id `json:"id"`
+	Params ListGoodreadsAuthorBooksParams
+}
+
+type ListGoodreadsAuthorBooksResponseObject interface {
+	VisitListGoodreadsAuthorBooksResponse(w http.ResponseWriter) error
+}
+
+type ListGoodreadsAuthorBooks200JSONResponse AuthorBooks
+
+func (response ListGoodreadsAuthorBooks200JSONResponse) VisitListGoodreadsAuthorBooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsAuthorBooks400JSONResponse struct{ N400JSONResponse }
+
+func (response ListGoodreadsAuthorBooks400JSONResponse) VisitListGoodreadsAuthorBooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsAuthorBooks401JSONResponse struct{ N401JSONResponse }
+
+func (response ListGoodreadsAuthorBooks401JSONResponse) VisitListGoodreadsAuthorBooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsAuthorBooks500JSONResponse struct{ N500JSONResponse }
+
+func (response ListGoodreadsAuthorBooks500JSONResponse) VisitListGoodreadsAuthorBooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsAuthorBooks503JSONResponse struct{ N503JSONResponse }
+
+func (response ListGoodreadsAuthorBooks503JSONResponse) VisitListGoodreadsAuthorBooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsBookOpfRequestObject struct {
+	Id     Id `json:"id"`
+	Params GetGoodreadsBookOpfParams
+}
+
+type GetGoodreadsBookOpfResponseObject interface {
+	VisitGetGoodreadsBookOpfResponse(w http.ResponseWriter) error
+}
+
+type GetGoodreadsBookOpf200ApplicationoebpsPackageXmlResponse struct {
+	OpfApplicationoebpsPackageXmlResponse
+}
+
+func (response GetGoodreadsBookOpf200ApplicationoebpsPackageXmlResponse) VisitGetGoodreadsBookOpfResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/oebps-package+xml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetGoodreadsBookOpf400JSONResponse struct{ N400JSONResponse }
+
+func (response GetGoodreadsBookOpf400JSONResponse) VisitGetGoodreadsBookOpfResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsBookOpf401JSONResponse struct{ N401JSONResponse }
+
+func (response GetGoodreadsBookOpf401JSONResponse) VisitGetGoodreadsBookOpfResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsBookOpf500JSONResponse struct{ N500JSONResponse }
+
+func (response GetGoodreadsBookOpf500JSONResponse) VisitGetGoodreadsBookOpfResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsBookOpf503JSONResponse struct{ N503JSONResponse }
+
+func (response GetGoodreadsBookOpf503JSONResponse) VisitGetGoodreadsBookOpfResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsCoversRequestObject struct {
+	Params ListGoodreadsCoversParams
+}
+
+type ListGoodreadsCoversResponseObject interface {
+	VisitListGoodreadsCoversResponse(w http.ResponseWriter) error
+}
+
+type ListGoodreadsCovers200JSONResponse Covers
+
+func (response ListGoodreadsCovers200JSONResponse) VisitListGoodreadsCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsCovers400JSONResponse struct{ N400JSONResponse }
+
+func (response ListGoodreadsCovers400JSONResponse) VisitListGoodreadsCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsCovers401JSONResponse struct{ N401JSONResponse }
+
+func (response ListGoodreadsCovers401JSONResponse) VisitListGoodreadsCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsCovers500JSONResponse struct{ N500JSONResponse }
+
+func (response ListGoodreadsCovers500JSONResponse) VisitListGoodreadsCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListGoodreadsCovers503JSONResponse struct{ N503JSONResponse }
+
+func (response ListGoodreadsCovers503JSONResponse) VisitListGoodreadsCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchGoodreadsRequestObject struct {
+	Params SearchGoodreadsParams
+}
+
+type SearchGoodreadsResponseObject interface {
+	VisitSearchGoodreadsResponse(w http.ResponseWriter) error
+}
+
+type SearchGoodreads200JSONResponse struct{ N200JSONResponse }
+
+func (response SearchGoodreads200JSONResponse) VisitSearchGoodreadsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchGoodreads400JSONResponse struct{ N400JSONResponse }
+
+func (response SearchGoodreads400JSONResponse) VisitSearchGoodreadsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchGoodreads401JSONResponse struct{ N401JSONResponse }
+
+func (response SearchGoodreads401JSONResponse) VisitSearchGoodreadsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchGoodreads500JSONResponse struct{ N500JSONResponse }
+
+func (response SearchGoodreads500JSONResponse) VisitSearchGoodreadsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchGoodreads503JSONResponse struct{ N503JSONResponse }
+
+func (response SearchGoodreads503JSONResponse) VisitSearchGoodreadsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsSeriesRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetGoodreadsSeriesResponseObject interface {
+	VisitGetGoodreadsSeriesResponse(w http.ResponseWriter) error
+}
+
+type GetGoodreadsSeries200JSONResponse Series
+
+func (response GetGoodreadsSeries200JSONResponse) VisitGetGoodreadsSeriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsSeries400JSONResponse struct{ N400JSONResponse }
+
+func (response GetGoodreadsSeries400JSONResponse) VisitGetGoodreadsSeriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsSeries401JSONResponse struct{ N401JSONResponse }
+
+func (response GetGoodreadsSeries401JSONResponse) VisitGetGoodreadsSeriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsSeries500JSONResponse struct{ N500JSONResponse }
+
+func (response GetGoodreadsSeries500JSONResponse) VisitGetGoodreadsSeriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetGoodreadsSeries503JSONResponse struct{ N503JSONResponse }
+
+func (response GetGoodreadsSeries503JSONResponse) VisitGetGoodreadsSeriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindleByLanguageRequestObject struct {
+	Params SearchKindleByLanguageParams
+}
+
+type SearchKindleByLanguageResponseObject interface {
+	VisitSearchKindleByLanguageResponse(w http.ResponseWriter) error
+}
+
+type SearchKindleByLanguage200JSONResponse struct{ N200JSONResponse }
+
+func (response SearchKindleByLanguage200JSONResponse) VisitSearchKindleByLanguageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindleByLanguage400JSONResponse struct{ N400JSONResponse }
+
+func (response SearchKindleByLanguage400JSONResponse) VisitSearchKindleByLanguageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindleByLanguage401JSONResponse struct{ N401JSONResponse }
+
+func (response SearchKindleByLanguage401JSONResponse) VisitSearchKindleByLanguageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindleByLanguage500JSONResponse struct{ N500JSONResponse }
+
+func (response SearchKindleByLanguage500JSONResponse) VisitSearchKindleByLanguageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindleByLanguage503JSONResponse struct{ N503JSONResponse }
+
+func (response SearchKindleByLanguage503JSONResponse) VisitSearchKindleByLanguageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListKindleCoversRequestObject struct {
+	Region ListKindleCoversParamsRegion `json:"region,omitempty"`
+	Params ListKindleCoversParams
+}
+
+type ListKindleCoversResponseObject interface {
+	VisitListKindleCoversResponse(w http.ResponseWriter) error
+}
+
+type ListKindleCovers200JSONResponse Covers
+
+func (response ListKindleCovers200JSONResponse) VisitListKindleCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListKindleCovers400JSONResponse struct{ N400JSONResponse }
+
+func (response ListKindleCovers400JSONResponse) VisitListKindleCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListKindleCovers401JSONResponse struct{ N401JSONResponse }
+
+func (response ListKindleCovers401JSONResponse) VisitListKindleCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListKindleCovers500JSONResponse struct{ N500JSONResponse }
+
+func (response ListKindleCovers500JSONResponse) VisitListKindleCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListKindleCovers503JSONResponse struct{ N503JSONResponse }
+
+func (response ListKindleCovers503JSONResponse) VisitListKindleCoversResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindleRequestObject struct {
+	Region SearchKindleParamsRegion `json:"region,omitempty"`
+	Params SearchKindleParams
+}
+
+type SearchKindleResponseObject interface {
+	VisitSearchKindleResponse(w http.ResponseWriter) error
+}
+
+type SearchKindle200JSONResponse struct{ N200JSONResponse }
+
+func (response SearchKindle200JSONResponse) VisitSearchKindleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindle400JSONResponse struct{ N400JSONResponse }
+
+func (response SearchKindle400JSONResponse) VisitSearchKindleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindle401JSONResponse struct{ N401JSONResponse }
+
+func (response SearchKindle401JSONResponse) VisitSearchKindleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindle500JSONResponse struct{ N500JSONResponse }
+
+func (response SearchKindle500JSONResponse) VisitSearchKindleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	return r
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchKindle503JSONResponse struct{ N503JSONResponse }
+
+func (response SearchKindle503JSONResponse) VisitSearchKindleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchLocalRequestObject struct {
+	Params SearchLocalParams
+}
+
+type SearchLocalResponseObject interface {
+	VisitSearchLocalResponse(w http.ResponseWriter) error
+}
+
+type SearchLocal200JSONResponse struct{ N200JSONResponse }
+
+func (response SearchLocal200JSONResponse) VisitSearchLocalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchLocal400JSONResponse struct{ N400JSONResponse }
+
+func (response SearchLocal400JSONResponse) VisitSearchLocalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchLocal401JSONResponse struct{ N401JSONResponse }
+
+func (response SearchLocal401JSONResponse) VisitSearchLocalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SearchLocal500JSONResponse struct{ N500JSONResponse }
+
+func (response SearchLocal500JSONResponse) VisitSearchLocalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenderOpfRequestObject struct {
+	Params RenderOpfParams
+	Body   *RenderOpfJSONRequestBody
+}
+
+type RenderOpfResponseObject interface {
+	VisitRenderOpfResponse(w http.ResponseWriter) error
+}
+
+type RenderOpf200ApplicationoebpsPackageXmlResponse struct {
+	OpfApplicationoebpsPackageXmlResponse
+}
+
+func (response RenderOpf200ApplicationoebpsPackageXmlResponse) VisitRenderOpfResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/oebps-package+xml")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type RenderOpf400JSONResponse struct{ N400JSONResponse }
+
+func (response RenderOpf400JSONResponse) VisitRenderOpfResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenderOpf401JSONResponse struct{ N401JSONResponse }
+
+func (response RenderOpf401JSONResponse) VisitRenderOpfResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RenderOpf500JSONResponse struct{ N500JSONResponse }
+
+func (response RenderOpf500JSONResponse) VisitRenderOpfResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List the libraries of audiobookshelf
+	// (GET /abs/libraries)
+	ListAbsLibraries(ctx context.Context, request ListAbsLibrariesRequestObject) (ListAbsLibrariesResponseObject, error)
+	// Enrich an audiobookshelf library
+	// (POST /abs/libraries/{id}/enrich)
+	EnrichAbsLibrary(ctx context.Context, request EnrichAbsLibraryRequestObject) (EnrichAbsLibraryResponseObject, error)
+	// Match a batch of books
+	// (POST /batch/match)
+	MatchBatch(ctx context.Context, request MatchBatchRequestObject) (MatchBatchResponseObject, error)
+	// Get the progress of a batch job
+	// (GET /batch/{id})
+	GetBatch(ctx context.Context, request GetBatchRequestObject) (GetBatchResponseObject, error)
+	// Stream the results of a batch job
+	// (GET /batch/{id}/results)
+	GetBatchResults(ctx context.Context, request GetBatchResultsRequestObject) (GetBatchResultsResponseObject, error)
+	// Get the cover of a book in the calibre library
+	// (GET /calibre/books/{id}/cover)
+	GetCalibreCover(ctx context.Context, request GetCalibreCoverRequestObject) (GetCalibreCoverResponseObject, error)
+	// Search for books in the calibre library
+	// (GET /calibre/search)
+	SearchCalibre(ctx context.Context, request SearchCalibreRequestObject) (SearchCalibreResponseObject, error)
+	// Get a book cover
+	// (GET /covers/{provider}/{id})
+	GetCover(ctx context.Context, request GetCoverRequestObject) (GetCoverResponseObject, error)
+	// Get an author from goodreads
+	// (GET /goodreads/authors/{id})
+	GetGoodreadsAuthor(ctx context.Context, request GetGoodreadsAuthorRequestObject) (GetGoodreadsAuthorResponseObject, error)
+	// List books by an author from goodreads
+	// (GET /goodreads/authors/{id}/books)
+	ListGoodreadsAuthorBooks(ctx context.Context, request ListGoodreadsAuthorBooksRequestObject) (ListGoodreadsAuthorBooksResponseObject, error)
+	// Get a book from goodreads as an OPF file
+	// (GET /goodreads/books/{id}.opf)
+	GetGoodreadsBookOpf(ctx context.Context, request GetGoodreadsBookOpfRequestObject) (GetGoodreadsBookOpfResponseObject, error)
+	// List covers of books using goodreads
+	// (GET /goodreads/covers)
+	ListGoodreadsCovers(ctx context.Context, request ListGoodreadsCoversRequestObject) (ListGoodreadsCoversResponseObject, error)
+	// Search for books using goodreads
+	// (GET /goodreads/search)
+	SearchGoodreads(ctx context.Context, request SearchGoodreadsRequestObject) (SearchGoodreadsResponseObject, error)
+	// Get a series from goodreads
+	// (GET /goodreads/series/{id})
+	GetGoodreadsSeries(ctx context.Context, request GetGoodreadsSeriesRequestObject) (GetGoodreadsSeriesResponseObject, error)
+	// Search for books using kindle, in the marketplace of a language
+	// (GET /kindle/search)
+	SearchKindleByLanguage(ctx context.Context, request SearchKindleByLanguageRequestObject) (SearchKindleByLanguageResponseObject, error)
+	// List covers of books using kindle
+	// (GET /kindle/{region}/covers)
+	ListKindleCovers(ctx context.Context, request ListKindleCoversRequestObject) (ListKindleCoversResponseObject, error)
+	// Search for books using kindle
+	// (GET /kindle/{region}/search)
+	SearchKindle(ctx context.Context, request SearchKindleRequestObject) (SearchKindleResponseObject, error)
+	// Search for books in local libraries
+	// (GET /local/search)
+	SearchLocal(ctx context.Context, request SearchLocalRequestObject) (SearchLocalResponseObject, error)
+	// Render a book as an OPF file
+	// (POST /opf)
+	RenderOpf(ctx context.Context, request RenderOpfRequestObject) (RenderOpfResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListAbsLibraries operation middleware
+func (sh *strictHandler) ListAbsLibraries(w http.ResponseWriter, r *http.Request) {
+	var request ListAbsLibrariesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAbsLibraries(ctx, request.(ListAbsLibrariesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAbsLibraries")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAbsLibrariesResponseObject); ok {
+		if err := validResponse.VisitListAbsLibrariesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// EnrichAbsLibrary operation middleware
+func (sh *strictHandler) EnrichAbsLibrary(w http.ResponseWriter, r *http.Request, id Id) {
+	var request EnrichAbsLibraryRequestObject
+
+	request.Id = id
+
+	var body EnrichAbsLibraryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.EnrichAbsLibrary(ctx, request.(EnrichAbsLibraryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EnrichAbsLibrary")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(EnrichAbsLibraryResponseObject); ok {
+		if err := validResponse.VisitEnrichAbsLibraryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// MatchBatch operation middleware
+func (sh *strictHandler) MatchBatch(w http.ResponseWriter, r *http.Request) {
+	var request MatchBatchRequestObject
+
+	var body MatchBatchJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.MatchBatch(ctx, request.(MatchBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MatchBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(MatchBatchResponseObject); ok {
+		if err := validResponse.VisitMatchBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetBatch operation middleware
+func (sh *strictHandler) GetBatch(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetBatchRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBatch(ctx, request.(GetBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBatchResponseObject); ok {
+		if err := validResponse.VisitGetBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetBatchResults operation middleware
+func (sh *strictHandler) GetBatchResults(w http.ResponseWriter, r *http.Request, id Id, params GetBatchResultsParams) {
+	var request GetBatchResultsRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBatchResults(ctx, request.(GetBatchResultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBatchResults")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBatchResultsResponseObject); ok {
+		if err := validResponse.VisitGetBatchResultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCalibreCover operation middleware
+func (sh *strictHandler) GetCalibreCover(w http.ResponseWriter, r *http.Request, id int) {
+	var request GetCalibreCoverRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCalibreCover(ctx, request.(GetCalibreCoverRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCalibreCover")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCalibreCoverResponseObject); ok {
+		if err := validResponse.VisitGetCalibreCoverResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SearchCalibre operation middleware
+func (sh *strictHandler) SearchCalibre(w http.ResponseWriter, r *http.Request, params SearchCalibreParams) {
+	var request SearchCalibreRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchCalibre(ctx, request.(SearchCalibreRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchCalibre")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchCalibreResponseObject); ok {
+		if err := validResponse.VisitSearchCalibreResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type N200JSONResponse struct {
-	Matches *[]BookMetadata `json:"matches,omitempty"`
+// GetCover operation middleware
+func (sh *strictHandler) GetCover(w http.ResponseWriter, r *http.Request, provider GetCoverParamsProvider, id string, params GetCoverParams) {
+	var request GetCoverRequestObject
+
+	request.Provider = provider
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCover(ctx, request.(GetCoverRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCover")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCoverResponseObject); ok {
+		if err := validResponse.VisitGetCoverResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type N400JSONResponse struct {
-	Error *string `json:"error,omitempty"`
-}
+// GetGoodreadsAuthor operation middleware
+func (sh *strictHandler) GetGoodreadsAuthor(w http.ResponseWriter, r *http.Request, id Id, params GetGoodreadsAuthorParams) {
+	var request GetGoodreadsAuthorRequestObject
 
-type N401JSONResponse struct {
-	Error *string `json:"error,omitempty"`
-}
+	request.Id = id
+	request.Params = params
 
-type N500JSONResponse struct {
-	Error *string `json:"error,omitempty"`
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGoodreadsAuthor(ctx, request.(GetGoodreadsAuthorRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGoodreadsAuthor")
+	}
 
-type SearchGoodreadsRequestObject struct {
-	Params SearchGoodreadsParams
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type SearchGoodreadsResponseObject interface {
-	VisitSearchGoodreadsResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetGoodreadsAuthorResponseObject); ok {
+		if err := validResponse.VisitGetGoodreadsAuthorResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type SearchGoodreads200JSONResponse struct{ N200JSONResponse }
-
-func (response SearchGoodreads200JSONResponse) VisitSearchGoodreadsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// ListGoodreadsAuthorBooks operation middleware
+func (sh *strictHandler) ListGoodreadsAuthorBooks(w http.ResponseWriter, r *http.Request, id Id, params ListGoodreadsAuthorBooksParams) {
+	var request ListGoodreadsAuthorBooksRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Id = id
+	request.Params = params
 
-type SearchGoodreads400JSONResponse struct{ N400JSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListGoodreadsAuthorBooks(ctx, request.(ListGoodreadsAuthorBooksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListGoodreadsAuthorBooks")
+	}
 
-func (response SearchGoodreads400JSONResponse) VisitSearchGoodreadsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListGoodreadsAuthorBooksResponseObject); ok {
+		if err := validResponse.VisitListGoodreadsAuthorBooksResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type SearchGoodreads401JSONResponse struct{ N401JSONResponse }
-
-func (response SearchGoodreads401JSONResponse) VisitSearchGoodreadsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+// GetGoodreadsBookOpf operation middleware
+func (sh *strictHandler) GetGoodreadsBookOpf(w http.ResponseWriter, r *http.Request, id Id, params GetGoodreadsBookOpfParams) {
+	var request GetGoodreadsBookOpfRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Id = id
+	request.Params = params
 
-type SearchGoodreads500JSONResponse struct{ N500JSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGoodreadsBookOpf(ctx, request.(GetGoodreadsBookOpfRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGoodreadsBookOpf")
+	}
 
-func (response SearchGoodreads500JSONResponse) VisitSearchGoodreadsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetGoodreadsBookOpfResponseObject); ok {
+		if err := validResponse.VisitGetGoodreadsBookOpfResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type SearchKindleRequestObject struct {
-	Region SearchKindleParamsRegion `json:"region,omitempty"`
-	Params SearchKindleParams
-}
+// ListGoodreadsCovers operation middleware
+func (sh *strictHandler) ListGoodreadsCovers(w http.ResponseWriter, r *http.Request, params ListGoodreadsCoversParams) {
+	var request ListGoodreadsCoversRequestObject
 
-type SearchKindleResponseObject interface {
-	VisitSearchKindleResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type SearchKindle200JSONResponse struct{ N200JSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListGoodreadsCovers(ctx, request.(ListGoodreadsCoversRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListGoodreadsCovers")
+	}
 
-func (response SearchKindle200JSONResponse) VisitSearchKindleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListGoodreadsCoversResponseObject); ok {
+		if err := validResponse.VisitListGoodreadsCoversResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type SearchKindle400JSONResponse struct{ N400JSONResponse }
-
-func (response SearchKindle400JSONResponse) VisitSearchKindleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// SearchGoodreads operation middleware
+func (sh *strictHandler) SearchGoodreads(w http.ResponseWriter, r *http.Request, params SearchGoodreadsParams) {
+	var request SearchGoodreadsRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type SearchKindle401JSONResponse struct{ N401JSONResponse }
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchGoodreads(ctx, request.(SearchGoodreadsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchGoodreads")
+	}
 
-func (response SearchKindle401JSONResponse) VisitSearchKindleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchGoodreadsResponseObject); ok {
+		if err := validResponse.VisitSearchGoodreadsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type SearchKindle500JSONResponse struct{ N500JSONResponse }
+// GetGoodreadsSeries operation middleware
+func (sh *strictHandler) GetGoodreadsSeries(w http.ResponseWriter, r *http.Request, id Id) {
+	var request GetGoodreadsSeriesRequestObject
 
-func (response SearchKindle500JSONResponse) VisitSearchKindleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetGoodreadsSeries(ctx, request.(GetGoodreadsSeriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetGoodreadsSeries")
+	}
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Search for books using goodreads
-	// (GET /goodreads/search)
-	SearchGoodreads(ctx context.Context, request SearchGoodreadsRequestObject) (SearchGoodreadsResponseObject, error)
-	// Search for books using kindle
-	// (GET /kindle/{region}/search)
-	SearchKindle(ctx context.Context, request SearchKindleRequestObject) (SearchKindleResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetGoodreadsSeriesResponseObject); ok {
+		if err := validResponse.VisitGetGoodreadsSeriesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// SearchKindleByLanguage operation middleware
+func (sh *strictHandler) SearchKindleByLanguage(w http.ResponseWriter, r *http.Request, params SearchKindleByLanguageParams) {
+	var request SearchKindleByLanguageRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	request.Params = params
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchKindleByLanguage(ctx, request.(SearchKindleByLanguageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchKindleByLanguage")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchKindleByLanguageResponseObject); ok {
+		if err := validResponse.VisitSearchKindleByLanguageResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// SearchGoodreads operation middleware
-func (sh *strictHandler) SearchGoodreads(w http.ResponseWriter, r *http.Request, params SearchGoodreadsParams) {
-	var request SearchGoodreadsRequestObject
+// ListKindleCovers operation middleware
+func (sh *strictHandler) ListKindleCovers(w http.ResponseWriter, r *http.Request, region ListKindleCoversParamsRegion, params ListKindleCoversParams) {
+	var request ListKindleCoversRequestObject
 
+	request.Region = region
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.SearchGoodreads(ctx, request.(SearchGoodreadsRequestObject))
+		return sh.ssi.ListKindleCovers(ctx, request.(ListKindleCoversRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "SearchGoodreads")
+		handler = middleware(handler, "ListKindleCovers")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(SearchGoodreadsResponseObject); ok {
-		if err := validResponse.VisitSearchGoodreadsResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListKindleCoversResponseObject); ok {
+		if err := validResponse.VisitListKindleCoversResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -563,23 +3635,158 @@ func (sh *strictHandler) SearchKindle(w http.ResponseWriter, r *http.Request, re
 	}
 }
 
+// SearchLocal operation middleware
+func (sh *strictHandler) SearchLocal(w http.ResponseWriter, r *http.Request, params SearchLocalParams) {
+	var request SearchLocalRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SearchLocal(ctx, request.(SearchLocalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SearchLocal")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SearchLocalResponseObject); ok {
+		if err := validResponse.VisitSearchLocalResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RenderOpf operation middleware
+func (sh *strictHandler) RenderOpf(w http.ResponseWriter, r *http.Request, params RenderOpfParams) {
+	var request RenderOpfRequestObject
+
+	request.Params = params
+
+	var body RenderOpfJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RenderOpf(ctx, request.(RenderOpfRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RenderOpf")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RenderOpfResponseObject); ok {
+		if err := validResponse.VisitRenderOpfResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xWTXPjNgz9Kxq0R43ldLcX3XbbTuvJbtNJsoc24+nQEiIxlkgGBDPjZvTfO6DkyB+y",
-	"07SHvfRiS8QD+AjiAXqGwrbOGjTsIX8Gp0i1yEjxTQWuLcmTNpDDY0DaQApGtQj51pqCL2pslcB448Ti",
-	"mbSpoOvSwedEhO0r4WPQhCXkTAHPBewE7J01HiPD7+Zz+SusYTQcOTvX6EKxtiZ78NbI2hjPkXVIrHvv",
-	"VnFR94+asY0P3xLeQw7fZGNist7fZx+tXX9GVqViBV26ZaeI1CaedliwqwcsuKdboi9IO+EDOVxdit/7",
-	"/8QaifpLOc72q/t/VGVyjY8BPfdELr4SkS+mLx/9F5bC5PuvlpKFYSSjmuQG6Qkp+SnGOnbclmXccK8S",
-	"jugoryPde0utYqkQWUgP2aU7AjsyFfYJo+Ugb9efErYJ15hERKJbVeFU7D2/iQ3KQGpr3N/jx8GSaJN4",
-	"LKwpPaTjYbThcT9tGCskCVihoQMxHW26r5gUtF/tZyouTJymUaYKctKpqEbi8YlEurBqtK+x/B3VecS0",
-	"1SPpN/SImwg/3SVS8GHFmpvpo7Cq3pjCU7G63b56N8CWR3WdwgHjo2r20i9Mga9k5/z2A245qSssAmne",
-	"3EgKBwU5/ecax8FRoyqRxsnx4cvtL1fXiz8+3C6ufh3rRTl9iZte5drcW/FvdIHGR/aD8+fFLaQQqJHA",
-	"zM7nWWYdGm8DFTizVGWDk88EOyYZfgiebZtsk5X8RvZJ98yekHyvn/nsYjYXLwmqnIYc3s3mszmk4BTX",
-	"8YBZZW1JqEqfeVRU1LJYIR+r8Saak3tLycratU+C16ZKXvwh7tMrdlG+OPy8Y9+d6nfT5TtCsn4qd+mr",
-	"wKF7dcvpoTzl/YLLBDSOwvPY91vsxT/BXuzMk/NYAcUCDG2r5DPl9WQLPFtrUzaYPRNW2pruX95gH+XE",
-	"9V1ujQd3F9UgVTRqoWex9x2GJrSiOhUghUKBTANIAWMfl2KN40hLH39wooW1/OzKc5Tx//Xy9noZrrbb",
-	"7W7x+l762t1S0uDjJ0d/s/s188kWqpnsM33fasReW8/5u7mwWnZ/BwAA///3jNWoywsAAA==",
+	"H4sIAAAAAAAC/+w9aZPbNpZ/BcWdD+5dttQ+Zmqrv0y1z/EmGbtsZ7O7UXYLJJ8kuEmAAcBWa1z937fe",
+	"A8BLoKRu27HHyZdYTYLAw7svIB+SXFW1kiCtSc4/JDXXvAILmv7ijV0rjb+ETM6TXxvQ2yRNJK8gOQ9v",
+	"08Tka6g4DrPbGt8Yq4VcJTc3aVKAybWorVDyudIVtzis9zA5T9xzppas99wwLguWCbXSvF4LMDP2cska",
+	"acCmzK6B5UouxarRULClm0AY1hgokjQK7S4gfcD/pGGZnCf/Mu/QMXdvzfzpzpe4seXEbl7Jcss02EZL",
+	"lil1aXBj3MM4AdvydgA9VuqyB4koWgrV3K67aQXiQsOvjdBQJOdWN7CfWELmZVPAs0IQCXY399Ma7Bo0",
+	"sypsEa5Abxm4L9xWcdfs3kbYNRHK8AqYFbYEIqnjmpPZQr5csiUvDaRMIc5wbKWMZRpKuOLS9mcFnq/d",
+	"xML4paFYyAl8jvfR33UBS96UNqDDIyFTqgQuCQuXQhYlPP9y9P2OABhRueRy1fAV7EL0RFUVZwZQdi0U",
+	"LIw0qcNnrWEJmgRFaGNn7BkiUxgGgojJ2y9Yrgpg92C2mrFFUsAiSdkiWYFeJExptkhAnr54vEhOFlJp",
+	"xiUDuSqFWXcT4IbbCV6ArrhcJCczRlhz6CLGwG89dYVkSgJiETmgBZ5xDS2l04UUEt8L3f+uZZlui+H7",
+	"PepiuET76SQ3hfEHNF0pKhHhlx/4taiaismmykDjNjWYprSmE6KpdWnCKO8+OEuTyk2cnN8/w7+E9H+1",
+	"PC2khRVoAq4S8glioACZwzFM7ahkWQncWGbXwrC8nYDdy8BuACQ7I5m+f4L7ykDIFSGbBNUA1/naqeeJ",
+	"HQ6hiu70rNO0SaGarISkv/Xexs/ajTtU077VcmkgQpW/x6hhLkU9AamfZwrECBB97Kt6+Z+gDS09hsS/",
+	"CPz/6vVzthQlzNhTNz9B9nB2NgHYlZ/3WN3yqgMFIau9RolNXY95vt3x/YP85qaamDj8ebxpusHBplbS",
+	"AFmlB2dn+E+upAVJ5OV1XYqcI1Ln741DdDdfrVUN2gr3dcVtvnY/hYXKHGNvfwDLC255ctPul2vNt8lN",
+	"90Bl7yG3DtyRfH2H3z36KKhBa+eL7aqeg+s/5gV7A782YKwD5P4XAuRH6ey/+AcUDpJHXwiSvyvLnqtG",
+	"Ehh//mKUeSktaMlL9hb0FWj2jOba+RBBfPgRIOZC542wjzXwS9CHGP7JcPRN+lFbxJ2JHNiPkl9xUfIM",
+	"tZvnRtJunNVaXYkCfRENTCrrbUnFC2DcMEHO/ZKLkhYlhboHGQqy2pzWPL/kK/i366ocYiaiXHal9Sao",
+	"I0LfRWaerLmMel/03DuBAsrC/SxFprneMlQwM/a9wK3i5lbiCiTuKR95bRaubZKOyEYTHqLWRWae07ib",
+	"NJGwiWwxTVRZRJ7f9HXwz341N9hN9UuEES8y80xqka9fWqginEb4OF65dqjd0aw9thvinISEkRpHNlGa",
+	"0arB/cB1k3S82RAojeSvaD3PHsViX9NyUEwHRT7y2XDD/Fhk7l2A2lgjTSgsihKsqQtki+nVcF6Pa7YB",
+	"DSQsM3ZRbvjWuMAKPS9W6C3TDQVBkVinT34KFx1E3W47SNKWtHu54g3USttdvij09k0je3vtoQElG4p9",
+	"XppzSu2aW+YGI2ozaBEdWIDgHPsjaceJx7Jkj8MjbOlZ5WURJd0ko4y3E2WYKPxWWV4entCHRB686EyT",
+	"fBXFNTHWNGJHDNShJQ3kDqDHGcrTPdDnAF8592UPY41C+8nUhXLRDvJpEKGUAh7VWFbxS69H4iJLSjKS",
+	"GiEVTMkA2gyus9HCDgJRXpbORhgG1znUlsg1zo0Ydm+zFvnaT8R1x+bZ9sSFqUeycWsXxhwciQe7aGac",
+	"XtgT9nkWrhpj2ZpfQRcHcknwkw4SdiiuyUcEdWmirkATagdwu1TSNNEdoQP6PWpLDbzYEuREp34+Cqra",
+	"bsN4JIILAHc5IjgvB8MI3PzrMJhkZ9WGhH4XSWOS8SZcOoi50bgVwmIr8inz6ZbmMqRpGrNIfM4FM6Is",
+	"c9k1l9hqna2F7LYz4RG0W5sQzefBP4nIQrCrlY+c8G8uGW8KoQj2NZTLsdUFiVT/uTVDpsnCTy8c+BA0",
+	"yn6arEBq+mH5Cv+pmwzTUVCcboHr3gM9QmqaCJPhP9wI/KeX4snV1WC/nV6/yMz3BK1XPEM1VPZfHSuf",
+	"br5tNKjc1axi2vaGiXbAEhM2CgrB39HTMfHwKdKKhoyco5bXkICB22pV5NzYRRLzmlzIf8jzJNeDhvZB",
+	"i+6VuAAj8ggNsvD4E4T1XW7kaHP8Dh/3En1OQr3wtdWSA0bUZ12C2XRbmsbEU7BclDFc+NLJNkr/TGn5",
+	"lDsV2qlifBChYSGgOHrwWlVg1UZGl53gxlLIy110/vjm++BAr5QqUFUbhugJTDlG6UGuS5N6rayaXIoz",
+	"en94/o3Sl+aJalzseYCiHXPHyEhWIYRSo6wNmtag72fssXMaB+7AjvPABEaf7qkwjNwOhTZwIwzgB6j4",
+	"UG5R880W8vEgJ99/OVjJ08H5q4PKzuATsaRcPgbrmFohCzNkSxwWpUxXa9x5hUtEX0yFTzdTeP4Ple2i",
+	"+bVWKw3GF3IyHMfeq2wHdFQhJRxwnp1j4dGWImJGQQu9irrm7fwXdkfSTq2oouKWa+C3/ORwtOU2cTTg",
+	"4s5xkFvo+Djozq6Wsdw2RMXgYfzaQEOeqG6kRIh7BIia/4MxmNuM1x2OgY7RDO2eWig77d9B1A+g2rCp",
+	"I/6kZvmB2z2x0+3i4k5VEXWvX7qP7p+d+QpUePBtxRu/B/9+Xwg+3FdMfdIbD3DfajTGCVaQudaMJ6HK",
+	"HpW0x45nTVNGWPa4lGDIus0Y8WQb3XOPXKUZTeSYSKruS9xFNHkoC7iO5A/xcRB6YkAh298HtIFD+i2l",
+	"DpXwrRzbsc6hffilo+Tu+g72tOrwgKhAW/B/t/EdqTYhbZzEfRDPP0w4Ca3Q+jDtNk5Dvqfc/Te1aavZ",
+	"1lcXyNq1Po4w3e9+ITtlI5U0Y09UVTeYwV9qVdFXRlSi5FrY7UJ6BiBPJfU+WspcBEszYKQa2CTzDh/+",
+	"piKpF3IDlvSagySUrEm+P0KvuUh3n9tNI5iofEiyi+Ir0H8DsVpHWMU9DzvrzeT3dCnVhhxG94pLXm6N",
+	"MIh5kFgjKiacpCvQP4nCriOpcXz8yVccrBFhtKLR3EZL+0/9G1QJBnIlC9OnGMpGbEHf4PI6JDNC6DVS",
+	"PG9fsX//y9l9hr4e7dh/R35UmwkZ7L3m2rY6ib5Dbe27pYrZQnpL8+Ds7C+nZ/dPzx64DiD/dzA/+Oci",
+	"cc49txY0wvO/i0Xx4dHNvVP890H778lfT/76pxjz+NxN3/fYGTP2IkIg0KHQZFG90G+WigSHWnM7oTbq",
+	"22K9dV2VFisheVluO+ynmFEsyazwvBXt/RRbSIEJY6LYbajHfkPitcD+N/D9eIy/RbmQq0N27I0bddNm",
+	"/I51VN/S8H25nTavGGU7vrolY+4JRPu21w2LGd0nO/X5UQ3dIr2DanODWeZGM2NVXSOT6VBU75XPKa3h",
+	"S+es5++NCs1clI2Xx6n4JlfSQN5YcQUhLNS9Kj5C1pt/V6+FpMxofl61Gwufty5v6zAG7nVe4ym6wjG+",
+	"1GD19iJijd6JChhfWtDM1VamIGcbUZYYOlgtoGB8xcXQzO6LrI31SiM4RXmpXEeyqgGnWfNyeUq/fznk",
+	"mvtsqJsy7QjUbTLKR8GmD8m7bo10pCKoy49xAjbBFh+IdXGZMDoNAE1uIZLQzNvnR+kAh4lDiXU/aQyO",
+	"p5+sc30hn7lO27rkGB3AtU1ZxfVloTaSckSGS2EFhnJrW5WknAML0Tfk1rnxiDxblVGveqdx+KADz4zC",
+	"So30seOMuSlYocBQI46BsuzVbCIO/7ST/+r4tkeUCeY6dlBPLVswQz9kf+UH1A2JPZGxVd+0tmW4ontu",
+	"fAcOqUXCQEZBtPbpv0kNya9AR9uvL9wLZizXzBk2hqGmWrI/z9gZOpxIfPC9TX6IifruER/d55gn21fb",
+	"2SKeq0CsZE3wTg/b2qf9L0hUrgRszJNDUCBLMz/4cNoroDJsLyZ9EXgOY8HTFkMlOjDQI0mkteoK3tqB",
+	"99JD3VI1evqtkns+tWsN+15v1NTLEZrCKt03/cl7MKbdZmKofAvxiuXtqmVuFtQtMQfoUJA0kSJ2jA5F",
+	"vC2o1qLieotrHuRAP3bYCOPD7HtwjT4yCqZUV1CW3KRMVVJkjQHDwOazk6gA7XERMUF7FGARgI5MDIfC",
+	"93QJsEeT3RzKvtTIRwb+E9T0NNjfsxbSK3xAsmAHxgjqc8PBmMOgTxdN+LxWpj1K1AHRp0kbPt13UdPZ",
+	"7M/B57x/+jDubR7p9Q+IGXA0Tc7ptFh/f5HNBynfD40ft7s+zZE3mLZ6izLv2agW/3cJXSv/Grizjr6X",
+	"/+LHd3979ebl/1y8e/nq7x2SeC2+g61rrhVyqVyHRA7SEPT+4x9evku8A5qsra3N+XyuapBGNTqHmdKr",
+	"uf/IzHFsh/PkSWOsqlhAFnvd2e1wJOI8OZvdn525fmGQvBbJOXoN5D3gSTna4JxnZj5o3ljFDoxgB2+v",
+	"EwLVilqOTxaNelsMtXQ7799lgl4WfqpBL8lHHm44qscE1zl0MCE2UQvZHAd1ZwcOjb3fa6vfPxYHEfM1",
+	"ldMeE7geIpc+GdJu/kEUN3OgbkESHmUihPyJl5etDjDDZu0jKZp21QJyMzpfkgoePS9yITEWwGa1UJQw",
+	"UEJO2eK2X7Cf+RU2VCz6dRQlgXFLdf0KUmaUP5DFJbP8EhjHoLaE2UK+oTNcqF19f2PYku8UDkcpXW3k",
+	"qe8NZvdwjC9SnRzdHzlbyB3mds2avY6kdHCm+Oc4N3RD5qJIbn5xKguMfayK7acUhmEv6c1QOVrdwM3n",
+	"FcZBj/Q/izw6oKeb95wsUuPEvC1QxaXvbZNVAjn5vco6KQpdF6275E6QIlursm0+O9kRLzxEvCMs3qxj",
+	"wnWlsQsFIzyUz0YWUDCq+5MMhVlMe+rEgETYskYb684GuiTRbCHfuRxR2yjiq3okgxmwWpUlpntR3IU1",
+	"7alCYzXwChUJFZS2fUhnC/mjXApJTg1OFs68mga/2KxBMp6ZU6t5bt25Z2O5tlDEBI8K/VQrTD6P9Oy2",
+	"ExwlPg8+LQDYwRORm4s8h9qGM2VflfQQynZYvC8zaLcmHY8XYJkdMd+wS2nIBy/ABi64o+L9TNpvH/le",
+	"fXdbYviDg4fGPhoR4xA2R1SZezmepM5bkm+aMoj8cEYUfAmbUkg0sHSkG4XdKJmSWXdfsRo0wzHtQXem",
+	"NGajXRfBBgZaY5Lmbzy0dyF9uueuh0uA2qsyV2ZwO+UmckShkVaUrXqkY+O+hyl+5UMPbcOm/DBh/x6A",
+	"yTP6S1WWanOrix5ux+vXp7K4A787inxRlj/Ioo7pc45mFuaknRzzt3mCvYqJRrF79M/sfb066WWXhRw7",
+	"1H6Z3hmlHVZ+4oa4BP4OK08d3xust7PInW9H6TI0h/mF8iXz9zWshlzS5nkzIQfnsvYfQf18lux4Trqz",
+	"1Rtyxy5HjCg0YEHX5jKtc+k1ZXkHybVpLku95zg+joKeGv7OuMHg6cI13bhWIN8BNGhv7rbhlBOdTIZr",
+	"nttyG1HLDlDPzrdWygQL6eUDA32m74iRZH6OGeivuzhi5LCx84gPdu9hmpKs/Sz3ILD9V+XsTfJmlN+p",
+	"+Dj/EAKRm8OeoGc/J1XUcMZ7VXNFQ6kFRYPBOxYoIMmVvAJtXRs3Z4VYLkGDtL7MNltIV3MlU5tz17lu",
+	"cKhbRxhnrpfgLHLb6BZWZkrmMOGYTKjxiELuld6m1fKeVlJaXAYMxwrs09aDNpoyHkpInmy1VtfYBuDw",
+	"0OjS+CtjPLOmn+LarakbgzY77Wwz9q5LzpuWxlaxpbApuWmo5gT5ZjXklopfasJnCrX4DrT9l7pMwbne",
+	"7fT7tIC6BT4CUl/1tiqIQs80WTVjP0H2muWdDJTKmBKMOf46r8CWZPzTpKaC4way+ig+7LnaOKjujJRL",
+	"hDz7r5fPTzqxy71IxWCj7+N+sD8hewdH2Dk2//qHVzNWwfR63uoib4rNEXpchvNaRNV2htT383lvRei2",
+	"kWRLqtydX4uo2RdhhotwaO1OIeAnM96fJmE6OOL4FadL2+t5Do19GGOoCV7Yx1zztng/Xa3i7WFJf6Wb",
+	"FtaCpHTo1JKxWtWItR77PqDPwV8I8FfLh4993u7b40Jil/a08rH82GUKZv4+pkNu63A+nxNn11Xp7wYI",
+	"zV5t/j/YQVyAGVFAzvUJw6+xXx+BDY41x9Omw/KEc0IyMIObRPs9CHh4ylW2XCjYwY2wta1408oWWeJV",
+	"vfxM0tC7NvBuMRKS5RvQj1PM0+OYMW92zaL7q/mFMFbI3Abvr1WWy1A5cskItMc9I80x2+hqUcGLpCqQ",
+	"yC8pz/m65DmsVUlXmsmCWSG3fQdTKts/drFf7fqG2M+eP/icGtTv4ZtVnmP2acyAY8bsedsM13i6eKbp",
+	"Re/9t5hrak/1HDHWRydHjBxfFv1H0utzSstB1t6RlLa154CH4Ubuiafw9q14N+a0gX8bhnxdtVQP1jcb",
+	"EcWJ6XjDZfvuqELdx2ngAF7xfyhJZzHA1mi021ur4teKL+S9Ng0TnrGW8O66j/hl4yeurLDmhikJrhYq",
+	"VTcJvuB9SNy95Y0ZQOed1sligz8Bsv2+u9Dq924JBtf5/yb24A/VvkfkRrLW/c8HBtL9wd2GcfNZHPkA",
+	"0W/hxTuBnHLhI9UDt/Focpk3SZrknC4QSdKE7NLSJ4LTRNgkTd7XSZo0l/gfM5F6/iNs+NrDBsefcXn4",
+	"GLO312Z8m8z5RazTHxbna7A4Tn5KlfPyE3SU0DztQYFCaMit0gJMv7fE8lVoDhbaNUu7w6sLea96lKWs",
+	"esRTVtWPyJxU9cMT+uGGtyddffazn/s8mbFDzSl01cztu1O+x339PnpTvvE2kz6HuuAwTeY+MR9vxn8D",
+	"sujurQ8598ElQyd3TdIv5ESWfnSGIMKYDqy7pNV3EuafoQt+eLfWsedH/kny9AMmG7LHTr69d16QaNOe",
+	"FPz5F0S+OyoV69kklRM9uedOAhIjr5Wx5w/PEKhfbv5/ABIYIS6mcAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
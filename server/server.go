@@ -2,34 +2,451 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
 
+	"github.com/ahobsonsayers/abs-tract/abs"
+	"github.com/ahobsonsayers/abs-tract/batch"
+	"github.com/ahobsonsayers/abs-tract/calibre"
+	"github.com/ahobsonsayers/abs-tract/config"
+	"github.com/ahobsonsayers/abs-tract/cover"
+	"github.com/ahobsonsayers/abs-tract/description"
+	"github.com/ahobsonsayers/abs-tract/goodreads"
+	"github.com/ahobsonsayers/abs-tract/kindle"
+	"github.com/ahobsonsayers/abs-tract/local"
+	"github.com/ahobsonsayers/abs-tract/utils"
 	"github.com/samber/lo"
+	"golang.org/x/text/language"
 )
 
-type server struct{}
+type server struct {
+	config          config.Config
+	goodreadsClient *goodreads.Client
 
-func NewServer() StrictServerInterface { return &server{} }
+	kindleOptions      []kindle.ClientOption
+	kindleClients      map[string]*kindle.Client
+	kindleClientsMutex sync.Mutex
 
-func (*server) SearchGoodreads(
+	coverClient *cover.Client
+	coverQuery  url.Values
+
+	descriptionFormat description.Format
+	languages         []language.Tag
+
+	batchQueue *batch.Queue
+	// absClient is the client of the audiobookshelf server whose libraries are enriched. nil if not configured
+	absClient *abs.Client
+	// localLibrary is the library of books in local directories. nil if not configured
+	localLibrary *local.Library
+	// calibreLibrary is the calibre library searched for books. nil if not configured
+	calibreLibrary *calibre.Library
+}
+
+// NewServer creates a new server using the config.
+// Will return an error if the config is invalid.
+func NewServer(cfg config.Config) (StrictServerInterface, error) {
+	retryPolicy := utils.DefaultRetryPolicy
+	retryPolicy.MaxRetries = cfg.RequestRetries
+
+	goodreadsClient, err := NewGoodreadsClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	kindleOptions, err := newKindleOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	coverClient, err := newCoverClient(cfg, retryPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	coverQuery, err := newCoverQuery(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptionFormat := description.DefaultFormat
+	if cfg.DescriptionFormat != "" {
+		parsedDescriptionFormat := description.Formats.Parse(cfg.DescriptionFormat)
+		if parsedDescriptionFormat == nil {
+			return nil, fmt.Errorf("invalid description format: %s", cfg.DescriptionFormat)
+		}
+		descriptionFormat = *parsedDescriptionFormat
+	}
+
+	languages, err := utils.ParseLanguages(cfg.Languages)
+	if err != nil {
+		return nil, fmt.Errorf("invalid languages: %w", err)
+	}
+
+	s := &server{
+		config:          cfg,
+		goodreadsClient: goodreadsClient,
+		kindleOptions:   kindleOptions,
+		kindleClients:   make(map[string]*kindle.Client),
+		coverClient:     coverClient,
+		coverQuery:      coverQuery,
+
+		descriptionFormat: descriptionFormat,
+		languages:         languages,
+	}
+
+	if cfg.AbsURL != "" {
+		s.absClient, err = abs.NewClient(
+			cfg.AbsURL,
+			cfg.AbsToken,
+			abs.WithTimeout(cfg.RequestTimeout),
+			abs.WithRetryPolicy(retryPolicy),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cfg.LocalLibraryDirs) != 0 {
+		s.localLibrary, err = local.NewLibrary(
+			cfg.LocalLibraryDirs,
+			local.WithScanInterval(cfg.LocalLibraryScanInterval),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.CalibreLibraryDir != "" {
+		s.calibreLibrary, err = calibre.NewLibrary(cfg.CalibreLibraryDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.batchQueue, err = batch.NewQueue(cfg.BatchDir, cfg.BatchWorkers, cfg.BatchRetention, s.matchBatchItem)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// NewGoodreadsClient creates a goodreads client using the config.
+// Will return an error if the config is invalid.
+func NewGoodreadsClient(cfg config.Config) (*goodreads.Client, error) {
+	retryPolicy := utils.DefaultRetryPolicy
+	retryPolicy.MaxRetries = cfg.RequestRetries
+
+	genreTaxonomy, err := newGenreTaxonomy(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure genres: %w", err)
+	}
+
+	tagExtractor, err := newTagExtractor(cfg, genreTaxonomy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure tags: %w", err)
+	}
+
+	goodreadsOptions := []goodreads.ClientOption{
+		goodreads.WithURL(cfg.GoodreadsURL),
+		goodreads.WithProxy(cfg.ProxyURL),
+		goodreads.WithTimeout(cfg.RequestTimeout),
+		goodreads.WithRetryPolicy(retryPolicy),
+		goodreads.WithSearchConfidence(cfg.GoodreadsSearchConfidence),
+		goodreads.WithGenreTaxonomy(genreTaxonomy),
+		goodreads.WithTagExtractor(tagExtractor),
+	}
+	if len(cfg.GoodreadsSearchStrategies) != 0 {
+		goodreadsSearchStrategies := make([]goodreads.SearchStrategy, 0, len(cfg.GoodreadsSearchStrategies))
+		for _, strategy := range cfg.GoodreadsSearchStrategies {
+			goodreadsSearchStrategy := goodreads.SearchStrategies.Parse(strategy)
+			if goodreadsSearchStrategy == nil {
+				return nil, fmt.Errorf("invalid goodreads search strategy: %s", strategy)
+			}
+			goodreadsSearchStrategies = append(goodreadsSearchStrategies, *goodreadsSearchStrategy)
+		}
+		goodreadsOptions = append(goodreadsOptions, goodreads.WithSearchStrategies(goodreadsSearchStrategies...))
+	}
+	if breaker := newCircuitBreaker(cfg, "goodreads"); breaker != nil {
+		goodreadsOptions = append(goodreadsOptions, goodreads.WithCircuitBreaker(breaker))
+	}
+
+	return goodreads.NewClient(goodreadsOptions...)
+}
+
+// NewKindleClient creates a kindle client of a region using the config.
+// Will return an error if the config or region is invalid.
+func NewKindleClient(cfg config.Config, region string) (*kindle.Client, error) {
+	kindleOptions, err := newKindleOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKindleClient(cfg, region, kindleOptions)
+}
+
+// newKindleOptions creates the options of kindle clients using the config.
+// Kindle clients created using the same options share their proxy pool.
+func newKindleOptions(cfg config.Config) ([]kindle.ClientOption, error) {
+	retryPolicy := utils.DefaultRetryPolicy
+	retryPolicy.MaxRetries = cfg.RequestRetries
+
+	kindleOptions := []kindle.ClientOption{
+		kindle.WithURL(cfg.KindleURL),
+		kindle.WithProxy(cfg.ProxyURL),
+		kindle.WithTimeout(cfg.RequestTimeout),
+		kindle.WithRetryPolicy(retryPolicy),
+	}
+	if len(cfg.KindleImpersonations) != 0 {
+		kindleImpersonations := make([]kindle.Impersonation, 0, len(cfg.KindleImpersonations))
+		for _, impersonation := range cfg.KindleImpersonations {
+			kindleImpersonation := kindle.Impersonations.Parse(impersonation)
+			if kindleImpersonation == nil {
+				return nil, fmt.Errorf("invalid kindle impersonation: %s", impersonation)
+			}
+			kindleImpersonations = append(kindleImpersonations, *kindleImpersonation)
+		}
+		kindleOptions = append(kindleOptions, kindle.WithImpersonations(kindleImpersonations...))
+	}
+
+	if len(cfg.KindleProxies) != 0 {
+		kindleProxyPool, err := newKindleProxyPool(cfg)
+		if err != nil {
+			return nil, err
+		}
+		kindleOptions = append(kindleOptions, kindle.WithProxyPool(kindleProxyPool))
+	}
+
+	return kindleOptions, nil
+}
+
+// newKindleClient creates a kindle client of a region using the options of kindle clients.
+// Each region has its own circuit breaker, so a failing region does not stop requests to others.
+func newKindleClient(cfg config.Config, region string, kindleOptions []kindle.ClientOption) (*kindle.Client, error) {
+	if breaker := newCircuitBreaker(cfg, "kindle-"+region); breaker != nil {
+		kindleOptions = append(slices.Clone(kindleOptions), kindle.WithCircuitBreaker(breaker))
+	}
+
+	return kindle.NewClient(&region, kindleOptions...)
+}
+
+// newGenreTaxonomy creates the taxonomy converting goodreads shelves to genres using the config.
+func newGenreTaxonomy(cfg config.Config) (*goodreads.GenreTaxonomy, error) {
+	genreTaxonomy := *goodreads.DefaultGenreTaxonomy
+	if cfg.GenreTaxonomyFile != "" {
+		loadedGenreTaxonomy, err := goodreads.LoadGenreTaxonomy(cfg.GenreTaxonomyFile)
+		if err != nil {
+			return nil, err
+		}
+		genreTaxonomy = *loadedGenreTaxonomy
+	}
+
+	genreTaxonomy.MaxGenres = cfg.MaxGenres
+	genreTaxonomy.MinShelfCount = cfg.MinGenreShelfCount
+
+	return &genreTaxonomy, nil
+}
+
+// newTagExtractor creates the extractor converting goodreads shelves to tags using the config.
+// Shelves that are genres of the genre taxonomy are never tags.
+func newTagExtractor(cfg config.Config, genreTaxonomy *goodreads.GenreTaxonomy) (*goodreads.TagExtractor, error) {
+	tagExtractor := &goodreads.TagExtractor{
+		MaxTags:        cfg.MaxTags,
+		MinShelfWeight: cfg.MinTagShelfWeight,
+		GenreTaxonomy:  genreTaxonomy,
+	}
+
+	err := tagExtractor.BlockShelves(cfg.TagBlocklist...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tagExtractor, nil
+}
+
+// newKindleProxyPool creates the pool of proxies shared by the kindle clients of all regions.
+func newKindleProxyPool(cfg config.Config) (*kindle.ProxyPool, error) {
+	proxyPool, err := kindle.NewProxyPool(cfg.KindleProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kindle proxies: %w", err)
+	}
+
+	if cfg.KindleProxyRotation != "" {
+		proxyRotation := kindle.ProxyRotations.Parse(cfg.KindleProxyRotation)
+		if proxyRotation == nil {
+			return nil, fmt.Errorf("invalid kindle proxy rotation: %s", cfg.KindleProxyRotation)
+		}
+		proxyPool.Rotation = *proxyRotation
+	}
+
+	if cfg.KindleProxyUnhealthyTimeout != 0 {
+		proxyPool.UnhealthyTimeout = cfg.KindleProxyUnhealthyTimeout
+	}
+
+	return proxyPool, nil
+}
+
+// kindleClient gets the kindle client of a region, creating it if it does not exist.
+func (s *server) kindleClient(region string) (*kindle.Client, error) {
+	s.kindleClientsMutex.Lock()
+	defer s.kindleClientsMutex.Unlock()
+
+	kindleClient, ok := s.kindleClients[region]
+	if ok {
+		return kindleClient, nil
+	}
+
+	kindleClient, err := newKindleClient(s.config, region, s.kindleOptions)
+	if err != nil {
+		return nil, err
+	}
+	s.kindleClients[region] = kindleClient
+
+	return kindleClient, nil
+}
+
+// descriptionFormatOrDefault gets the description format of a request,
+// falling back to the configured format if the request does not set one.
+func (s *server) descriptionFormatOrDefault(requestFormat *DescriptionFormat) description.Format {
+	if requestFormat == nil {
+		return s.descriptionFormat
+	}
+
+	// Request formats are validated against the schema, so should always parse
+	format := description.Formats.Parse(string(*requestFormat))
+	if format == nil {
+		return s.descriptionFormat
+	}
+	return *format
+}
+
+func (s *server) SearchGoodreads(
 	ctx context.Context,
 	request SearchGoodreadsRequestObject,
 ) (SearchGoodreadsResponseObject, error) {
-	books, err := searchGoodreadsBooks(ctx, request.Params.Query, request.Params.Author)
+	options, err := s.goodreadsSearchOptions(request.Params)
+	if err != nil {
+		return SearchGoodreads400JSONResponse{N400JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	books, err := s.searchGoodreadsBooks(ctx, request.Params.Query, request.Params.Author, options)
 	if err != nil {
+		if response, ok := unavailableResponse(err); ok {
+			return SearchGoodreads503JSONResponse{response}, nil
+		}
 		return SearchGoodreads500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
 	}
 
 	return SearchGoodreads200JSONResponse{N200JSONResponse{Matches: &books}}, nil
 }
 
-func (*server) SearchKindle(
+func (s *server) SearchKindle(
 	ctx context.Context,
 	request SearchKindleRequestObject,
 ) (SearchKindleResponseObject, error) {
-	books, err := searchKindleBooks(ctx, request.Region, request.Params.Query, request.Params.Author)
+	books, err := s.searchKindleBooks(
+		ctx,
+		request.Region,
+		request.Params.Query,
+		request.Params.Author,
+		s.kindleSearchOptions(request.Params),
+	)
 	if err != nil {
+		if response, ok := unavailableResponse(err); ok {
+			return SearchKindle503JSONResponse{response}, nil
+		}
 		return SearchKindle500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
 	}
 
 	return SearchKindle200JSONResponse{N200JSONResponse{Matches: &books}}, nil
 }
+
+func (s *server) SearchKindleByLanguage(
+	ctx context.Context,
+	request SearchKindleByLanguageRequestObject,
+) (SearchKindleByLanguageResponseObject, error) {
+	languages, _, err := s.languagesOrDefault(request.Params.Language)
+	if err != nil {
+		return SearchKindleByLanguage400JSONResponse{N400JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	books, err := s.searchKindleBooks(
+		ctx,
+		kindleLanguagesRegion(languages),
+		request.Params.Query,
+		request.Params.Author,
+		s.kindleSearchOptions(SearchKindleParams{
+			Query:           request.Params.Query,
+			Author:          request.Params.Author,
+			Limit:           request.Params.Limit,
+			Offset:          request.Params.Offset,
+			Format:          request.Params.Format,
+			IncludeEditions: request.Params.IncludeEditions,
+			MinConfidence:   request.Params.MinConfidence,
+		}),
+	)
+	if err != nil {
+		if response, ok := unavailableResponse(err); ok {
+			return SearchKindleByLanguage503JSONResponse{response}, nil
+		}
+		return SearchKindleByLanguage500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return SearchKindleByLanguage200JSONResponse{N200JSONResponse{Matches: &books}}, nil
+}
+
+func (s *server) GetGoodreadsAuthor(
+	ctx context.Context,
+	request GetGoodreadsAuthorRequestObject,
+) (GetGoodreadsAuthorResponseObject, error) {
+	author, err := s.getGoodreadsAuthor(
+		ctx,
+		request.Id,
+		s.descriptionFormatOrDefault(request.Params.DescriptionFormat),
+	)
+	if err != nil {
+		if response, ok := unavailableResponse(err); ok {
+			return GetGoodreadsAuthor503JSONResponse{response}, nil
+		}
+		return GetGoodreadsAuthor500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return GetGoodreadsAuthor200JSONResponse(author), nil
+}
+
+func (s *server) ListGoodreadsAuthorBooks(
+	ctx context.Context,
+	request ListGoodreadsAuthorBooksRequestObject,
+) (ListGoodreadsAuthorBooksResponseObject, error) {
+	authorBooks, err := s.listGoodreadsAuthorBooks(
+		ctx,
+		request.Id,
+		lo.FromPtr(request.Params.Page),
+		s.descriptionFormatOrDefault(request.Params.DescriptionFormat),
+	)
+	if err != nil {
+		if response, ok := unavailableResponse(err); ok {
+			return ListGoodreadsAuthorBooks503JSONResponse{response}, nil
+		}
+		return ListGoodreadsAuthorBooks500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return ListGoodreadsAuthorBooks200JSONResponse(authorBooks), nil
+}
+
+func (s *server) GetGoodreadsSeries(
+	ctx context.Context,
+	request GetGoodreadsSeriesRequestObject,
+) (GetGoodreadsSeriesResponseObject, error) {
+	series, err := s.getGoodreadsSeries(ctx, request.Id)
+	if err != nil {
+		if response, ok := unavailableResponse(err); ok {
+			return GetGoodreadsSeries503JSONResponse{response}, nil
+		}
+		return GetGoodreadsSeries500JSONResponse{N500JSONResponse{Error: lo.ToPtr(err.Error())}}, nil
+	}
+
+	return GetGoodreadsSeries200JSONResponse(series), nil
+}
@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/orsinium-labs/enum"
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState enum.Member[string]
+
+var (
+	circuitStateEnum = enum.NewBuilder[string, CircuitState]()
+
+	// CircuitStateClosed allows all requests to be made.
+	CircuitStateClosed = circuitStateEnum.Add(CircuitState{"closed"})
+	// CircuitStateOpen fails requests without making them.
+	CircuitStateOpen = circuitStateEnum.Add(CircuitState{"open"})
+	// CircuitStateHalfOpen allows a single trial request to be made.
+	// If it succeeds the circuit is closed, otherwise it is opened again.
+	CircuitStateHalfOpen = circuitStateEnum.Add(CircuitState{"half-open"})
+
+	CircuitStates = circuitStateEnum.Enum()
+)
+
+// CircuitOpenError is returned when a request is not made because a circuit breaker is open.
+type CircuitOpenError struct {
+	Name     string
+	State    CircuitState
+	Failures int
+	RetryAt  time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf(
+		"%s is unavailable after %d failed requests. Not retrying until %s",
+		e.Name, e.Failures, e.RetryAt.Format(time.RFC3339),
+	)
+}
+
+// CircuitBreaker stops requests being made to a server after too many consecutive failures,
+// failing them instead. After a timeout, a trial request is allowed to check if the server has recovered.
+// Circuit breakers should be created using NewCircuitBreaker.
+type CircuitBreaker struct {
+	// Name is the name of the server requests are made to, used in errors.
+	Name string
+
+	// FailureThreshold is the number of consecutive failures that open the circuit.
+	FailureThreshold int
+
+	// OpenTimeout is the time the circuit stays open before a trial request is allowed.
+	OpenTimeout time.Duration
+
+	// OnStateChange is called when the state of the circuit changes.
+	// It is called while the circuit breaker is locked, so must not call its methods.
+	OnStateChange func(name string, from, to CircuitState)
+
+	mutex    sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trialing bool
+}
+
+// NewCircuitBreaker creates a new circuit breaker that is closed.
+func NewCircuitBreaker(name string, failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Name:             name,
+		FailureThreshold: failureThreshold,
+		OpenTimeout:      openTimeout,
+		state:            CircuitStateClosed,
+	}
+}
+
+// State returns the state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.updateState(time.Now())
+	return b.state
+}
+
+// Allow returns whether a request can be made. If not, a *CircuitOpenError is returned.
+// If a request is allowed, its outcome must be recorded using Record (or Release), passing whether
+// it is the trial request of a half open circuit, as returned by Allow.
+func (b *CircuitBreaker) Allow() (trial bool, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.updateState(time.Now())
+	switch b.state {
+	case CircuitStateOpen:
+		return false, b.openError()
+	case CircuitStateHalfOpen:
+		// Only allow a single trial request at a time
+		if b.trialing {
+			return false, b.openError()
+		}
+		b.trialing = true
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Record records whether a request was successful.
+// Only the trial request allows another trial request to be made.
+func (b *CircuitBreaker) Record(trial, success bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if trial {
+		b.trialing = false
+	}
+
+	if success {
+		b.failures = 0
+		b.setState(CircuitStateClosed)
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitStateHalfOpen || b.failures >= max(b.FailureThreshold, 1) {
+		b.openedAt = time.Now()
+		b.setState(CircuitStateOpen)
+	}
+}
+
+// Release allows another trial request to be made if a request is the trial request,
+// without recording the outcome of the request. This should be used instead of Record
+// for requests whose outcome says nothing about the server, such as cancelled requests.
+func (b *CircuitBreaker) Release(trial bool) {
+	if !trial {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.trialing = false
+}
+
+// updateState half opens the circuit if it has been open for longer than the timeout.
+func (b *CircuitBreaker) updateState(now time.Time) {
+	if b.state == CircuitStateOpen && now.Sub(b.openedAt) >= b.OpenTimeout {
+		b.setState(CircuitStateHalfOpen)
+	}
+}
+
+func (b *CircuitBreaker) setState(state CircuitState) {
+	if state == b.state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+	if b.OnStateChange != nil {
+		b.OnStateChange(b.Name, from, state)
+	}
+}
+
+func (b *CircuitBreaker) openError() *CircuitOpenError {
+	return &CircuitOpenError{
+		Name:     b.Name,
+		State:    b.state,
+		Failures: b.failures,
+		RetryAt:  b.openedAt.Add(b.OpenTimeout),
+	}
+}
+
+// NewCircuitBreakerTransport creates a transport that makes requests using another transport,
+// unless the circuit breaker is open. Requests that should be retried (see ShouldRetry) are
+// recorded as failures. If transport is nil, the default transport is used.
+func NewCircuitBreakerTransport(transport http.RoundTripper, breaker *CircuitBreaker) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &circuitBreakerTransport{transport: transport, breaker: breaker}
+}
+
+type circuitBreakerTransport struct {
+	transport http.RoundTripper
+	breaker   *CircuitBreaker
+}
+
+func (t *circuitBreakerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	trial, err := t.breaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := t.transport.RoundTrip(request)
+
+	// Requests cancelled by the caller say nothing about the server
+	if err != nil && request.Context().Err() != nil {
+		t.breaker.Release(trial)
+		return response, err
+	}
+
+	t.breaker.Record(trial, !ShouldRetry(response, err))
+
+	return response, err
+}
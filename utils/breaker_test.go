@@ -0,0 +1,147 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var stateChanges []string
+	breaker := utils.NewCircuitBreaker("test", 2, 50*time.Millisecond)
+	breaker.OnStateChange = func(_ string, from, to utils.CircuitState) {
+		stateChanges = append(stateChanges, from.Value+"->"+to.Value)
+	}
+
+	// Circuit should open after consecutive failures
+	trial, err := breaker.Allow()
+	require.NoError(t, err)
+	require.False(t, trial)
+	breaker.Record(trial, false)
+	trial, err = breaker.Allow()
+	require.NoError(t, err)
+	breaker.Record(trial, false)
+	require.Equal(t, utils.CircuitStateOpen, breaker.State())
+
+	// Requests should fail while circuit is open
+	_, err = breaker.Allow()
+	var circuitOpenErr *utils.CircuitOpenError
+	require.ErrorAs(t, err, &circuitOpenErr)
+	require.Equal(t, "test", circuitOpenErr.Name)
+	require.Equal(t, 2, circuitOpenErr.Failures)
+
+	// After the timeout, a single trial request should be allowed
+	time.Sleep(60 * time.Millisecond)
+	require.Equal(t, utils.CircuitStateHalfOpen, breaker.State())
+	trial, err = breaker.Allow()
+	require.NoError(t, err)
+	require.True(t, trial)
+	_, err = breaker.Allow()
+	require.Error(t, err)
+
+	// Failed trial should open the circuit again
+	breaker.Record(trial, false)
+	require.Equal(t, utils.CircuitStateOpen, breaker.State())
+
+	// Successful trial should close the circuit
+	time.Sleep(60 * time.Millisecond)
+	trial, err = breaker.Allow()
+	require.NoError(t, err)
+	require.True(t, trial)
+	breaker.Record(trial, true)
+	require.Equal(t, utils.CircuitStateClosed, breaker.State())
+
+	require.Equal(
+		t,
+		[]string{
+			"closed->open",
+			"open->half-open",
+			"half-open->open",
+			"open->half-open",
+			"half-open->closed",
+		},
+		stateChanges,
+	)
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	breaker := utils.NewCircuitBreaker("test", 2, time.Minute)
+	breaker.Record(false, false)
+	breaker.Record(false, true)
+	breaker.Record(false, false)
+	require.Equal(t, utils.CircuitStateClosed, breaker.State())
+}
+
+func TestCircuitBreakerOnlyTrialAllowsTrial(t *testing.T) {
+	breaker := utils.NewCircuitBreaker("test", 1, 50*time.Millisecond)
+
+	// Requests allowed before the circuit opens are not trials
+	firstTrial, err := breaker.Allow()
+	require.NoError(t, err)
+	require.False(t, firstTrial)
+	secondTrial, err := breaker.Allow()
+	require.NoError(t, err)
+	require.False(t, secondTrial)
+
+	breaker.Record(firstTrial, false)
+	time.Sleep(60 * time.Millisecond)
+	trial, err := breaker.Allow()
+	require.NoError(t, err)
+	require.True(t, trial)
+
+	// Request allowed before the circuit opened should not allow another trial request
+	breaker.Record(secondTrial, false)
+	time.Sleep(60 * time.Millisecond)
+	_, err = breaker.Allow()
+	require.Error(t, err)
+
+	// Trial request should allow another trial request
+	breaker.Record(trial, false)
+	time.Sleep(60 * time.Millisecond)
+	trial, err = breaker.Allow()
+	require.NoError(t, err)
+	require.True(t, trial)
+}
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	breaker := utils.NewCircuitBreaker("test", 1, time.Minute)
+	client := &http.Client{Transport: utils.NewCircuitBreakerTransport(nil, breaker)}
+
+	// First request fails, opening the circuit
+	response, err := client.Get(server.URL) //nolint:noctx
+	require.NoError(t, err)
+	response.Body.Close()
+	require.Equal(t, http.StatusBadGateway, response.StatusCode)
+
+	// Second request is not made
+	_, err = client.Get(server.URL) //nolint:noctx
+	var circuitOpenErr *utils.CircuitOpenError
+	require.ErrorAs(t, err, &circuitOpenErr)
+}
+
+func TestCircuitBreakerTransportCancelled(t *testing.T) {
+	breaker := utils.NewCircuitBreaker("test", 1, time.Minute)
+	client := &http.Client{Transport: utils.NewCircuitBreakerTransport(nil, breaker)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost:1", http.NoBody)
+	require.NoError(t, err)
+
+	// Cancelled requests should not open the circuit
+	_, err = client.Do(request) //nolint:bodyclose
+	require.True(t, errors.Is(err, context.Canceled))
+	require.Equal(t, utils.CircuitStateClosed, breaker.State())
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// SearchRequestLimit is the maximum number of requests a search makes, over all the ways it searches.
+const SearchRequestLimit = 250
+
+// ErrRequestLimit is returned when a request is not made because the request limit of its context is reached.
+var ErrRequestLimit = errors.New("request limit reached")
+
+type requestBudgetKey struct{}
+
+// WithRequestLimit returns a context limiting the number of requests made with it (or any context derived
+// from it) to limit, replacing any existing limit. If limit <= 0, there is no limit.
+// Use HasRequestLimit to only limit contexts that are not already limited.
+func WithRequestLimit(ctx context.Context, limit int) context.Context {
+	if limit <= 0 {
+		return ctx
+	}
+
+	remaining := new(atomic.Int64)
+	remaining.Store(int64(limit))
+	return context.WithValue(ctx, requestBudgetKey{}, remaining)
+}
+
+// HasRequestLimit returns whether a context limits the number of requests made with it.
+func HasRequestLimit(ctx context.Context) bool {
+	return ctx.Value(requestBudgetKey{}) != nil
+}
+
+// TakeRequest takes a request from the request limit of a context, and must be called before each request.
+// Will return ErrRequestLimit if the limit is reached, in which case the request must not be made.
+func TakeRequest(ctx context.Context) error {
+	remaining, ok := ctx.Value(requestBudgetKey{}).(*atomic.Int64)
+	if ok && remaining.Add(-1) < 0 {
+		return ErrRequestLimit
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLimit(t *testing.T) {
+	ctx := utils.WithRequestLimit(context.Background(), 2)
+	require.NoError(t, utils.TakeRequest(ctx))
+
+	// Derived contexts share the limit
+	derivedCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	require.NoError(t, utils.TakeRequest(derivedCtx))
+	require.ErrorIs(t, utils.TakeRequest(derivedCtx), utils.ErrRequestLimit)
+	require.ErrorIs(t, utils.TakeRequest(ctx), utils.ErrRequestLimit)
+
+	// Limits replace the existing limit
+	require.NoError(t, utils.TakeRequest(utils.WithRequestLimit(ctx, 1)))
+
+	// Contexts without a limit are unlimited
+	require.True(t, utils.HasRequestLimit(ctx))
+	require.False(t, utils.HasRequestLimit(context.Background()))
+	require.NoError(t, utils.TakeRequest(context.Background()))
+	require.NoError(t, utils.TakeRequest(utils.WithRequestLimit(context.Background(), 0)))
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatPartialDate formats a date in ISO 8601 format, only including the parts of the date that are known.
+// e.g. "2006-01-02" if year, month and day are known, "2006-01" if only year and month are known,
+// or "2006" if only year is known. Unknown parts should be 0.
+// If the year is unknown, or the date is invalid, an empty string is returned.
+func FormatPartialDate(year, month, day int) string {
+	if year <= 0 || year > 9999 {
+		return ""
+	}
+	if month < 1 || month > 12 {
+		return fmt.Sprintf("%04d", year)
+	}
+
+	// Make sure the day is valid for the month.
+	// Normalisation by time.Date will change the month if not
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if day < 1 || date.Month() != time.Month(month) {
+		return fmt.Sprintf("%04d-%02d", year, month)
+	}
+
+	return date.Format(time.DateOnly)
+}
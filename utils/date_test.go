@@ -0,0 +1,20 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatPartialDate(t *testing.T) {
+	require.Equal(t, "1937-09-21", utils.FormatPartialDate(1937, 9, 21))
+	require.Equal(t, "1937-09", utils.FormatPartialDate(1937, 9, 0))
+	require.Equal(t, "1937", utils.FormatPartialDate(1937, 0, 21))
+	require.Equal(t, "1937", utils.FormatPartialDate(1937, 0, 0))
+	require.Equal(t, "0800", utils.FormatPartialDate(800, 0, 0))
+	require.Equal(t, "2001-02", utils.FormatPartialDate(2001, 2, 30)) // Invalid day
+	require.Equal(t, "2001", utils.FormatPartialDate(2001, 13, 1))    // Invalid month
+	require.Empty(t, utils.FormatPartialDate(0, 9, 21))
+	require.Empty(t, utils.FormatPartialDate(-300, 0, 0))
+}
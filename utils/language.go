@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// languageNames maps the lowercase english names of languages to their base language e.g. "german" to "de"
+var languageNames = func() map[string]language.Base {
+	namer := display.English.Languages()
+
+	baseLanguages := display.Values.BaseLanguages()
+	names := make(map[string]language.Base, len(baseLanguages))
+	for _, base := range baseLanguages {
+		name := namer.Name(base)
+		if name != "" {
+			names[strings.ToLower(name)] = base
+		}
+	}
+
+	return names
+}()
+
+// ParseLanguage parses a language from either a code (e.g. "de", "ger" or "en-GB")
+// or an english name of the language (e.g. "German").
+// Will return an error if the language is unknown.
+func ParseLanguage(value string) (language.Tag, error) {
+	value = strings.TrimSpace(value)
+
+	tag, err := language.Parse(value)
+	if err == nil {
+		return tag, nil
+	}
+
+	base, ok := languageNames[strings.ToLower(value)]
+	if ok {
+		return language.Make(base.String()), nil
+	}
+
+	return language.Und, fmt.Errorf("unknown language: %s", value)
+}
+
+// ParseLanguages parses languages from codes or english names. See ParseLanguage.
+func ParseLanguages(values []string) ([]language.Tag, error) {
+	tags := make([]language.Tag, 0, len(values))
+	for _, value := range values {
+		tag, err := ParseLanguage(value)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// LanguageName gets the english name of the base of a language, ignoring its region e.g. "German".
+func LanguageName(tag language.Tag) string {
+	base, confidence := tag.Base()
+	if confidence != language.Exact {
+		return ""
+	}
+	return display.English.Languages().Name(base)
+}
+
+// SameLanguage returns whether two languages have the same base, ignoring their region.
+// e.g. "en-GB" and "eng" are the same language. Unknown languages are never the same.
+func SameLanguage(tag, other language.Tag) bool {
+	base, confidence := tag.Base()
+	otherBase, otherConfidence := other.Base()
+	return confidence == language.Exact && otherConfidence == language.Exact && base == otherBase
+}
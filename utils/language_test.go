@@ -0,0 +1,42 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestParseLanguage(t *testing.T) {
+	tests := map[string]language.Tag{
+		"de":      language.German,
+		"ger":     language.German,
+		"en-GB":   language.BritishEnglish,
+		"English": language.English,
+		" french": language.French,
+	}
+	for value, expected := range tests {
+		tag, err := utils.ParseLanguage(value)
+		require.NoError(t, err, value)
+		require.Equal(t, expected, tag, value)
+	}
+
+	_, err := utils.ParseLanguage("Elvish")
+	require.Error(t, err)
+
+	_, err = utils.ParseLanguage("")
+	require.Error(t, err)
+}
+
+func TestLanguageName(t *testing.T) {
+	require.Equal(t, "German", utils.LanguageName(language.German))
+	require.Equal(t, "English", utils.LanguageName(language.BritishEnglish))
+	require.Empty(t, utils.LanguageName(language.Und))
+}
+
+func TestSameLanguage(t *testing.T) {
+	require.True(t, utils.SameLanguage(language.BritishEnglish, language.Make("eng")))
+	require.False(t, utils.SameLanguage(language.English, language.German))
+	require.False(t, utils.SameLanguage(language.Und, language.Und))
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRetryPolicy is a sensible policy for retrying requests to providers.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 2,
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+	Jitter:     0.5,
+}
+
+// RetryPolicy is a policy for retrying failed http requests.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried.
+	MaxRetries int
+
+	// MinBackoff is the time waited before the first retry.
+	// The time waited doubles for each following retry.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum time waited before a retry.
+	// If a server asks for a longer wait using the Retry-After header, the request is not retried.
+	// If unset, the time waited is not limited.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (between 0 and 1) of the time waited before a retry that is random.
+	// This stops many failed requests being retried at the same time.
+	Jitter float64
+
+	// Retryable returns whether a request should be retried, given its response and error.
+	// If unset, ShouldRetry is used.
+	Retryable func(response *http.Response, err error) bool
+}
+
+// ShouldRetry returns whether a request should be retried under the policy, given its response and error.
+func (p RetryPolicy) ShouldRetry(response *http.Response, err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(response, err)
+	}
+	return ShouldRetry(response, err)
+}
+
+// Backoff returns the time to wait before a retry. The first retry is 1.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+
+	backoff := p.MinBackoff
+	for range retry - 1 {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	return backoff
+}
+
+// Wait returns the time to wait before a retry, and whether the retry should be made.
+// If the response of the failed request has a Retry-After header, it is honoured.
+// Otherwise the time waited is the backoff of the retry, with jitter applied.
+func (p RetryPolicy) Wait(retry int, response *http.Response) (time.Duration, bool) {
+	if response != nil {
+		retryAfter, ok := ParseRetryAfter(response.Header.Get("Retry-After"), time.Now())
+		if ok {
+			if p.MaxBackoff > 0 && retryAfter > p.MaxBackoff {
+				return 0, false
+			}
+			return retryAfter, true
+		}
+	}
+
+	backoff := p.Backoff(retry)
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1) * float64(backoff)
+		backoff -= time.Duration(rand.Float64() * jitter)
+	}
+
+	return backoff, true
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, returning the time to wait.
+// The value can either be a number of seconds, or a http date.
+// Returns false if the value is empty or invalid.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+// ShouldRetry returns whether a request should be retried, given its response and error.
+// Requests are retried if they failed to be made (unless cancelled), were rate limited,
+// or the server returned an error.
+func ShouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	if response == nil {
+		return false
+	}
+
+	return response.StatusCode == http.StatusTooManyRequests ||
+		(response.StatusCode >= 500 && response.StatusCode != http.StatusNotImplemented)
+}
+
+// NewRetryTransport creates a transport that retries requests made using another transport,
+// according to a retry policy. If transport is nil, the default transport is used.
+func NewRetryTransport(transport http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &retryTransport{transport: transport, policy: policy}
+}
+
+type retryTransport struct {
+	transport http.RoundTripper
+	policy    RetryPolicy
+}
+
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	for retry := 1; ; retry++ {
+		response, err := t.transport.RoundTrip(request)
+		if retry > t.policy.MaxRetries || !t.policy.ShouldRetry(response, err) {
+			return response, err
+		}
+
+		wait, ok := t.policy.Wait(retry, response)
+		if !ok {
+			return response, err
+		}
+
+		// Requests with a body can only be retried if the body can be read again
+		hasBody := request.Body != nil && request.Body != http.NoBody
+		if hasBody && request.GetBody == nil {
+			return response, err
+		}
+
+		// Discard the response of the failed request so its connection can be reused
+		if response != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		// Transports must not modify requests, so retry using a clone
+		if hasBody {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request = request.Clone(request.Context())
+			request.Body = body
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
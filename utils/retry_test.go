@@ -0,0 +1,126 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ahobsonsayers/abs-tract/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := utils.RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	require.Equal(t, time.Second, policy.Backoff(0))
+	require.Equal(t, time.Second, policy.Backoff(1))
+	require.Equal(t, 2*time.Second, policy.Backoff(2))
+	require.Equal(t, 4*time.Second, policy.Backoff(3))
+	require.Equal(t, 5*time.Second, policy.Backoff(4))
+	require.Equal(t, 5*time.Second, policy.Backoff(100))
+}
+
+func TestRetryPolicyWait(t *testing.T) {
+	policy := utils.RetryPolicy{MinBackoff: time.Second, MaxBackoff: 10 * time.Second, Jitter: 0.5}
+
+	// Jitter should reduce wait by up to half
+	for range 100 {
+		wait, ok := policy.Wait(2, nil)
+		require.True(t, ok)
+		require.GreaterOrEqual(t, wait, time.Second)
+		require.LessOrEqual(t, wait, 2*time.Second)
+	}
+
+	// Retry-After should be honoured
+	response := &http.Response{Header: http.Header{"Retry-After": {"3"}}}
+	wait, ok := policy.Wait(1, response)
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, wait)
+
+	// Retry-After longer than the maximum backoff should not be retried
+	response = &http.Response{Header: http.Header{"Retry-After": {"60"}}}
+	_, ok = policy.Wait(1, response)
+	require.False(t, ok)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	wait, ok := utils.ParseRetryAfter("120", now)
+	require.True(t, ok)
+	require.Equal(t, 2*time.Minute, wait)
+
+	wait, ok = utils.ParseRetryAfter("Mon, 01 Jan 2024 12:00:30 GMT", now)
+	require.True(t, ok)
+	require.Equal(t, 30*time.Second, wait)
+
+	wait, ok = utils.ParseRetryAfter("Mon, 01 Jan 2024 11:00:00 GMT", now)
+	require.True(t, ok)
+	require.Zero(t, wait)
+
+	_, ok = utils.ParseRetryAfter("", now)
+	require.False(t, ok)
+	_, ok = utils.ParseRetryAfter("-1", now)
+	require.False(t, ok)
+	_, ok = utils.ParseRetryAfter("soon", now)
+	require.False(t, ok)
+}
+
+func TestShouldRetry(t *testing.T) {
+	require.True(t, utils.ShouldRetry(nil, errors.New("connection reset")))
+	require.False(t, utils.ShouldRetry(nil, context.Canceled))
+	require.True(t, utils.ShouldRetry(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	require.True(t, utils.ShouldRetry(&http.Response{StatusCode: http.StatusBadGateway}, nil))
+	require.False(t, utils.ShouldRetry(&http.Response{StatusCode: http.StatusNotImplemented}, nil))
+	require.False(t, utils.ShouldRetry(&http.Response{StatusCode: http.StatusNotFound}, nil))
+	require.False(t, utils.ShouldRetry(&http.Response{StatusCode: http.StatusOK}, nil))
+}
+
+func TestRetryTransport(t *testing.T) {
+	// Server always fails
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: utils.NewRetryTransport(nil, utils.RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond}),
+	}
+	response, err := client.Get(server.URL) //nolint:noctx
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	require.EqualValues(t, 3, requests.Load())
+}
+
+func TestRetryTransportRetryAfter(t *testing.T) {
+	// Server rate limits the first request
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: utils.NewRetryTransport(nil, utils.RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond}),
+	}
+
+	start := time.Now()
+	response, err := client.Get(server.URL) //nolint:noctx
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+}